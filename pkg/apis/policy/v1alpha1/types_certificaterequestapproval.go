@@ -0,0 +1,87 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var CertificateRequestApprovalKind = "CertificateRequestApproval"
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+//+kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="CertificateRequest",type="string",JSONPath=".spec.certificateRequestName",description="CertificateRequest being approved"
+// +kubebuilder:printcolumn:name="Username",type="string",JSONPath=".spec.username",description="User that approved the CertificateRequest"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Timestamp CertificateRequestApproval was created"
+//+kubebuilder:resource:categories=cert-manager,shortName=cra,scope=Namespaced
+
+// CertificateRequestApproval records the manual approval of a
+// CertificateRequest by a user. CertificateRequestApprovals are consumed by
+// the manual-approval plugin of CertificateRequestPolicies. The approving user
+// is recorded by the approver-policy webhook from the user that created the
+// CertificateRequestApproval, and cannot be changed.
+type CertificateRequestApproval struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CertificateRequestApprovalSpec `json:"spec"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// CertificateRequestApprovalList is a list of CertificateRequestApprovals.
+type CertificateRequestApprovalList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CertificateRequestApproval `json:"items"`
+}
+
+// CertificateRequestApprovalSpec defines the CertificateRequest being approved,
+// and the user approving it.
+type CertificateRequestApprovalSpec struct {
+	// CertificateRequestName is the name of the CertificateRequest in the same
+	// namespace that is approved.
+	// +kubebuilder:validation:MinLength=1
+	CertificateRequestName string `json:"certificateRequestName"`
+
+	// CertificateRequestUID is the UID of the CertificateRequest that is
+	// approved. If defined, the approval only applies to the CertificateRequest
+	// with this UID, preventing the approval from applying to a
+	// CertificateRequest re-created with the same name.
+	// +optional
+	CertificateRequestUID types.UID `json:"certificateRequestUID,omitempty"`
+
+	// Username is the name of the user that approved the CertificateRequest.
+	// Populated by the approver-policy webhook from the user creating the
+	// CertificateRequestApproval. If set on creation, it must match the
+	// creating user.
+	// An approval is ignored if Username is the user that created the
+	// CertificateRequest. This self-approval restriction has no effect on
+	// CertificateRequests created by cert-manager from a Certificate, since
+	// their requester is cert-manager and not the author of the Certificate.
+	// +optional
+	Username string `json:"username,omitempty"`
+
+	// Groups are the groups of the user that approved the CertificateRequest.
+	// Populated by the approver-policy webhook from the user creating the
+	// CertificateRequestApproval. If set on creation, they must match the
+	// groups of the creating user.
+	// +listType=atomic
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+}
@@ -29,6 +29,7 @@ var CertificateRequestPolicyKind = "CertificateRequestPolicy"
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 //+kubebuilder:object:root=true
 // +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=`.status.conditions[?(@.type == "Ready")].status`,description="CertificateRequestPolicy is ready for evaluation"
+// +kubebuilder:printcolumn:name="Drifted",type="integer",JSONPath=".status.compliance.driftedRequests",description="Number of approved CertificateRequests that would no longer be approved",priority=1
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Timestamp CertificateRequestPolicy was created"
 //+kubebuilder:resource:categories=cert-manager,shortName=crp,scope=Cluster
 //+kubebuilder:subresource:status
@@ -84,8 +85,66 @@ type CertificateRequestPolicySpec struct {
 	// CertificateRequestPolicy is appropriate for and so will be used for its
 	// approval evaluation.
 	Selector CertificateRequestPolicySelector `json:"selector"`
+
+	// DefaultAction is the action taken on CertificateRequests that are
+	// selected by this policy, but whose requester is not bound to this
+	// policy, and for which no other policy is applicable.
+	// "Ignore" leaves the request unprocessed, so it is neither approved nor
+	// denied. "Deny" denies the request.
+	// Defaults to "Ignore".
+	// +kubebuilder:validation:Enum=Ignore;Deny
+	// +optional
+	DefaultAction CertificateRequestPolicyDefaultAction `json:"defaultAction,omitempty"`
+
+	// Defaults are the values set on CertificateRequests, and optionally
+	// Certificates, which are created without them by requesters this policy
+	// is bound to, so that requests do not need to repeat them to satisfy the
+	// policy. Defaults are applied by the approver-policy mutating webhook,
+	// using the defaults of the highest priority policy that is ready,
+	// selects and is bound to the request.
+	// An omitted field applies no defaults.
+	// +optional
+	Defaults *CertificateRequestPolicyDefaults `json:"defaults,omitempty"`
+}
+
+// CertificateRequestPolicyDefaults defines the values set on requests which
+// are created without them. An omitted `isCA` is always `false`, so is not
+// defaulted.
+type CertificateRequestPolicyDefaults struct {
+	// Priority of these defaults. When multiple policies with defaults apply
+	// to a request, the defaults of the policy with the highest priority are
+	// used. Policies of equal priority are ordered by name.
+	// Defaults to `0`.
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+
+	// Duration is set on requests which do not request a duration.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// Usages are set on requests which do not request any usages. Usages are
+	// not defaulted on CertificateRequests whose CSR requests key usages or
+	// extended key usages, since they must match.
+	// +optional
+	Usages []cmapi.KeyUsage `json:"usages,omitempty"`
 }
 
+// CertificateRequestPolicyDefaultAction is the action taken on
+// CertificateRequests that are selected by a CertificateRequestPolicy, but
+// whose requester is not bound to it.
+type CertificateRequestPolicyDefaultAction string
+
+const (
+	// CertificateRequestPolicyDefaultActionIgnore leaves the request
+	// unprocessed.
+	// +k8s:deepcopy-gen=false
+	CertificateRequestPolicyDefaultActionIgnore CertificateRequestPolicyDefaultAction = "Ignore"
+
+	// CertificateRequestPolicyDefaultActionDeny denies the request.
+	// +k8s:deepcopy-gen=false
+	CertificateRequestPolicyDefaultActionDeny CertificateRequestPolicyDefaultAction = "Deny"
+)
+
 // CertificateRequestPolicyAllowed defines the allowed attributes for a
 // CertificateRequest.
 // A CertificateRequest can request _less_ than what is allowed,
@@ -114,6 +173,19 @@ type CertificateRequestPolicyAllowed struct {
 	// +optional
 	EmailAddresses *CertificateRequestPolicyAllowedStringSlice `json:"emailAddresses,omitempty"`
 
+	// OtherNames defines the X.509 otherName SANs that may be requested, such
+	// as Microsoft User Principal Names (UPN), by the OID of their type.
+	// Requested otherName SANs of a type not defined here are denied.
+	// +listType=map
+	// +listMapKey=oid
+	// +optional
+	OtherNames []CertificateRequestPolicyAllowedOtherName `json:"otherNames,omitempty"`
+
+	// RegisteredIDs defines the X.509 registeredID SANs that may be requested,
+	// as OIDs in dotted decimal form.
+	// +optional
+	RegisteredIDs *CertificateRequestPolicyAllowedStringSlice `json:"registeredIDs,omitempty"`
+
 	// IsCA defines if a CertificateRequest is allowed to set the `spec.isCA`
 	// field set to `true`.
 	// If `true`, the `spec.isCA` field can be `true` or `false`.
@@ -179,6 +251,34 @@ type CertificateRequestPolicyAllowedX509Subject struct {
 	// requested.
 	// +optional
 	SerialNumber *CertificateRequestPolicyAllowedString `json:"serialNumber,omitempty"`
+
+	// ExtraAttributes defines the X.509 Subject attributes that may be
+	// requested which do not have a dedicated field, by the OID of their
+	// type. Requested Subject attributes of a type not defined here, or in a
+	// dedicated field, are denied.
+	// +listType=map
+	// +listMapKey=oid
+	// +optional
+	ExtraAttributes []CertificateRequestPolicyAllowedX509SubjectAttribute `json:"extraAttributes,omitempty"`
+
+	// DN defines the full X.509 Subject Distinguished Name that may be
+	// requested, in its RFC 4514 string form, e.g.
+	// `CN=foo,OU=bar,O=example,C=GB`. The value accepts wildcards "*", and
+	// validations are evaluated with `self` as the full Distinguished Name.
+	// The Distinguished Name is evaluated in addition to the individual
+	// Subject attributes.
+	// +optional
+	DN *CertificateRequestPolicyAllowedString `json:"dn,omitempty"`
+}
+
+// CertificateRequestPolicyAllowedX509SubjectAttribute defines the allowed
+// values of an X.509 Subject attribute type.
+type CertificateRequestPolicyAllowedX509SubjectAttribute struct {
+	// OID is the object identifier of the Subject attribute type in dotted
+	// decimal form, e.g. `1.2.840.113549.1.9.1` for an email address.
+	OID string `json:"oid"`
+
+	CertificateRequestPolicyAllowedStringSlice `json:",inline"`
 }
 
 // CertificateRequestPolicyAllowedStringSlice represents allowed string values
@@ -211,6 +311,18 @@ type CertificateRequestPolicyAllowedStringSlice struct {
 	Validations []ValidationRule `json:"validations,omitempty"`
 }
 
+// CertificateRequestPolicyAllowedOtherName defines the allowed values of the
+// X.509 otherName SANs of a type.
+type CertificateRequestPolicyAllowedOtherName struct {
+	// OID is the object identifier of the otherName type in dotted decimal
+	// form, e.g. `1.3.6.1.4.1.311.20.2.3` for a User Principal Name.
+	OID string `json:"oid"`
+
+	// Values, Required and Validations apply to the string values of the
+	// otherName SANs of this type.
+	CertificateRequestPolicyAllowedStringSlice `json:",inline"`
+}
+
 // CertificateRequestPolicyAllowedString represents an allowed string value
 // and/or validations paired with whether the field is a required value on the request.
 // If no allowed value nor validations are specified, the related field must be empty.
@@ -288,11 +400,182 @@ type CertificateRequestPolicyConstraints struct {
 	// +optional
 	MaxDuration *metav1.Duration `json:"maxDuration,omitempty"`
 
+	// AssumeDefaultDuration, if true, evaluates CertificateRequests which do
+	// not request a duration as requesting cert-manager's default duration of
+	// `2160h` (90 days), which cert-manager issues for requests without a
+	// duration. MinDuration, MaxDuration, WithinIssuerCAValidity and
+	// RenewBefore are then evaluated against the default duration for
+	// requests without a duration, rather than denying them.
+	// An omitted field requires a duration to be requested when MinDuration or
+	// MaxDuration are set.
+	// +optional
+	AssumeDefaultDuration *bool `json:"assumeDefaultDuration,omitempty"`
+
+	// WithinIssuerCAValidity, if true, requires that the certificate would not
+	// be valid beyond the expiry of the CA certificate of its issuer. The CA
+	// certificate is read from the Secret of the referenced CA Issuer or
+	// ClusterIssuer. Requests referencing issuers which are not CA issuers
+	// are denied, as are all requests if approver-policy is not run with
+	// `--enable-issuer-ca-validity`.
+	// Defaults to `false`.
+	// +optional
+	WithinIssuerCAValidity *bool `json:"withinIssuerCAValidity,omitempty"`
+
+	// RenewBefore defines constraints on the percentage of the certificate
+	// duration before expiry at which it is renewed, as annotated on the
+	// CertificateRequest by its Certificate with `cert-manager.io/renew-before`
+	// or `cert-manager.io/renew-before-percentage`.
+	// Requests without either annotation are not constrained.
+	// An omitted field applies no renewal constraints.
+	// +optional
+	RenewBefore *CertificateRequestPolicyConstraintsRenewBefore `json:"renewBefore,omitempty"`
+
 	// PrivateKey defines constraints on the shape of private key
 	// allowed for a CertificateRequest.
 	// An omitted field applies no private key shape constraints.
 	// +optional
 	PrivateKey *CertificateRequestPolicyConstraintsPrivateKey `json:"privateKey,omitempty"`
+
+	// Extensions defines constraints on the X.509 extensions requested in the
+	// CSR of a CertificateRequest.
+	// An omitted field applies no extension constraints.
+	// +optional
+	Extensions *CertificateRequestPolicyConstraintsExtensions `json:"extensions,omitempty"`
+
+	// CA defines constraints on requests for CA certificates, i.e.
+	// CertificateRequests with `spec.isCA` set to `true`.
+	// May only be defined if `spec.allowed.isCA` is `true`.
+	// An omitted field applies no CA constraints.
+	// +optional
+	CA *CertificateRequestPolicyConstraintsCA `json:"ca,omitempty"`
+}
+
+// CertificateRequestPolicyConstraintsRenewBefore defines constraints on the
+// renewal of certificates, as a percentage of their duration.
+type CertificateRequestPolicyConstraintsRenewBefore struct {
+	// MinPercentage is the minimum percentage of the certificate duration
+	// before expiry at which it must be renewed. Inclusive.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=99
+	// +optional
+	MinPercentage *int32 `json:"minPercentage,omitempty"`
+
+	// MaxPercentage is the maximum percentage of the certificate duration
+	// before expiry at which it may be renewed. Inclusive.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=99
+	// +optional
+	MaxPercentage *int32 `json:"maxPercentage,omitempty"`
+}
+
+// CertificateRequestPolicyConstraintsCA defines constraints on requests for
+// CA certificates.
+// The path length of CAs cannot be constrained, since cert-manager never
+// requests a path length in the CSR, and its issuers sign CAs without a path
+// length constraint.
+type CertificateRequestPolicyConstraintsCA struct {
+	// NameConstraints, if defined, requires the CSR to request the X.509 name
+	// constraints extension, restricting the names the CA may issue
+	// certificates for to within the given values.
+	// An omitted field does not require name constraints.
+	// +optional
+	NameConstraints *CertificateRequestPolicyConstraintsCANameConstraints `json:"nameConstraints,omitempty"`
+}
+
+// CertificateRequestPolicyConstraintsCANameConstraints defines the name
+// constraints that a CA must request.
+// For each name type, every permitted subtree requested must be within one
+// of the values defined for that type, and if values are defined, at least
+// one permitted subtree of that type must be requested. A CSR may not request
+// permitted subtrees of a name type with no values defined. Excluded subtrees
+// only narrow the names the CA may issue for, and so are always permitted.
+// Name types with no values defined are NOT constrained: a CA without
+// permitted subtrees of a name type may issue certificates for any name of
+// that type. Define values for every name type the CA must be restricted in,
+// e.g. permittedIPRanges in addition to permittedDNSDomains.
+type CertificateRequestPolicyConstraintsCANameConstraints struct {
+	// Critical requires the name constraints extension to be marked critical.
+	// +optional
+	Critical bool `json:"critical,omitempty"`
+
+	// PermittedDNSDomains defines the DNS domains that may be requested as
+	// permitted subtrees.
+	// Accepts wildcards "*".
+	// +listType=atomic
+	// +optional
+	PermittedDNSDomains []string `json:"permittedDNSDomains,omitempty"`
+
+	// PermittedIPRanges defines the IP ranges, in CIDR notation, that
+	// requested permitted IP ranges must be contained within.
+	// +listType=atomic
+	// +optional
+	PermittedIPRanges []string `json:"permittedIPRanges,omitempty"`
+
+	// PermittedEmailAddresses defines the email addresses and domains that
+	// may be requested as permitted subtrees.
+	// Accepts wildcards "*".
+	// +listType=atomic
+	// +optional
+	PermittedEmailAddresses []string `json:"permittedEmailAddresses,omitempty"`
+
+	// PermittedURIDomains defines the URI domains that may be requested as
+	// permitted subtrees.
+	// Accepts wildcards "*".
+	// +listType=atomic
+	// +optional
+	PermittedURIDomains []string `json:"permittedURIDomains,omitempty"`
+}
+
+// CertificateRequestPolicyConstraintsExtensions defines constraints on the
+// X.509 extensions requested in the CSR of a CertificateRequest.
+// When defined, a CSR requesting a basic constraints extension must agree
+// with the `spec.isCA` field of the CertificateRequest, and the key usages
+// and extended key usages requested in the CSR must be allowed by
+// `spec.allowed.usages`. The default usages `digital signature` and `key
+// encipherment` may always be requested by CertificateRequests which don't
+// request any usages, and `cert sign` by CAs, since cert-manager requests
+// them regardless.
+type CertificateRequestPolicyConstraintsExtensions struct {
+	// Allowed is the list of extensions that a CSR may request. If defined, a
+	// CSR requesting any other extension is denied. The subject alternative
+	// name, key usage, extended key usage and basic constraints extensions
+	// are always allowed, since their contents are evaluated against
+	// `spec.allowed`.
+	// An omitted field permits any extension.
+	// +listType=atomic
+	// +optional
+	Allowed []CertificateRequestPolicyConstraintsExtension `json:"allowed,omitempty"`
+
+	// Denied is the list of extensions that a CSR must not request. Denied
+	// takes precedence over Allowed, and may also deny the extensions which
+	// are always allowed.
+	// +listType=atomic
+	// +optional
+	Denied []CertificateRequestPolicyConstraintsExtension `json:"denied,omitempty"`
+
+	// ExtendedKeyUsages is the list of extended key usage OIDs that a CSR may
+	// request which have no corresponding cert-manager key usage, e.g.
+	// `1.3.6.1.4.1.311.20.2.2` for smart card logon. A CSR requesting any
+	// other such extended key usage is denied.
+	// Extended key usages with a corresponding cert-manager key usage must be
+	// allowed by `spec.allowed.usages`.
+	// +listType=set
+	// +optional
+	ExtendedKeyUsages []string `json:"extendedKeyUsages,omitempty"`
+}
+
+// CertificateRequestPolicyConstraintsExtension matches an X.509 extension
+// requested in a CSR.
+type CertificateRequestPolicyConstraintsExtension struct {
+	// OID is the object identifier of the extension in dotted decimal form,
+	// e.g. `2.5.29.30` for name constraints.
+	OID string `json:"oid"`
+
+	// Critical, if defined, only matches the extension if its critical flag
+	// has the same value.
+	// An omitted field matches the extension regardless of its critical flag.
+	// +optional
+	Critical *bool `json:"critical,omitempty"`
 }
 
 // CertificateRequestPolicyConstraintsPrivateKey defines constraints on the shape of private key
@@ -317,6 +600,53 @@ type CertificateRequestPolicyConstraintsPrivateKey struct {
 	// An omitted field applies no maximum constraint on size.
 	// +optional
 	MaxSize *int `json:"maxSize,omitempty"`
+
+	// Curves defines the allowed elliptic curves for an ECDSA private key.
+	// Accepted values are `P-256`, `P-384` and `P-521`.
+	// Private keys of other algorithms are not constrained by this field.
+	// An omitted field permits any curve.
+	// +optional
+	Curves []string `json:"curves,omitempty"`
+
+	// SignatureAlgorithms defines the allowed signature algorithms that the
+	// request may be signed with, e.g. `SHA256-RSA`, `SHA256-RSAPSS`,
+	// `ECDSA-SHA384` or `Ed25519`. This can be used to forbid weak algorithms
+	// such as `SHA1-RSA`, or to require RSASSA-PSS.
+	// An omitted field permits any signature algorithm.
+	// +optional
+	SignatureAlgorithms []string `json:"signatureAlgorithms,omitempty"`
+
+	// AnyOf defines a list of allowed combinations of private key algorithm
+	// and parameters. The private key of a request must match at least one of
+	// the entries, e.g. RSA of at least 3072 bits, OR ECDSA using the P-256 or
+	// P-384 curves, OR Ed25519. AnyOf is applied in addition to the other
+	// private key constraints.
+	// An omitted field applies no combined constraint.
+	// +optional
+	AnyOf []CertificateRequestPolicyConstraintsPrivateKeyOption `json:"anyOf,omitempty"`
+}
+
+// CertificateRequestPolicyConstraintsPrivateKeyOption defines an allowed
+// combination of private key algorithm and parameters.
+type CertificateRequestPolicyConstraintsPrivateKeyOption struct {
+	// Algorithm defines the crypto algorithm of the private key.
+	Algorithm cmapi.PrivateKeyAlgorithm `json:"algorithm"`
+
+	// MinSize defines the minimum key size for the private key, inclusive.
+	// An omitted field applies no minimum constraint on size.
+	// +optional
+	MinSize *int `json:"minSize,omitempty"`
+
+	// MaxSize defines the maximum key size for the private key, inclusive.
+	// An omitted field applies no maximum constraint on size.
+	// +optional
+	MaxSize *int `json:"maxSize,omitempty"`
+
+	// Curves defines the allowed elliptic curves when Algorithm is `ECDSA`.
+	// Accepted values are `P-256`, `P-384` and `P-521`.
+	// An omitted field permits any curve.
+	// +optional
+	Curves []string `json:"curves,omitempty"`
 }
 
 // CertificateRequestPolicyPluginData is configuration needed by the plugin
@@ -405,17 +735,141 @@ type CertificateRequestPolicySelectorNamespace struct {
 type CertificateRequestPolicyStatus struct {
 	// List of status conditions to indicate the status of the
 	// CertificateRequestPolicy.
-	// Known condition types are `Ready`.
+	// Known condition types are `Ready` and `Overlapping`.
 	// +listType=map
 	// +listMapKey=type
 	// +optional
 	Conditions []CertificateRequestPolicyCondition `json:"conditions,omitempty"`
+
+	// Compliance is the result of the most recent re-review of the
+	// CertificateRequests approved by this CertificateRequestPolicy against the
+	// current set of CertificateRequestPolicies. Only populated when the
+	// compliance check is enabled.
+	// +optional
+	Compliance *CertificateRequestPolicyCompliance `json:"compliance,omitempty"`
+
+	// Statistics reports how often this CertificateRequestPolicy is used to
+	// approve and deny CertificateRequests, and how widely it is bound. Only
+	// populated when policy statistics are enabled.
+	// +optional
+	Statistics *CertificateRequestPolicyStatistics `json:"statistics,omitempty"`
+}
+
+// CertificateRequestPolicyStatistics reports the usage of a
+// CertificateRequestPolicy.
+type CertificateRequestPolicyStatistics struct {
+	// LastUpdateTime is the time the statistics were last updated.
+	// +optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+
+	// ApprovedCount is the number of CertificateRequests approved by this
+	// CertificateRequestPolicy.
+	ApprovedCount int64 `json:"approvedCount"`
+
+	// LastApprovedTime is the time a CertificateRequest was last approved by
+	// this CertificateRequestPolicy.
+	// +optional
+	LastApprovedTime *metav1.Time `json:"lastApprovedTime,omitempty"`
+
+	// DeniedCount is the number of CertificateRequests denied which this
+	// CertificateRequestPolicy was applicable to, but did not approve.
+	DeniedCount int64 `json:"deniedCount"`
+
+	// LastDeniedTime is the time a CertificateRequest was last denied which
+	// this CertificateRequestPolicy was applicable to.
+	// +optional
+	LastDeniedTime *metav1.Time `json:"lastDeniedTime,omitempty"`
+
+	// LastDenialReason lists the fields of this CertificateRequestPolicy which
+	// the last denied CertificateRequest did not satisfy.
+	// +optional
+	LastDenialReason string `json:"lastDenialReason,omitempty"`
+
+	// BoundNamespaces is the number of namespaces in which a requester is
+	// bound to this CertificateRequestPolicy via RBAC.
+	BoundNamespaces int32 `json:"boundNamespaces"`
+
+	// BoundSubjects is the number of users, groups and service accounts which
+	// are bound to this CertificateRequestPolicy via RBAC.
+	BoundSubjects int32 `json:"boundSubjects"`
+
+	// Subjects lists the users, groups and service accounts which are bound to
+	// this CertificateRequestPolicy via RBAC, and the namespaces they are bound
+	// in. Only populated when listing subjects is enabled. The list is
+	// truncated to the first 50 subjects, ordered by kind, namespace and name.
+	// +listType=atomic
+	// +optional
+	Subjects []CertificateRequestPolicyBoundSubject `json:"subjects,omitempty"`
+}
+
+// CertificateRequestPolicyBoundSubject is a user, group or service account
+// which is bound to a CertificateRequestPolicy via RBAC.
+type CertificateRequestPolicyBoundSubject struct {
+	// Kind of the subject, one of `User`, `Group` or `ServiceAccount`.
+	Kind string `json:"kind"`
+
+	// Name of the subject.
+	Name string `json:"name"`
+
+	// Namespace of the subject, if it is a ServiceAccount.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Namespaces are the namespaces in which the subject is bound to the
+	// CertificateRequestPolicy. Empty if the subject is bound in all
+	// namespaces.
+	// +listType=atomic
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
+// CertificateRequestPolicyCompliance reports drift of the CertificateRequests
+// approved by a CertificateRequestPolicy, i.e. approved requests that would no
+// longer be approved by the current CertificateRequestPolicies if they were
+// created now, including because the requester is no longer bound to the
+// policy.
+type CertificateRequestPolicyCompliance struct {
+	// LastCheckTime is the time of the re-review of the approved
+	// CertificateRequests which last changed this report. The report is only
+	// written when it changes.
+	// +optional
+	LastCheckTime *metav1.Time `json:"lastCheckTime,omitempty"`
+
+	// ApprovedRequests is the number of existing CertificateRequests that were
+	// approved by this CertificateRequestPolicy.
+	ApprovedRequests int32 `json:"approvedRequests"`
+
+	// DriftedRequests is the number of CertificateRequests approved by this
+	// CertificateRequestPolicy that would no longer be approved.
+	DriftedRequests int32 `json:"driftedRequests"`
+
+	// Drifted lists the CertificateRequests approved by this
+	// CertificateRequestPolicy that would no longer be approved, along with the
+	// reason why. The list is truncated to the first 50 requests, ordered by
+	// namespace and name.
+	// +listType=atomic
+	// +optional
+	Drifted []CertificateRequestPolicyComplianceDrift `json:"drifted,omitempty"`
+}
+
+// CertificateRequestPolicyComplianceDrift is an approved CertificateRequest
+// that would no longer be approved.
+type CertificateRequestPolicyComplianceDrift struct {
+	// Namespace of the CertificateRequest.
+	Namespace string `json:"namespace"`
+
+	// Name of the CertificateRequest.
+	Name string `json:"name"`
+
+	// Message is the result of re-reviewing the CertificateRequest.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // CertificateRequestPolicyCondition contains condition information for a
 // CertificateRequestPolicyStatus.
 type CertificateRequestPolicyCondition struct {
-	// Type of the condition, known values are (`Ready`).
+	// Type of the condition, known values are (`Ready`, `Overlapping`).
 	Type CertificateRequestPolicyConditionType `json:"type"`
 
 	// Status of the condition, one of ('True', 'False', 'Unknown').
@@ -456,4 +910,12 @@ const (
 	// evaluating CertificateRequests.
 	// +k8s:deepcopy-gen=false
 	CertificateRequestPolicyConditionReady CertificateRequestPolicyConditionType = "Ready"
+
+	// CertificateRequestPolicyConditionOverlapping indicates that the
+	// CertificateRequestPolicy shadows, or is shadowed by, another
+	// CertificateRequestPolicy. A shadowed policy selects and permits a subset
+	// of the requests another policy does, so has no effect for requesters
+	// bound to both. The condition is only present while policies overlap.
+	// +k8s:deepcopy-gen=false
+	CertificateRequestPolicyConditionOverlapping CertificateRequestPolicyConditionType = "Overlapping"
 )
@@ -21,11 +21,89 @@ limitations under the License.
 package v1alpha1
 
 import (
-	"github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRequestApproval) DeepCopyInto(out *CertificateRequestApproval) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestApproval.
+func (in *CertificateRequestApproval) DeepCopy() *CertificateRequestApproval {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRequestApproval)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CertificateRequestApproval) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRequestApprovalList) DeepCopyInto(out *CertificateRequestApprovalList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CertificateRequestApproval, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestApprovalList.
+func (in *CertificateRequestApprovalList) DeepCopy() *CertificateRequestApprovalList {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRequestApprovalList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CertificateRequestApprovalList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRequestApprovalSpec) DeepCopyInto(out *CertificateRequestApprovalSpec) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestApprovalSpec.
+func (in *CertificateRequestApprovalSpec) DeepCopy() *CertificateRequestApprovalSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRequestApprovalSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertificateRequestPolicy) DeepCopyInto(out *CertificateRequestPolicy) {
 	*out = *in
@@ -81,6 +159,18 @@ func (in *CertificateRequestPolicyAllowed) DeepCopyInto(out *CertificateRequestP
 		*out = new(CertificateRequestPolicyAllowedStringSlice)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.OtherNames != nil {
+		in, out := &in.OtherNames, &out.OtherNames
+		*out = make([]CertificateRequestPolicyAllowedOtherName, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RegisteredIDs != nil {
+		in, out := &in.RegisteredIDs, &out.RegisteredIDs
+		*out = new(CertificateRequestPolicyAllowedStringSlice)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.IsCA != nil {
 		in, out := &in.IsCA, &out.IsCA
 		*out = new(bool)
@@ -88,10 +178,10 @@ func (in *CertificateRequestPolicyAllowed) DeepCopyInto(out *CertificateRequestP
 	}
 	if in.Usages != nil {
 		in, out := &in.Usages, &out.Usages
-		*out = new([]v1.KeyUsage)
+		*out = new([]certmanagerv1.KeyUsage)
 		if **in != nil {
 			in, out := *in, *out
-			*out = make([]v1.KeyUsage, len(*in))
+			*out = make([]certmanagerv1.KeyUsage, len(*in))
 			copy(*out, *in)
 		}
 	}
@@ -112,6 +202,22 @@ func (in *CertificateRequestPolicyAllowed) DeepCopy() *CertificateRequestPolicyA
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRequestPolicyAllowedOtherName) DeepCopyInto(out *CertificateRequestPolicyAllowedOtherName) {
+	*out = *in
+	in.CertificateRequestPolicyAllowedStringSlice.DeepCopyInto(&out.CertificateRequestPolicyAllowedStringSlice)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestPolicyAllowedOtherName.
+func (in *CertificateRequestPolicyAllowedOtherName) DeepCopy() *CertificateRequestPolicyAllowedOtherName {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRequestPolicyAllowedOtherName)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertificateRequestPolicyAllowedString) DeepCopyInto(out *CertificateRequestPolicyAllowedString) {
 	*out = *in
@@ -223,6 +329,18 @@ func (in *CertificateRequestPolicyAllowedX509Subject) DeepCopyInto(out *Certific
 		*out = new(CertificateRequestPolicyAllowedString)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ExtraAttributes != nil {
+		in, out := &in.ExtraAttributes, &out.ExtraAttributes
+		*out = make([]CertificateRequestPolicyAllowedX509SubjectAttribute, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DN != nil {
+		in, out := &in.DN, &out.DN
+		*out = new(CertificateRequestPolicyAllowedString)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestPolicyAllowedX509Subject.
@@ -235,6 +353,81 @@ func (in *CertificateRequestPolicyAllowedX509Subject) DeepCopy() *CertificateReq
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRequestPolicyAllowedX509SubjectAttribute) DeepCopyInto(out *CertificateRequestPolicyAllowedX509SubjectAttribute) {
+	*out = *in
+	in.CertificateRequestPolicyAllowedStringSlice.DeepCopyInto(&out.CertificateRequestPolicyAllowedStringSlice)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestPolicyAllowedX509SubjectAttribute.
+func (in *CertificateRequestPolicyAllowedX509SubjectAttribute) DeepCopy() *CertificateRequestPolicyAllowedX509SubjectAttribute {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRequestPolicyAllowedX509SubjectAttribute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRequestPolicyBoundSubject) DeepCopyInto(out *CertificateRequestPolicyBoundSubject) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestPolicyBoundSubject.
+func (in *CertificateRequestPolicyBoundSubject) DeepCopy() *CertificateRequestPolicyBoundSubject {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRequestPolicyBoundSubject)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRequestPolicyCompliance) DeepCopyInto(out *CertificateRequestPolicyCompliance) {
+	*out = *in
+	if in.LastCheckTime != nil {
+		in, out := &in.LastCheckTime, &out.LastCheckTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Drifted != nil {
+		in, out := &in.Drifted, &out.Drifted
+		*out = make([]CertificateRequestPolicyComplianceDrift, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestPolicyCompliance.
+func (in *CertificateRequestPolicyCompliance) DeepCopy() *CertificateRequestPolicyCompliance {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRequestPolicyCompliance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRequestPolicyComplianceDrift) DeepCopyInto(out *CertificateRequestPolicyComplianceDrift) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestPolicyComplianceDrift.
+func (in *CertificateRequestPolicyComplianceDrift) DeepCopy() *CertificateRequestPolicyComplianceDrift {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRequestPolicyComplianceDrift)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertificateRequestPolicyCondition) DeepCopyInto(out *CertificateRequestPolicyCondition) {
 	*out = *in
@@ -259,19 +452,44 @@ func (in *CertificateRequestPolicyConstraints) DeepCopyInto(out *CertificateRequ
 	*out = *in
 	if in.MinDuration != nil {
 		in, out := &in.MinDuration, &out.MinDuration
-		*out = new(metav1.Duration)
+		*out = new(v1.Duration)
 		**out = **in
 	}
 	if in.MaxDuration != nil {
 		in, out := &in.MaxDuration, &out.MaxDuration
-		*out = new(metav1.Duration)
+		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.AssumeDefaultDuration != nil {
+		in, out := &in.AssumeDefaultDuration, &out.AssumeDefaultDuration
+		*out = new(bool)
+		**out = **in
+	}
+	if in.WithinIssuerCAValidity != nil {
+		in, out := &in.WithinIssuerCAValidity, &out.WithinIssuerCAValidity
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(CertificateRequestPolicyConstraintsRenewBefore)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.PrivateKey != nil {
 		in, out := &in.PrivateKey, &out.PrivateKey
 		*out = new(CertificateRequestPolicyConstraintsPrivateKey)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Extensions != nil {
+		in, out := &in.Extensions, &out.Extensions
+		*out = new(CertificateRequestPolicyConstraintsExtensions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(CertificateRequestPolicyConstraintsCA)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestPolicyConstraints.
@@ -284,12 +502,121 @@ func (in *CertificateRequestPolicyConstraints) DeepCopy() *CertificateRequestPol
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRequestPolicyConstraintsCA) DeepCopyInto(out *CertificateRequestPolicyConstraintsCA) {
+	*out = *in
+	if in.NameConstraints != nil {
+		in, out := &in.NameConstraints, &out.NameConstraints
+		*out = new(CertificateRequestPolicyConstraintsCANameConstraints)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestPolicyConstraintsCA.
+func (in *CertificateRequestPolicyConstraintsCA) DeepCopy() *CertificateRequestPolicyConstraintsCA {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRequestPolicyConstraintsCA)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRequestPolicyConstraintsCANameConstraints) DeepCopyInto(out *CertificateRequestPolicyConstraintsCANameConstraints) {
+	*out = *in
+	if in.PermittedDNSDomains != nil {
+		in, out := &in.PermittedDNSDomains, &out.PermittedDNSDomains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PermittedIPRanges != nil {
+		in, out := &in.PermittedIPRanges, &out.PermittedIPRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PermittedEmailAddresses != nil {
+		in, out := &in.PermittedEmailAddresses, &out.PermittedEmailAddresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PermittedURIDomains != nil {
+		in, out := &in.PermittedURIDomains, &out.PermittedURIDomains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestPolicyConstraintsCANameConstraints.
+func (in *CertificateRequestPolicyConstraintsCANameConstraints) DeepCopy() *CertificateRequestPolicyConstraintsCANameConstraints {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRequestPolicyConstraintsCANameConstraints)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRequestPolicyConstraintsExtension) DeepCopyInto(out *CertificateRequestPolicyConstraintsExtension) {
+	*out = *in
+	if in.Critical != nil {
+		in, out := &in.Critical, &out.Critical
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestPolicyConstraintsExtension.
+func (in *CertificateRequestPolicyConstraintsExtension) DeepCopy() *CertificateRequestPolicyConstraintsExtension {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRequestPolicyConstraintsExtension)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRequestPolicyConstraintsExtensions) DeepCopyInto(out *CertificateRequestPolicyConstraintsExtensions) {
+	*out = *in
+	if in.Allowed != nil {
+		in, out := &in.Allowed, &out.Allowed
+		*out = make([]CertificateRequestPolicyConstraintsExtension, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Denied != nil {
+		in, out := &in.Denied, &out.Denied
+		*out = make([]CertificateRequestPolicyConstraintsExtension, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExtendedKeyUsages != nil {
+		in, out := &in.ExtendedKeyUsages, &out.ExtendedKeyUsages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestPolicyConstraintsExtensions.
+func (in *CertificateRequestPolicyConstraintsExtensions) DeepCopy() *CertificateRequestPolicyConstraintsExtensions {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRequestPolicyConstraintsExtensions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertificateRequestPolicyConstraintsPrivateKey) DeepCopyInto(out *CertificateRequestPolicyConstraintsPrivateKey) {
 	*out = *in
 	if in.Algorithm != nil {
 		in, out := &in.Algorithm, &out.Algorithm
-		*out = new(v1.PrivateKeyAlgorithm)
+		*out = new(certmanagerv1.PrivateKeyAlgorithm)
 		**out = **in
 	}
 	if in.MinSize != nil {
@@ -302,6 +629,23 @@ func (in *CertificateRequestPolicyConstraintsPrivateKey) DeepCopyInto(out *Certi
 		*out = new(int)
 		**out = **in
 	}
+	if in.Curves != nil {
+		in, out := &in.Curves, &out.Curves
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SignatureAlgorithms != nil {
+		in, out := &in.SignatureAlgorithms, &out.SignatureAlgorithms
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AnyOf != nil {
+		in, out := &in.AnyOf, &out.AnyOf
+		*out = make([]CertificateRequestPolicyConstraintsPrivateKeyOption, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestPolicyConstraintsPrivateKey.
@@ -314,6 +658,86 @@ func (in *CertificateRequestPolicyConstraintsPrivateKey) DeepCopy() *Certificate
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRequestPolicyConstraintsPrivateKeyOption) DeepCopyInto(out *CertificateRequestPolicyConstraintsPrivateKeyOption) {
+	*out = *in
+	if in.MinSize != nil {
+		in, out := &in.MinSize, &out.MinSize
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxSize != nil {
+		in, out := &in.MaxSize, &out.MaxSize
+		*out = new(int)
+		**out = **in
+	}
+	if in.Curves != nil {
+		in, out := &in.Curves, &out.Curves
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestPolicyConstraintsPrivateKeyOption.
+func (in *CertificateRequestPolicyConstraintsPrivateKeyOption) DeepCopy() *CertificateRequestPolicyConstraintsPrivateKeyOption {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRequestPolicyConstraintsPrivateKeyOption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRequestPolicyConstraintsRenewBefore) DeepCopyInto(out *CertificateRequestPolicyConstraintsRenewBefore) {
+	*out = *in
+	if in.MinPercentage != nil {
+		in, out := &in.MinPercentage, &out.MinPercentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxPercentage != nil {
+		in, out := &in.MaxPercentage, &out.MaxPercentage
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestPolicyConstraintsRenewBefore.
+func (in *CertificateRequestPolicyConstraintsRenewBefore) DeepCopy() *CertificateRequestPolicyConstraintsRenewBefore {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRequestPolicyConstraintsRenewBefore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRequestPolicyDefaults) DeepCopyInto(out *CertificateRequestPolicyDefaults) {
+	*out = *in
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Usages != nil {
+		in, out := &in.Usages, &out.Usages
+		*out = make([]certmanagerv1.KeyUsage, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestPolicyDefaults.
+func (in *CertificateRequestPolicyDefaults) DeepCopy() *CertificateRequestPolicyDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRequestPolicyDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertificateRequestPolicyList) DeepCopyInto(out *CertificateRequestPolicyList) {
 	*out = *in
@@ -471,6 +895,11 @@ func (in *CertificateRequestPolicySpec) DeepCopyInto(out *CertificateRequestPoli
 		}
 	}
 	in.Selector.DeepCopyInto(&out.Selector)
+	if in.Defaults != nil {
+		in, out := &in.Defaults, &out.Defaults
+		*out = new(CertificateRequestPolicyDefaults)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestPolicySpec.
@@ -483,6 +912,40 @@ func (in *CertificateRequestPolicySpec) DeepCopy() *CertificateRequestPolicySpec
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRequestPolicyStatistics) DeepCopyInto(out *CertificateRequestPolicyStatistics) {
+	*out = *in
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastApprovedTime != nil {
+		in, out := &in.LastApprovedTime, &out.LastApprovedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastDeniedTime != nil {
+		in, out := &in.LastDeniedTime, &out.LastDeniedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Subjects != nil {
+		in, out := &in.Subjects, &out.Subjects
+		*out = make([]CertificateRequestPolicyBoundSubject, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestPolicyStatistics.
+func (in *CertificateRequestPolicyStatistics) DeepCopy() *CertificateRequestPolicyStatistics {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRequestPolicyStatistics)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertificateRequestPolicyStatus) DeepCopyInto(out *CertificateRequestPolicyStatus) {
 	*out = *in
@@ -493,6 +956,16 @@ func (in *CertificateRequestPolicyStatus) DeepCopyInto(out *CertificateRequestPo
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Compliance != nil {
+		in, out := &in.Compliance, &out.Compliance
+		*out = new(CertificateRequestPolicyCompliance)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Statistics != nil {
+		in, out := &in.Statistics, &out.Statistics
+		*out = new(CertificateRequestPolicyStatistics)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequestPolicyStatus.
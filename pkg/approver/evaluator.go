@@ -23,8 +23,10 @@ package approver
 
 import (
 	"context"
+	"time"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
 )
@@ -51,6 +53,21 @@ type EvaluationResponse struct {
 	// Message is optional context as to why the evaluator has given the result
 	// it has.
 	Message string
+
+	// Errors optionally holds the policy fields which caused a ResultDenied.
+	// Used to report which fields of a policy deny requests.
+	Errors field.ErrorList
+
+	// Pending may be set alongside ResultNotDenied to signal that the evaluator
+	// has not denied the request, but is not yet able to let the policy approve
+	// it. For example, the evaluator may be waiting on a manual approval. A
+	// policy with a pending evaluator will not approve the request.
+	Pending bool
+
+	// RequeueAfter is the duration after which a Pending request should be
+	// evaluated again. The request may be evaluated again sooner. Ignored if
+	// Pending is false, or if zero.
+	RequeueAfter time.Duration
 }
 
 // Evaluator is responsible for making decisions on whether a
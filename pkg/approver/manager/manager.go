@@ -18,6 +18,7 @@ package manager
 
 import (
 	"context"
+	"time"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 )
@@ -39,8 +40,30 @@ const (
 	// that the request is not appropriate for any evaluators given the current
 	// policy. It is neither approved or denied by the manager.
 	ResultUnprocessed
+
+	// ResultPending is the result of a review where the manager has not denied
+	// the request, but a policy that may approve it is waiting on an external
+	// action, such as a manual approval. The request should be reviewed again
+	// later.
+	ResultPending
 )
 
+// String returns the lower case name of the ReviewResult.
+func (r ReviewResult) String() string {
+	switch r {
+	case ResultApproved:
+		return "approved"
+	case ResultDenied:
+		return "denied"
+	case ResultUnprocessed:
+		return "unprocessed"
+	case ResultPending:
+		return "pending"
+	default:
+		return "unknown"
+	}
+}
+
 // ReviewResponse is the response to an approver manager request review.
 type ReviewResponse struct {
 	// Result is the actionable result code from running the review.
@@ -49,6 +72,38 @@ type ReviewResponse struct {
 	// Message is optional context as to why the manager has given the result it
 	// has.
 	Message string
+
+	// RequeueAfter is the duration after which a ResultPending request should be
+	// reviewed again. Zero means the request is only reviewed again on an
+	// event.
+	RequeueAfter time.Duration
+
+	// Policy is the name of the CertificateRequestPolicy which approved the
+	// request. Only set for ResultApproved.
+	Policy string
+
+	// Denials are the reasons each bound policy denied the request. Only set
+	// for ResultDenied.
+	Denials []Denial
+
+	// Eliminations is the number of CertificateRequestPolicies each predicate
+	// removed from consideration during the review, keyed by predicate name.
+	Eliminations map[string]int
+}
+
+// Denial is a reason a CertificateRequestPolicy denied a request.
+type Denial struct {
+	// Policy is the name of the CertificateRequestPolicy which denied the
+	// request.
+	Policy string
+
+	// Evaluator is the name of the evaluator which denied the request. Empty if
+	// the request was denied by the policy's defaultAction.
+	Evaluator string
+
+	// Field is the path of the policy field which denied the request. Empty if
+	// the evaluator did not give a field.
+	Field string
 }
 
 // Interface is an Approver Manager that responsible for evaluating whether
@@ -65,6 +120,9 @@ type Interface interface {
 	// - Consumers should consider a ResultUnprocessed response to mean the
 	//   manager doesn't consider the request to be appropriate for any evaluator
 	//   and so no review was run. The request is neither approved or denied.
+	// - Consumers should consider a ResultPending response to mean the
+	//   CertificateRequest is neither approved nor denied yet, and should be
+	//   reviewed again after RequeueAfter.
 	// - Consumers should treat any error response as marking the
 	//   CertificateRequest as neither approved nor denied, and may consider
 	//   re-evaluation at a later time.
@@ -0,0 +1,238 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approver
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+)
+
+// PluginValueType is the type of a plugin value. Plugin values are always
+// stored as strings, the type defines how the string must be parsed.
+type PluginValueType string
+
+const (
+	// PluginValueTypeString accepts any string.
+	PluginValueTypeString PluginValueType = "string"
+
+	// PluginValueTypeBool accepts values parsed by strconv.ParseBool.
+	PluginValueTypeBool PluginValueType = "bool"
+
+	// PluginValueTypeInt accepts values parsed by strconv.ParseInt.
+	PluginValueTypeInt PluginValueType = "int"
+
+	// PluginValueTypeDuration accepts values parsed by time.ParseDuration.
+	PluginValueTypeDuration PluginValueType = "duration"
+)
+
+// PluginValueSchema declares a single key of a plugin's values.
+type PluginValueSchema struct {
+	// Key is the key of the value in the plugin values map.
+	Key string `json:"key"`
+
+	// Type is the type of the value. Defaults to PluginValueTypeString if
+	// empty.
+	Type PluginValueType `json:"type,omitempty"`
+
+	// Description is a human readable description of the value.
+	Description string `json:"description,omitempty"`
+
+	// Required declares that the value must be defined.
+	Required bool `json:"required,omitempty"`
+
+	// Enum is the set of accepted values. Any value is accepted if empty.
+	Enum []string `json:"enum,omitempty"`
+
+	// Default is the value used when the key is not defined. Ignored if
+	// empty.
+	Default string `json:"default,omitempty"`
+}
+
+// PluginSchema declares the values a plugin accepts under
+// `spec.plugins.<name>.values`.
+type PluginSchema struct {
+	// Description is a human readable description of the plugin.
+	Description string `json:"description,omitempty"`
+
+	// Values are the values accepted by the plugin. Keys which are not
+	// declared are rejected.
+	Values []PluginValueSchema `json:"values,omitempty"`
+}
+
+// PluginSchemaProvider may optionally be implemented by Approvers to declare
+// a typed schema for their plugin values. Values of CertificateRequestPolicies
+// are validated and defaulted against the schema before the Approver's
+// Webhook is called, and the schema is shown in the approver-policy help
+// output.
+type PluginSchemaProvider interface {
+	// PluginSchema returns the schema of the Approver's plugin values.
+	PluginSchema() PluginSchema
+}
+
+// PluginSchemas returns the plugin schemas of the given Approvers, keyed by
+// Approver name. Approvers which don't declare a schema are omitted.
+func PluginSchemas(approvers ...Interface) map[string]PluginSchema {
+	schemas := make(map[string]PluginSchema)
+	for _, a := range approvers {
+		if provider, ok := a.(PluginSchemaProvider); ok {
+			schemas[a.Name()] = provider.PluginSchema()
+		}
+	}
+	return schemas
+}
+
+// DefaultAndValidatePlugins defaults the values of every plugin of the
+// CertificateRequestPolicy which declares a schema, and validates the
+// defaulted values, exactly as the values are validated when the policy is
+// applied. Returns the defaulted CertificateRequestPolicy. The given policy is
+// not modified.
+func DefaultAndValidatePlugins(schemas map[string]PluginSchema, policy *policyapi.CertificateRequestPolicy) (*policyapi.CertificateRequestPolicy, field.ErrorList) {
+	var (
+		el      field.ErrorList
+		fldPath = field.NewPath("spec", "plugins")
+		names   []string
+	)
+
+	for name := range policy.Spec.Plugins {
+		names = append(names, name)
+	}
+	// Sort list so errors are deterministic.
+	sort.Strings(names)
+
+	for _, name := range names {
+		schema, ok := schemas[name]
+		if !ok {
+			continue
+		}
+
+		values, defaulted := schema.Default(policy.Spec.Plugins[name].Values)
+		if defaulted {
+			policy = policy.DeepCopy()
+			policy.Spec.Plugins[name] = policyapi.CertificateRequestPolicyPluginData{Values: values}
+		}
+
+		el = append(el, schema.Validate(fldPath.Child(name, "values"), values)...)
+	}
+
+	return policy, el
+}
+
+// Validate validates the given plugin values against the schema. Values are
+// expected to have been defaulted.
+func (s PluginSchema) Validate(fldPath *field.Path, values map[string]string) field.ErrorList {
+	var (
+		el    field.ErrorList
+		known = make([]string, 0, len(s.Values))
+	)
+
+	for _, value := range s.Values {
+		known = append(known, value.Key)
+
+		v, ok := values[value.Key]
+		if !ok {
+			if value.Required {
+				el = append(el, field.Required(fldPath.Key(value.Key), value.Description))
+			}
+			continue
+		}
+
+		el = append(el, value.validate(fldPath.Key(value.Key), v)...)
+	}
+
+	var unknown []string
+	for key := range values {
+		if !slices.Contains(known, key) {
+			unknown = append(unknown, key)
+		}
+	}
+	// Sort list so errors are deterministic.
+	sort.Strings(unknown)
+	for _, key := range unknown {
+		el = append(el, field.NotSupported(fldPath, key, known))
+	}
+
+	return el
+}
+
+// Default sets the default of every value in the schema which is not defined
+// in the given values. Returns the defaulted values, and whether any value
+// was defaulted. The given values are not modified.
+func (s PluginSchema) Default(values map[string]string) (map[string]string, bool) {
+	var defaulted map[string]string
+	for _, value := range s.Values {
+		if len(value.Default) == 0 {
+			continue
+		}
+		if _, ok := values[value.Key]; ok {
+			continue
+		}
+		if defaulted == nil {
+			defaulted = make(map[string]string, len(values)+1)
+			for k, v := range values {
+				defaulted[k] = v
+			}
+		}
+		defaulted[value.Key] = value.Default
+	}
+
+	if defaulted == nil {
+		return values, false
+	}
+	return defaulted, true
+}
+
+// validate validates a single defined value against its schema.
+func (s PluginValueSchema) validate(fldPath *field.Path, value string) field.ErrorList {
+	var el field.ErrorList
+
+	if len(s.Enum) > 0 && !slices.Contains(s.Enum, value) {
+		el = append(el, field.NotSupported(fldPath, value, s.Enum))
+	}
+
+	var err error
+	switch s.Type {
+	case "", PluginValueTypeString:
+	case PluginValueTypeBool:
+		_, err = strconv.ParseBool(value)
+	case PluginValueTypeInt:
+		_, err = strconv.ParseInt(value, 10, 64)
+	case PluginValueTypeDuration:
+		_, err = time.ParseDuration(value)
+	default:
+		err = fmt.Errorf("plugin schema declares unknown type %q", s.Type)
+	}
+	if err != nil {
+		el = append(el, field.Invalid(fldPath, value, fmt.Sprintf("must be of type %s: %s", s.typeString(), err)))
+	}
+
+	return el
+}
+
+// typeString returns the type of the value, defaulting to string.
+func (s PluginValueSchema) typeString() string {
+	if len(s.Type) == 0 {
+		return string(PluginValueTypeString)
+	}
+	return string(s.Type)
+}
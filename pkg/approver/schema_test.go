@@ -0,0 +1,184 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+)
+
+func Test_PluginSchema_Validate(t *testing.T) {
+	fldPath := field.NewPath("spec", "plugins", "test", "values")
+
+	schema := PluginSchema{
+		Values: []PluginValueSchema{
+			{Key: "name", Required: true},
+			{Key: "enabled", Type: PluginValueTypeBool},
+			{Key: "count", Type: PluginValueTypeInt},
+			{Key: "timeout", Type: PluginValueTypeDuration},
+			{Key: "mode", Enum: []string{"strict", "lenient"}},
+		},
+	}
+
+	tests := map[string]struct {
+		values map[string]string
+		expErr field.ErrorList
+	}{
+		"if all values are valid, return no errors": {
+			values: map[string]string{"name": "foo", "enabled": "true", "count": "3", "timeout": "5s", "mode": "strict"},
+			expErr: nil,
+		},
+		"if a required value is missing, return error": {
+			values: map[string]string{},
+			expErr: field.ErrorList{field.Required(fldPath.Key("name"), "")},
+		},
+		"if values don't parse as their type, return errors": {
+			values: map[string]string{"name": "foo", "enabled": "yes please", "count": "three", "timeout": "5"},
+			expErr: field.ErrorList{
+				field.Invalid(fldPath.Key("enabled"), "yes please", `must be of type bool: strconv.ParseBool: parsing "yes please": invalid syntax`),
+				field.Invalid(fldPath.Key("count"), "three", `must be of type int: strconv.ParseInt: parsing "three": invalid syntax`),
+				field.Invalid(fldPath.Key("timeout"), "5", `must be of type duration: time: missing unit in duration "5"`),
+			},
+		},
+		"if a value is not in its enum, return error": {
+			values: map[string]string{"name": "foo", "mode": "relaxed"},
+			expErr: field.ErrorList{field.NotSupported(fldPath.Key("mode"), "relaxed", []string{"strict", "lenient"})},
+		},
+		"if unknown values are defined, return errors in sorted order": {
+			values: map[string]string{"name": "foo", "zzz": "", "aaa": ""},
+			expErr: field.ErrorList{
+				field.NotSupported(fldPath, "aaa", []string{"name", "enabled", "count", "timeout", "mode"}),
+				field.NotSupported(fldPath, "zzz", []string{"name", "enabled", "count", "timeout", "mode"}),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expErr, schema.Validate(fldPath, test.values))
+		})
+	}
+}
+
+func Test_PluginSchema_Default(t *testing.T) {
+	schema := PluginSchema{
+		Values: []PluginValueSchema{
+			{Key: "mode", Default: "strict"},
+			{Key: "name"},
+		},
+	}
+
+	tests := map[string]struct {
+		values       map[string]string
+		expValues    map[string]string
+		expDefaulted bool
+	}{
+		"if no values are defined, set defaults": {
+			values:       nil,
+			expValues:    map[string]string{"mode": "strict"},
+			expDefaulted: true,
+		},
+		"if other values are defined, keep them and set defaults": {
+			values:       map[string]string{"name": "foo"},
+			expValues:    map[string]string{"name": "foo", "mode": "strict"},
+			expDefaulted: true,
+		},
+		"if defaulted values are already defined, don't modify them": {
+			values:       map[string]string{"mode": "lenient"},
+			expValues:    map[string]string{"mode": "lenient"},
+			expDefaulted: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var original map[string]string
+			if test.values != nil {
+				original = make(map[string]string)
+				for k, v := range test.values {
+					original[k] = v
+				}
+			}
+
+			values, defaulted := schema.Default(test.values)
+			assert.Equal(t, test.expValues, values)
+			assert.Equal(t, test.expDefaulted, defaulted)
+			assert.Equal(t, original, test.values, "input values must not be modified")
+		})
+	}
+}
+
+func Test_DefaultAndValidatePlugins(t *testing.T) {
+	schemas := map[string]PluginSchema{
+		"test": {
+			Values: []PluginValueSchema{
+				{Key: "mode", Enum: []string{"strict", "lenient"}, Default: "strict"},
+				{Key: "name", Required: true},
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		plugins   map[string]policyapi.CertificateRequestPolicyPluginData
+		expValues map[string]string
+		expErr    field.ErrorList
+	}{
+		"if values are defaulted, validate the defaulted values": {
+			plugins: map[string]policyapi.CertificateRequestPolicyPluginData{
+				"test": {Values: map[string]string{"name": "foo"}},
+			},
+			expValues: map[string]string{"name": "foo", "mode": "strict"},
+			expErr:    nil,
+		},
+		"if defaulted values are invalid, return errors": {
+			plugins: map[string]policyapi.CertificateRequestPolicyPluginData{
+				"test": {Values: map[string]string{"mode": "relaxed"}},
+			},
+			expValues: map[string]string{"mode": "relaxed"},
+			expErr: field.ErrorList{
+				field.NotSupported(field.NewPath("spec", "plugins", "test", "values").Key("mode"), "relaxed", []string{"strict", "lenient"}),
+				field.Required(field.NewPath("spec", "plugins", "test", "values").Key("name"), ""),
+			},
+		},
+		"if a plugin has no schema, ignore it": {
+			plugins: map[string]policyapi.CertificateRequestPolicyPluginData{
+				"test":  {Values: map[string]string{"name": "foo", "mode": "lenient"}},
+				"other": {Values: map[string]string{"anything": "goes"}},
+			},
+			expValues: map[string]string{"name": "foo", "mode": "lenient"},
+			expErr:    nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			policy := &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{Plugins: test.plugins},
+			}
+			original := policy.DeepCopy()
+
+			defaulted, el := DefaultAndValidatePlugins(schemas, policy)
+			assert.Equal(t, test.expErr, el)
+			assert.Equal(t, test.expValues, defaulted.Spec.Plugins["test"].Values)
+			assert.Equal(t, original, policy, "input policy must not be modified")
+		})
+	}
+}
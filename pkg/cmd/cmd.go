@@ -26,6 +26,8 @@ import (
 
 	_ "github.com/cert-manager/approver-policy/pkg/internal/approver/allowed"
 	_ "github.com/cert-manager/approver-policy/pkg/internal/approver/constraints"
+	_ "github.com/cert-manager/approver-policy/pkg/internal/approver/manualapproval"
+	_ "github.com/cert-manager/approver-policy/pkg/internal/approver/wasm"
 )
 
 // ExecutePolicyApprover executes the main approver-policy program making use
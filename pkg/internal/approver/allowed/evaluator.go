@@ -20,12 +20,15 @@ import (
 	"context"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
 
@@ -34,6 +37,26 @@ import (
 	"github.com/cert-manager/approver-policy/pkg/internal/util"
 )
 
+var (
+	// oidExtensionSubjectAltName is the OID of the subject alternative name
+	// extension.
+	oidExtensionSubjectAltName = asn1.ObjectIdentifier{2, 5, 29, 17}
+
+	// knownSubjectAttributes are the OIDs of the Subject attributes which are
+	// evaluated by a dedicated field.
+	knownSubjectAttributes = sets.New(
+		"2.5.4.3",  // commonName
+		"2.5.4.5",  // serialNumber
+		"2.5.4.6",  // countryName
+		"2.5.4.7",  // localityName
+		"2.5.4.8",  // stateOrProvinceName
+		"2.5.4.9",  // streetAddress
+		"2.5.4.10", // organizationName
+		"2.5.4.11", // organizationalUnitName
+		"2.5.4.17", // postalCode
+	)
+)
+
 // Evaluate evaluates whether the given CertificateRequest conforms to the
 // allowed attributes defined in the policy. The request _must_ conform to
 // _all_ allowed attributes in the policy to be permitted by the passed policy.
@@ -59,10 +82,16 @@ func (a allowed) Evaluate(_ context.Context, policy *policyapi.CertificateReques
 		return approver.EvaluationResponse{}, err
 	}
 
+	sans, err := decodeSANs(csr)
+	if err != nil {
+		return approver.EvaluationResponse{}, err
+	}
+
 	evaluate := evaluator{
 		a:       a,
 		request: request,
 		csr:     csr,
+		sans:    sans,
 		allowed: allowed,
 		fldPath: fldPath,
 	}
@@ -74,6 +103,9 @@ func (a allowed) Evaluate(_ context.Context, policy *policyapi.CertificateReques
 		evaluate.IPAddresses,
 		evaluate.URIs,
 		evaluate.EmailAddresses,
+		evaluate.OtherNames,
+		evaluate.RegisteredIDs,
+		evaluate.UnsupportedSANs,
 		evaluate.IsCA,
 		evaluate.Usages,
 		evaluateSubject.Organization,
@@ -84,6 +116,8 @@ func (a allowed) Evaluate(_ context.Context, policy *policyapi.CertificateReques
 		evaluateSubject.StreetAddress,
 		evaluateSubject.PostalCode,
 		evaluateSubject.SerialNumber,
+		evaluateSubject.ExtraAttributes,
+		evaluateSubject.DN,
 	}
 	for _, fn := range evaluateFns {
 		if e := fn(); e != nil {
@@ -93,7 +127,7 @@ func (a allowed) Evaluate(_ context.Context, policy *policyapi.CertificateReques
 
 	// If there are errors, then return not approved and the aggregated errors
 	if len(el) > 0 {
-		return approver.EvaluationResponse{Result: approver.ResultDenied, Message: el.ToAggregate().Error()}, nil
+		return approver.EvaluationResponse{Result: approver.ResultDenied, Message: el.ToAggregate().Error(), Errors: el}, nil
 	}
 
 	// If no evaluation errors resulting from this policy, return not denied
@@ -104,6 +138,7 @@ type evaluator struct {
 	a       allowed
 	request *cmapi.CertificateRequest
 	csr     *x509.CertificateRequest
+	sans    utilpki.GeneralNames
 	allowed *policyapi.CertificateRequestPolicyAllowed
 	fldPath *field.Path
 }
@@ -136,6 +171,62 @@ func (e evaluator) EmailAddresses() field.ErrorList {
 	return e.a.evaluateSlice(e.request, e.csr.EmailAddresses, e.allowed.EmailAddresses, e.fldPath.Child("emailAddresses"))
 }
 
+func (e evaluator) OtherNames() field.ErrorList {
+	var (
+		el      field.ErrorList
+		fldPath = e.fldPath.Child("otherNames")
+		values  = make(map[string][]string)
+	)
+
+	for _, otherName := range e.sans.OtherNames {
+		oid := otherName.TypeID.String()
+		value, err := decodeOtherNameValue(otherName)
+		if err != nil {
+			el = append(el, field.Invalid(fldPath.Key(oid), oid, fmt.Sprintf("failed to decode otherName value: %s", err)))
+			continue
+		}
+		values[oid] = append(values[oid], value)
+	}
+
+	allowedOIDs := make(map[string]bool)
+	for _, allowed := range e.allowed.OtherNames {
+		allowedOIDs[allowed.OID] = true
+		el = append(el, e.a.evaluateSlice(e.request, values[allowed.OID], &allowed.CertificateRequestPolicyAllowedStringSlice, fldPath.Key(allowed.OID))...)
+	}
+
+	for _, otherName := range e.sans.OtherNames {
+		if oid := otherName.TypeID.String(); !allowedOIDs[oid] {
+			el = append(el, field.Invalid(fldPath, oid, "no allowed otherName of this type"))
+		}
+	}
+
+	return el
+}
+
+func (e evaluator) RegisteredIDs() field.ErrorList {
+	var registeredIDs []string
+	for _, registeredID := range e.sans.RegisteredIDs {
+		registeredIDs = append(registeredIDs, registeredID.String())
+	}
+	return e.a.evaluateSlice(e.request, registeredIDs, e.allowed.RegisteredIDs, e.fldPath.Child("registeredIDs"))
+}
+
+// UnsupportedSANs denies requests for SAN types which no policy field
+// covers, since they would otherwise be issued unchecked.
+func (e evaluator) UnsupportedSANs() field.ErrorList {
+	var el field.ErrorList
+	if len(e.sans.X400Addresses) > 0 {
+		el = append(el, field.Forbidden(e.fldPath, "x400Address SANs cannot be requested"))
+	}
+	if len(e.sans.DirectoryNames) > 0 {
+		el = append(el, field.Forbidden(e.fldPath, "directoryName SANs cannot be requested"))
+	}
+	if len(e.sans.EDIPartyNames) > 0 {
+		el = append(el, field.Forbidden(e.fldPath, "ediPartyName SANs cannot be requested"))
+	}
+	return el
+}
+
 func (e evaluator) IsCA() field.ErrorList {
 	return e.a.evaluateBool(e.request.Spec.IsCA, e.allowed.IsCA, e.fldPath.Child("isCA"))
 }
@@ -171,6 +262,7 @@ func (e evaluator) Subject() subjectEvaluator {
 		a:       e.a,
 		request: e.request,
 		sub:     e.csr.Subject,
+		raw:     e.csr.RawSubject,
 		allowed: allowed,
 		fldPath: e.fldPath.Child("subject"),
 	}
@@ -180,6 +272,7 @@ type subjectEvaluator struct {
 	a       allowed
 	request *cmapi.CertificateRequest
 	sub     pkix.Name
+	raw     []byte
 	allowed *policyapi.CertificateRequestPolicyAllowedX509Subject
 	fldPath *field.Path
 }
@@ -216,6 +309,66 @@ func (e subjectEvaluator) SerialNumber() field.ErrorList {
 	return e.a.evaluateString(e.request, e.sub.SerialNumber, e.allowed.SerialNumber, e.fldPath.Child("serialNumber"))
 }
 
+// ExtraAttributes evaluates the Subject attributes which do not have a
+// dedicated field. Attributes of a type not defined in the policy are denied.
+func (e subjectEvaluator) ExtraAttributes() field.ErrorList {
+	var (
+		el      field.ErrorList
+		fldPath = e.fldPath.Child("extraAttributes")
+		values  = make(map[string][]string)
+		types   []string
+	)
+
+	for _, attr := range e.sub.Names {
+		oid := attr.Type.String()
+		if knownSubjectAttributes.Has(oid) {
+			continue
+		}
+		value, ok := attr.Value.(string)
+		if !ok {
+			el = append(el, field.Invalid(fldPath.Key(oid), fmt.Sprintf("%v", attr.Value), "subject attribute value must be a string"))
+			continue
+		}
+		if _, ok := values[oid]; !ok {
+			types = append(types, oid)
+		}
+		values[oid] = append(values[oid], value)
+	}
+
+	allowedTypes := sets.New[string]()
+	for _, allowed := range e.allowed.ExtraAttributes {
+		allowedTypes.Insert(allowed.OID)
+		el = append(el, e.a.evaluateSlice(e.request, values[allowed.OID], &allowed.CertificateRequestPolicyAllowedStringSlice, fldPath.Key(allowed.OID))...)
+	}
+
+	for _, oid := range types {
+		if !allowedTypes.Has(oid) {
+			el = append(el, field.Invalid(fldPath, oid, "no allowed subject attribute of this type"))
+		}
+	}
+
+	return el
+}
+
+// DN evaluates the full Subject Distinguished Name in its RFC 4514 string
+// form. Unlike the individual attributes, the Distinguished Name is only
+// evaluated if defined in the policy.
+func (e subjectEvaluator) DN() field.ErrorList {
+	if e.allowed.DN == nil {
+		return nil
+	}
+
+	var dn string
+	if len(e.raw) > 0 {
+		var rdns pkix.RDNSequence
+		if rest, err := asn1.Unmarshal(e.raw, &rdns); err != nil || len(rest) > 0 {
+			return field.ErrorList{field.Invalid(e.fldPath.Child("dn"), e.sub.String(), "failed to decode subject")}
+		}
+		dn = rdns.String()
+	}
+	return e.a.evaluateString(e.request, dn, e.allowed.DN, e.fldPath.Child("dn"))
+}
+
 func (a allowed) evaluateString(request *cmapi.CertificateRequest, s string, crp *policyapi.CertificateRequestPolicyAllowedString, fldPath *field.Path) field.ErrorList {
 	if len(s) == 0 {
 		// Attribute not set in request. We will only check if it's a required attribute
@@ -305,3 +458,45 @@ func (a allowed) runValidations(request *cmapi.CertificateRequest, validations [
 	}
 	return el
 }
+
+// decodeSANs decodes all SANs of the CSR, including those types which are not
+// parsed by crypto/x509.
+func decodeSANs(csr *x509.CertificateRequest) (utilpki.GeneralNames, error) {
+	for _, ext := range csr.Extensions {
+		if ext.Id.Equal(oidExtensionSubjectAltName) {
+			sans, err := utilpki.UnmarshalSANs(ext.Value)
+			if err != nil {
+				return utilpki.GeneralNames{}, fmt.Errorf("failed to decode subject alternative names: %w", err)
+			}
+			return sans, nil
+		}
+	}
+	return utilpki.GeneralNames{}, nil
+}
+
+// decodeOtherNameValue decodes the string value of an otherName SAN.
+func decodeOtherNameValue(otherName utilpki.OtherName) (string, error) {
+	// The value is still wrapped in its context specific [0] tag.
+	var inner asn1.RawValue
+	if rest, err := asn1.Unmarshal(otherName.Value.Bytes, &inner); err != nil {
+		return "", err
+	} else if len(rest) > 0 {
+		return "", errors.New("trailing data")
+	}
+
+	value, err := utilpki.UnmarshalUniversalValue(inner)
+	if err != nil {
+		return "", err
+	}
+
+	switch value.Type() {
+	case utilpki.UniversalValueTypeUTF8String:
+		return value.UTF8String, nil
+	case utilpki.UniversalValueTypeIA5String:
+		return value.IA5String, nil
+	case utilpki.UniversalValueTypePrintableString:
+		return value.PrintableString, nil
+	default:
+		return "", errors.New("value is not a string")
+	}
+}
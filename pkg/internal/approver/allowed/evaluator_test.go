@@ -18,11 +18,14 @@ package allowed
 
 import (
 	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
 	"net"
 	"net/url"
 	"testing"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
@@ -78,26 +81,23 @@ func Test_Evaluate(t *testing.T) {
 			policy: policyapi.CertificateRequestPolicySpec{
 				Allowed: nil,
 			},
-			expResponse: approver.EvaluationResponse{
-				Result: approver.ResultDenied,
-				Message: field.ErrorList{
-					field.Invalid(field.NewPath("spec.allowed.commonName"), "hello-world", "no allowed value"),
-					field.Invalid(field.NewPath("spec.allowed.dnsNames"), []string{"example.com", "foo.bar"}, "no allowed values"),
-					field.Invalid(field.NewPath("spec.allowed.ipAddresses"), []string{"1.1.1.1", "2.3.4.5"}, "no allowed values"),
-					field.Invalid(field.NewPath("spec.allowed.uris"), []string{"spiffe://cluster.local/ns/foo/sa/bar", "foo.bar.com"}, "no allowed values"),
-					field.Invalid(field.NewPath("spec.allowed.emailAddresses"), []string{"foo@example.com", "bar@example.com"}, "no allowed values"),
-					field.Invalid(field.NewPath("spec.allowed.isCA"), true, "nil"),
-					field.Invalid(field.NewPath("spec.allowed.usages"), []string{"crl sign", "client auth"}, "nil"),
-					field.Invalid(field.NewPath("spec.allowed.subject.organizations"), []string{"company-1", "company-2"}, "no allowed values"),
-					field.Invalid(field.NewPath("spec.allowed.subject.countries"), []string{"country-1", "country-2"}, "no allowed values"),
-					field.Invalid(field.NewPath("spec.allowed.subject.organizationalUnits"), []string{"org-1", "org-2"}, "no allowed values"),
-					field.Invalid(field.NewPath("spec.allowed.subject.localities"), []string{"loc-1", "loc-2"}, "no allowed values"),
-					field.Invalid(field.NewPath("spec.allowed.subject.provinces"), []string{"prov-1", "prov-2"}, "no allowed values"),
-					field.Invalid(field.NewPath("spec.allowed.subject.streetAddresses"), []string{"street-1", "street-2"}, "no allowed values"),
-					field.Invalid(field.NewPath("spec.allowed.subject.postalCodes"), []string{"post-1", "post-2"}, "no allowed values"),
-					field.Invalid(field.NewPath("spec.allowed.subject.serialNumber"), "serial-1", "no allowed value"),
-				}.ToAggregate().Error(),
-			},
+			expResponse: deniedResponse(field.ErrorList{
+				field.Invalid(field.NewPath("spec.allowed.commonName"), "hello-world", "no allowed value"),
+				field.Invalid(field.NewPath("spec.allowed.dnsNames"), []string{"example.com", "foo.bar"}, "no allowed values"),
+				field.Invalid(field.NewPath("spec.allowed.ipAddresses"), []string{"1.1.1.1", "2.3.4.5"}, "no allowed values"),
+				field.Invalid(field.NewPath("spec.allowed.uris"), []string{"spiffe://cluster.local/ns/foo/sa/bar", "foo.bar.com"}, "no allowed values"),
+				field.Invalid(field.NewPath("spec.allowed.emailAddresses"), []string{"foo@example.com", "bar@example.com"}, "no allowed values"),
+				field.Invalid(field.NewPath("spec.allowed.isCA"), true, "nil"),
+				field.Invalid(field.NewPath("spec.allowed.usages"), []string{"crl sign", "client auth"}, "nil"),
+				field.Invalid(field.NewPath("spec.allowed.subject.organizations"), []string{"company-1", "company-2"}, "no allowed values"),
+				field.Invalid(field.NewPath("spec.allowed.subject.countries"), []string{"country-1", "country-2"}, "no allowed values"),
+				field.Invalid(field.NewPath("spec.allowed.subject.organizationalUnits"), []string{"org-1", "org-2"}, "no allowed values"),
+				field.Invalid(field.NewPath("spec.allowed.subject.localities"), []string{"loc-1", "loc-2"}, "no allowed values"),
+				field.Invalid(field.NewPath("spec.allowed.subject.provinces"), []string{"prov-1", "prov-2"}, "no allowed values"),
+				field.Invalid(field.NewPath("spec.allowed.subject.streetAddresses"), []string{"street-1", "street-2"}, "no allowed values"),
+				field.Invalid(field.NewPath("spec.allowed.subject.postalCodes"), []string{"post-1", "post-2"}, "no allowed values"),
+				field.Invalid(field.NewPath("spec.allowed.subject.serialNumber"), "serial-1", "no allowed value"),
+			}),
 		},
 		"if all allowed defined, all attributes set in request but are different, return Denied": {
 			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t,
@@ -139,26 +139,23 @@ func Test_Evaluate(t *testing.T) {
 					},
 				},
 			},
-			expResponse: approver.EvaluationResponse{
-				Result: approver.ResultDenied,
-				Message: field.ErrorList{
-					field.Invalid(field.NewPath("spec.allowed.commonName.value"), "hello-world", "hello-world2"),
-					field.Invalid(field.NewPath("spec.allowed.dnsNames.values"), []string{"example.com", "foo.bar"}, "example.com2, foo.bar2"),
-					field.Invalid(field.NewPath("spec.allowed.ipAddresses.values"), []string{"1.1.1.1", "2.3.4.5"}, "1.1.1.12, 2.3.4.52"),
-					field.Invalid(field.NewPath("spec.allowed.uris.values"), []string{"spiffe://cluster.local/ns/foo/sa/bar", "foo.bar.com"}, "spiffe://cluster.local/ns/foo/sa/bar2, foo.bar.com2"),
-					field.Invalid(field.NewPath("spec.allowed.emailAddresses.values"), []string{"foo@example.com", "bar@example.com"}, "foo@example.com2, bar@example.com2"),
-					field.Invalid(field.NewPath("spec.allowed.isCA"), true, "false"),
-					field.Invalid(field.NewPath("spec.allowed.usages"), []string{"crl sign", "client auth"}, "crl sign, server auth"),
-					field.Invalid(field.NewPath("spec.allowed.subject.organizations.values"), []string{"company-1", "company-2"}, "company-3, company-4"),
-					field.Invalid(field.NewPath("spec.allowed.subject.countries.values"), []string{"country-1", "country-2"}, "country-3, country-4"),
-					field.Invalid(field.NewPath("spec.allowed.subject.organizationalUnits.values"), []string{"org-1", "org-2"}, "org-3, org-4"),
-					field.Invalid(field.NewPath("spec.allowed.subject.localities.values"), []string{"loc-1", "loc-2"}, "loc-3, loc-4"),
-					field.Invalid(field.NewPath("spec.allowed.subject.provinces.values"), []string{"prov-1", "prov-2"}, "prov-3, prov-4"),
-					field.Invalid(field.NewPath("spec.allowed.subject.streetAddresses.values"), []string{"street-1", "street-2"}, "street-3, street-4"),
-					field.Invalid(field.NewPath("spec.allowed.subject.postalCodes.values"), []string{"post-1", "post-2"}, "post-3, post-4"),
-					field.Invalid(field.NewPath("spec.allowed.subject.serialNumber.value"), "serial-1", "serial-2"),
-				}.ToAggregate().Error(),
-			},
+			expResponse: deniedResponse(field.ErrorList{
+				field.Invalid(field.NewPath("spec.allowed.commonName.value"), "hello-world", "hello-world2"),
+				field.Invalid(field.NewPath("spec.allowed.dnsNames.values"), []string{"example.com", "foo.bar"}, "example.com2, foo.bar2"),
+				field.Invalid(field.NewPath("spec.allowed.ipAddresses.values"), []string{"1.1.1.1", "2.3.4.5"}, "1.1.1.12, 2.3.4.52"),
+				field.Invalid(field.NewPath("spec.allowed.uris.values"), []string{"spiffe://cluster.local/ns/foo/sa/bar", "foo.bar.com"}, "spiffe://cluster.local/ns/foo/sa/bar2, foo.bar.com2"),
+				field.Invalid(field.NewPath("spec.allowed.emailAddresses.values"), []string{"foo@example.com", "bar@example.com"}, "foo@example.com2, bar@example.com2"),
+				field.Invalid(field.NewPath("spec.allowed.isCA"), true, "false"),
+				field.Invalid(field.NewPath("spec.allowed.usages"), []string{"crl sign", "client auth"}, "crl sign, server auth"),
+				field.Invalid(field.NewPath("spec.allowed.subject.organizations.values"), []string{"company-1", "company-2"}, "company-3, company-4"),
+				field.Invalid(field.NewPath("spec.allowed.subject.countries.values"), []string{"country-1", "country-2"}, "country-3, country-4"),
+				field.Invalid(field.NewPath("spec.allowed.subject.organizationalUnits.values"), []string{"org-1", "org-2"}, "org-3, org-4"),
+				field.Invalid(field.NewPath("spec.allowed.subject.localities.values"), []string{"loc-1", "loc-2"}, "loc-3, loc-4"),
+				field.Invalid(field.NewPath("spec.allowed.subject.provinces.values"), []string{"prov-1", "prov-2"}, "prov-3, prov-4"),
+				field.Invalid(field.NewPath("spec.allowed.subject.streetAddresses.values"), []string{"street-1", "street-2"}, "street-3, street-4"),
+				field.Invalid(field.NewPath("spec.allowed.subject.postalCodes.values"), []string{"post-1", "post-2"}, "post-3, post-4"),
+				field.Invalid(field.NewPath("spec.allowed.subject.serialNumber.value"), "serial-1", "serial-2"),
+			}),
 		},
 		"if all allowed defined, all attributes set in request and match exactly, return Not-Denied": {
 			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t,
@@ -271,24 +268,21 @@ func Test_Evaluate(t *testing.T) {
 					},
 				},
 			},
-			expResponse: approver.EvaluationResponse{
-				Result: approver.ResultDenied,
-				Message: field.ErrorList{
-					field.Required(field.NewPath("spec.allowed.commonName.required"), "true"),
-					field.Required(field.NewPath("spec.allowed.dnsNames.required"), "true"),
-					field.Required(field.NewPath("spec.allowed.ipAddresses.required"), "true"),
-					field.Required(field.NewPath("spec.allowed.uris.required"), "true"),
-					field.Required(field.NewPath("spec.allowed.emailAddresses.required"), "true"),
-					field.Required(field.NewPath("spec.allowed.subject.organizations.required"), "true"),
-					field.Required(field.NewPath("spec.allowed.subject.countries.required"), "true"),
-					field.Required(field.NewPath("spec.allowed.subject.organizationalUnits.required"), "true"),
-					field.Required(field.NewPath("spec.allowed.subject.localities.required"), "true"),
-					field.Required(field.NewPath("spec.allowed.subject.provinces.required"), "true"),
-					field.Required(field.NewPath("spec.allowed.subject.streetAddresses.required"), "true"),
-					field.Required(field.NewPath("spec.allowed.subject.postalCodes.required"), "true"),
-					field.Required(field.NewPath("spec.allowed.subject.serialNumber.required"), "true"),
-				}.ToAggregate().Error(),
-			},
+			expResponse: deniedResponse(field.ErrorList{
+				field.Required(field.NewPath("spec.allowed.commonName.required"), "true"),
+				field.Required(field.NewPath("spec.allowed.dnsNames.required"), "true"),
+				field.Required(field.NewPath("spec.allowed.ipAddresses.required"), "true"),
+				field.Required(field.NewPath("spec.allowed.uris.required"), "true"),
+				field.Required(field.NewPath("spec.allowed.emailAddresses.required"), "true"),
+				field.Required(field.NewPath("spec.allowed.subject.organizations.required"), "true"),
+				field.Required(field.NewPath("spec.allowed.subject.countries.required"), "true"),
+				field.Required(field.NewPath("spec.allowed.subject.organizationalUnits.required"), "true"),
+				field.Required(field.NewPath("spec.allowed.subject.localities.required"), "true"),
+				field.Required(field.NewPath("spec.allowed.subject.provinces.required"), "true"),
+				field.Required(field.NewPath("spec.allowed.subject.streetAddresses.required"), "true"),
+				field.Required(field.NewPath("spec.allowed.subject.postalCodes.required"), "true"),
+				field.Required(field.NewPath("spec.allowed.subject.serialNumber.required"), "true"),
+			}),
 		},
 		"if all allowed defined as required, all of the attributes are set, return Not-Denied": {
 			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t,
@@ -365,26 +359,23 @@ func Test_Evaluate(t *testing.T) {
 					},
 				},
 			},
-			expResponse: approver.EvaluationResponse{
-				Result: approver.ResultDenied,
-				Message: field.ErrorList{
-					field.Invalid(field.NewPath("spec.allowed.commonName.validations[0]"), "hello-world", "failed rule: self.contains('cn-1')"),
-					field.Invalid(field.NewPath("spec.allowed.dnsNames.validations[0]"), "example.com", "only local namespace DNS names are allowed"),
-					field.Invalid(field.NewPath("spec.allowed.dnsNames.validations[0]"), "foo.bar", "only local namespace DNS names are allowed"),
-					field.Invalid(field.NewPath("spec.allowed.ipAddresses.validations[0]"), "1.1.1.1", "failed rule: self.startsWith('10.0.1.')"),
-					field.Invalid(field.NewPath("spec.allowed.ipAddresses.validations[0]"), "2.3.4.5", "failed rule: self.startsWith('10.0.1.')"),
-					field.Invalid(field.NewPath("spec.allowed.uris.validations[0]"), "foo.bar.com", "must be a namespced SPIFFE ID in local trust domain"),
-					field.Invalid(field.NewPath("spec.allowed.emailAddresses.validations[0]"), "bar@example.com", "failed rule: self == cr.namespace + '@example.com'"),
-					field.Invalid(field.NewPath("spec.allowed.subject.organizations.validations[0]"), "company-2", "failed rule: self == 'company-1'"),
-					field.Invalid(field.NewPath("spec.allowed.subject.countries.validations[0]"), "country-2", "failed rule: self == 'country-1'"),
-					field.Invalid(field.NewPath("spec.allowed.subject.organizationalUnits.validations[0]"), "org-2", "failed rule: self == 'org-1'"),
-					field.Invalid(field.NewPath("spec.allowed.subject.localities.validations[0]"), "loc-2", "failed rule: self == 'loc-1'"),
-					field.Invalid(field.NewPath("spec.allowed.subject.provinces.validations[0]"), "prov-2", "failed rule: self == 'prov-1'"),
-					field.Invalid(field.NewPath("spec.allowed.subject.streetAddresses.validations[0]"), "street-2", "failed rule: self == 'street-1'"),
-					field.Invalid(field.NewPath("spec.allowed.subject.postalCodes.validations[0]"), "post-2", "failed rule: self == 'post-1'"),
-					field.Invalid(field.NewPath("spec.allowed.subject.serialNumber.validations[0]"), "serial-1", "failed rule: self == 'serial-2'"),
-				}.ToAggregate().Error(),
-			},
+			expResponse: deniedResponse(field.ErrorList{
+				field.Invalid(field.NewPath("spec.allowed.commonName.validations[0]"), "hello-world", "failed rule: self.contains('cn-1')"),
+				field.Invalid(field.NewPath("spec.allowed.dnsNames.validations[0]"), "example.com", "only local namespace DNS names are allowed"),
+				field.Invalid(field.NewPath("spec.allowed.dnsNames.validations[0]"), "foo.bar", "only local namespace DNS names are allowed"),
+				field.Invalid(field.NewPath("spec.allowed.ipAddresses.validations[0]"), "1.1.1.1", "failed rule: self.startsWith('10.0.1.')"),
+				field.Invalid(field.NewPath("spec.allowed.ipAddresses.validations[0]"), "2.3.4.5", "failed rule: self.startsWith('10.0.1.')"),
+				field.Invalid(field.NewPath("spec.allowed.uris.validations[0]"), "foo.bar.com", "must be a namespced SPIFFE ID in local trust domain"),
+				field.Invalid(field.NewPath("spec.allowed.emailAddresses.validations[0]"), "bar@example.com", "failed rule: self == cr.namespace + '@example.com'"),
+				field.Invalid(field.NewPath("spec.allowed.subject.organizations.validations[0]"), "company-2", "failed rule: self == 'company-1'"),
+				field.Invalid(field.NewPath("spec.allowed.subject.countries.validations[0]"), "country-2", "failed rule: self == 'country-1'"),
+				field.Invalid(field.NewPath("spec.allowed.subject.organizationalUnits.validations[0]"), "org-2", "failed rule: self == 'org-1'"),
+				field.Invalid(field.NewPath("spec.allowed.subject.localities.validations[0]"), "loc-2", "failed rule: self == 'loc-1'"),
+				field.Invalid(field.NewPath("spec.allowed.subject.provinces.validations[0]"), "prov-2", "failed rule: self == 'prov-1'"),
+				field.Invalid(field.NewPath("spec.allowed.subject.streetAddresses.validations[0]"), "street-2", "failed rule: self == 'street-1'"),
+				field.Invalid(field.NewPath("spec.allowed.subject.postalCodes.validations[0]"), "post-2", "failed rule: self == 'post-1'"),
+				field.Invalid(field.NewPath("spec.allowed.subject.serialNumber.validations[0]"), "serial-1", "failed rule: self == 'serial-2'"),
+			}),
 		},
 		"if all has validation, and all attributes are valid, return Not-Denied": {
 			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t,
@@ -445,15 +436,162 @@ func Test_Evaluate(t *testing.T) {
 					EmailAddresses: &policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"foo@example.com"}, Validations: []policyapi.ValidationRule{{Rule: "self == cr.namespace + '@example.com'"}}},
 				},
 			},
-			expResponse: approver.EvaluationResponse{
-				Result: approver.ResultDenied,
-				Message: field.ErrorList{
-					field.Invalid(field.NewPath("spec.allowed.commonName.value"), "hello-world", "hello-world2"),
-					field.Invalid(field.NewPath("spec.allowed.uris.validations[0]"), "spiffe://cluster.local/ns/foo/sa/bar", "failed rule: self.startsWith('spiffe://foo.bar/ns/')"),
-					field.Invalid(field.NewPath("spec.allowed.emailAddresses.values"), []string{"foo@example.com", "bar@example.com"}, "foo@example.com"),
-					field.Invalid(field.NewPath("spec.allowed.emailAddresses.validations[0]"), "bar@example.com", "failed rule: self == cr.namespace + '@example.com'"),
-				}.ToAggregate().Error(),
+			expResponse: deniedResponse(field.ErrorList{
+				field.Invalid(field.NewPath("spec.allowed.commonName.value"), "hello-world", "hello-world2"),
+				field.Invalid(field.NewPath("spec.allowed.uris.validations[0]"), "spiffe://cluster.local/ns/foo/sa/bar", "failed rule: self.startsWith('spiffe://foo.bar/ns/')"),
+				field.Invalid(field.NewPath("spec.allowed.emailAddresses.values"), []string{"foo@example.com", "bar@example.com"}, "foo@example.com"),
+				field.Invalid(field.NewPath("spec.allowed.emailAddresses.validations[0]"), "bar@example.com", "failed rule: self == cr.namespace + '@example.com'"),
+			}),
+		},
+		"if otherName and registeredID SANs are requested but not allowed, return Denied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t,
+				withSANs(t, utilpki.GeneralNames{
+					OtherNames:    []utilpki.OtherName{otherName(t, "1.3.6.1.4.1.311.20.2.3", "alice@example.com")},
+					RegisteredIDs: []asn1.ObjectIdentifier{{1, 2, 3, 4}},
+				}),
+			))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{},
+			},
+			expResponse: deniedResponse(field.ErrorList{
+				field.Invalid(field.NewPath("spec.allowed.otherNames"), "1.3.6.1.4.1.311.20.2.3", "no allowed otherName of this type"),
+				field.Invalid(field.NewPath("spec.allowed.registeredIDs"), []string{"1.2.3.4"}, "no allowed values"),
+			}),
+		},
+		"if otherName and registeredID SANs match the allowed values and validations, return NotDenied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t,
+				withSANs(t, utilpki.GeneralNames{
+					OtherNames:    []utilpki.OtherName{otherName(t, "1.3.6.1.4.1.311.20.2.3", "foo@example.com")},
+					RegisteredIDs: []asn1.ObjectIdentifier{{1, 2, 3, 4}},
+				}),
+			)), gen.SetCertificateRequestNamespace("foo")),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					OtherNames: []policyapi.CertificateRequestPolicyAllowedOtherName{
+						{
+							OID: "1.3.6.1.4.1.311.20.2.3",
+							CertificateRequestPolicyAllowedStringSlice: policyapi.CertificateRequestPolicyAllowedStringSlice{
+								Values:      &[]string{"*@example.com"},
+								Validations: []policyapi.ValidationRule{{Rule: "self == cr.namespace + '@example.com'"}},
+							},
+						},
+					},
+					RegisteredIDs: &policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"1.2.3.*"}},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied, Message: ""},
+		},
+		"if otherName SANs do not match the allowed values, or are required but missing, return Denied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t,
+				withSANs(t, utilpki.GeneralNames{
+					OtherNames: []utilpki.OtherName{otherName(t, "1.3.6.1.4.1.311.20.2.3", "bar@example.org")},
+				}),
+			)), gen.SetCertificateRequestNamespace("foo")),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					OtherNames: []policyapi.CertificateRequestPolicyAllowedOtherName{
+						{
+							OID: "1.3.6.1.4.1.311.20.2.3",
+							CertificateRequestPolicyAllowedStringSlice: policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"*@example.com"}},
+						},
+						{
+							OID: "1.2.3.4",
+							CertificateRequestPolicyAllowedStringSlice: policyapi.CertificateRequestPolicyAllowedStringSlice{Required: ptr.To(true), Values: &[]string{"*"}},
+						},
+					},
+				},
+			},
+			expResponse: deniedResponse(field.ErrorList{
+				field.Invalid(field.NewPath("spec.allowed.otherNames[1.3.6.1.4.1.311.20.2.3].values"), []string{"bar@example.org"}, "*@example.com"),
+				field.Required(field.NewPath("spec.allowed.otherNames[1.2.3.4].required"), "true"),
+			}),
+		},
+		"if SAN types which cannot be allowed are requested, return Denied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t,
+				withSANs(t, utilpki.GeneralNames{
+					DirectoryNames: []pkix.RDNSequence{{{{Type: asn1.ObjectIdentifier{2, 5, 4, 3}, Value: "foo"}}}},
+				}),
+			))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{},
 			},
+			expResponse: deniedResponse(field.ErrorList{
+				field.Forbidden(field.NewPath("spec.allowed"), "directoryName SANs cannot be requested"),
+			}),
+		},
+		"if subject attributes without a dedicated field are requested but not allowed, return Denied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t,
+				noErrModifier(func(csr *x509.CertificateRequest) {
+					csr.Subject.ExtraNames = []pkix.AttributeTypeAndValue{{Type: asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 1}, Value: "foo@example.com"}}
+				}),
+			))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{},
+			},
+			expResponse: deniedResponse(field.ErrorList{
+				field.Invalid(field.NewPath("spec.allowed.subject.extraAttributes"), "1.2.840.113549.1.9.1", "no allowed subject attribute of this type"),
+			}),
+		},
+		"if subject attributes without a dedicated field match the allowed values, return NotDenied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t,
+				noErrModifier(func(csr *x509.CertificateRequest) {
+					csr.Subject.ExtraNames = []pkix.AttributeTypeAndValue{{Type: asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 1}, Value: "foo@example.com"}}
+				}),
+			))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					Subject: &policyapi.CertificateRequestPolicyAllowedX509Subject{
+						ExtraAttributes: []policyapi.CertificateRequestPolicyAllowedX509SubjectAttribute{
+							{
+								OID: "1.2.840.113549.1.9.1",
+								CertificateRequestPolicyAllowedStringSlice: policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"*@example.com"}},
+							},
+						},
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied, Message: ""},
+		},
+		"if the subject DN does not match the allowed value or validations, return Denied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t,
+				gen.SetCSRCommonName("foo"),
+				noErrModifier(func(csr *x509.CertificateRequest) { csr.Subject.Organization = []string{"company-2"} }),
+			)), gen.SetCertificateRequestNamespace("bar")),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					CommonName: &policyapi.CertificateRequestPolicyAllowedString{Value: ptr.To("*")},
+					Subject: &policyapi.CertificateRequestPolicyAllowedX509Subject{
+						Organizations: &policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"*"}},
+						DN: &policyapi.CertificateRequestPolicyAllowedString{
+							Value:       ptr.To("CN=*,O=company-1"),
+							Validations: []policyapi.ValidationRule{{Rule: "self.startsWith('CN=' + cr.namespace + ',')"}},
+						},
+					},
+				},
+			},
+			expResponse: deniedResponse(field.ErrorList{
+				field.Invalid(field.NewPath("spec.allowed.subject.dn.value"), "CN=foo,O=company-2", "CN=*,O=company-1"),
+				field.Invalid(field.NewPath("spec.allowed.subject.dn.validations[0]"), "CN=foo,O=company-2", "failed rule: self.startsWith('CN=' + cr.namespace + ',')"),
+			}),
+		},
+		"if the subject DN matches the allowed value and validations, return NotDenied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t,
+				gen.SetCSRCommonName("foo"),
+				noErrModifier(func(csr *x509.CertificateRequest) { csr.Subject.Organization = []string{"company-1"} }),
+			)), gen.SetCertificateRequestNamespace("foo")),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					CommonName: &policyapi.CertificateRequestPolicyAllowedString{Value: ptr.To("*")},
+					Subject: &policyapi.CertificateRequestPolicyAllowedX509Subject{
+						Organizations: &policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"*"}},
+						DN: &policyapi.CertificateRequestPolicyAllowedString{
+							Value:       ptr.To("CN=*,O=company-1"),
+							Validations: []policyapi.ValidationRule{{Rule: "self.startsWith('CN=' + cr.namespace + ',')"}},
+						},
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied, Message: ""},
 		},
 	}
 
@@ -483,3 +621,36 @@ func csrFrom(t *testing.T, mods ...gen.CSRModifier) []byte {
 	}
 	return csr
 }
+
+func withSANs(t *testing.T, sans utilpki.GeneralNames) gen.CSRModifier {
+	t.Helper()
+	ext, err := utilpki.MarshalSANs(sans, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return noErrModifier(func(csr *x509.CertificateRequest) {
+		csr.ExtraExtensions = append(csr.ExtraExtensions, ext)
+	})
+}
+
+func otherName(t *testing.T, oid, value string) utilpki.OtherName {
+	t.Helper()
+	typeID, err := utilpki.ParseObjectIdentifier(oid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bytes, err := utilpki.MarshalUniversalValue(utilpki.UniversalValue{UTF8String: value})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return utilpki.OtherName{
+		TypeID: typeID,
+		Value:  asn1.RawValue{Tag: 0, Class: asn1.ClassContextSpecific, IsCompound: true, Bytes: bytes},
+	}
+}
+
+// deniedResponse returns the response of an evaluator denying a request with
+// the given field errors.
+func deniedResponse(el field.ErrorList) approver.EvaluationResponse {
+	return approver.EvaluationResponse{Result: approver.ResultDenied, Message: el.ToAggregate().Error(), Errors: el}
+}
@@ -18,6 +18,8 @@ package allowed
 
 import (
 	"context"
+	"crypto/x509"
+	"fmt"
 
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
@@ -51,6 +53,15 @@ func (a allowed) Validate(_ context.Context, policy *policyapi.CertificateReques
 		{fldPath.Child("ipAddresses"), allowed.IPAddresses},
 		{fldPath.Child("uris"), allowed.URIs},
 		{fldPath.Child("emailAddresses"), allowed.EmailAddresses},
+		{fldPath.Child("registeredIDs"), allowed.RegisteredIDs},
+	}
+
+	for i := range allowed.OtherNames {
+		otherName := &allowed.OtherNames[i]
+		if _, err := x509.ParseOID(otherName.OID); err != nil {
+			el = append(el, field.Invalid(fldPath.Child("otherNames").Index(i).Child("oid"), otherName.OID, fmt.Sprintf("must be an object identifier in dotted decimal form: %s", err)))
+		}
+		stringSlices = append(stringSlices, stringSlicePair{fldPath.Child("otherNames").Key(otherName.OID), &otherName.CertificateRequestPolicyAllowedStringSlice})
 	}
 
 	type stringPair struct {
@@ -73,6 +84,17 @@ func (a allowed) Validate(_ context.Context, policy *policyapi.CertificateReques
 		stringSlices = append(stringSlices, stringSlicePair{fldPathSub.Child("postalCodes"), allowedSub.PostalCodes})
 
 		strings = append(strings, stringPair{fldPathSub.Child("serialNumber"), allowedSub.SerialNumber})
+		strings = append(strings, stringPair{fldPathSub.Child("dn"), allowedSub.DN})
+
+		for i := range allowedSub.ExtraAttributes {
+			attr := &allowedSub.ExtraAttributes[i]
+			if _, err := x509.ParseOID(attr.OID); err != nil {
+				el = append(el, field.Invalid(fldPathSub.Child("extraAttributes").Index(i).Child("oid"), attr.OID, fmt.Sprintf("must be an object identifier in dotted decimal form: %s", err)))
+			} else if knownSubjectAttributes.Has(attr.OID) {
+				el = append(el, field.Invalid(fldPathSub.Child("extraAttributes").Index(i).Child("oid"), attr.OID, "subject attribute has a dedicated field which must be used instead"))
+			}
+			stringSlices = append(stringSlices, stringSlicePair{fldPathSub.Child("extraAttributes").Key(attr.OID), &attr.CertificateRequestPolicyAllowedStringSlice})
+		}
 	}
 
 	for _, stringSlice := range stringSlices {
@@ -206,6 +206,62 @@ func Test_Validate(t *testing.T) {
 				Errors:  nil,
 			},
 		},
+		"if policy contains invalid otherName and registeredID rules, expect an Allowed=false response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Allowed: &policyapi.CertificateRequestPolicyAllowed{
+						OtherNames: []policyapi.CertificateRequestPolicyAllowedOtherName{
+							{
+								OID: "not-an-oid",
+								CertificateRequestPolicyAllowedStringSlice: policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"*"}},
+							},
+							{
+								OID: "1.3.6.1.4.1.311.20.2.3",
+								CertificateRequestPolicyAllowedStringSlice: policyapi.CertificateRequestPolicyAllowedStringSlice{Required: ptr.To(true)},
+							},
+						},
+						RegisteredIDs: &policyapi.CertificateRequestPolicyAllowedStringSlice{Validations: []policyapi.ValidationRule{{Rule: "cel"}}},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.Invalid(field.NewPath("spec.allowed.otherNames[0].oid"), "not-an-oid", "must be an object identifier in dotted decimal form: invalid oid"),
+					field.Invalid(field.NewPath("spec.allowed.registeredIDs.validations[0]"), "cel", "ERROR: <input>:1:1: undeclared reference to 'cel' (in container '')\n | cel\n | ^"),
+					field.Required(field.NewPath("spec.allowed.otherNames[1.3.6.1.4.1.311.20.2.3].values"), "at least one of 'values' or 'validations' must be defined if field is 'required'"),
+				},
+			},
+		},
+		"if policy contains invalid subject extraAttributes and dn rules, expect an Allowed=false response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Allowed: &policyapi.CertificateRequestPolicyAllowed{
+						Subject: &policyapi.CertificateRequestPolicyAllowedX509Subject{
+							ExtraAttributes: []policyapi.CertificateRequestPolicyAllowedX509SubjectAttribute{
+								{
+									OID: "not-an-oid",
+									CertificateRequestPolicyAllowedStringSlice: policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"*"}},
+								},
+								{
+									OID: "2.5.4.10",
+									CertificateRequestPolicyAllowedStringSlice: policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"*"}},
+								},
+							},
+							DN: &policyapi.CertificateRequestPolicyAllowedString{Required: ptr.To(true)},
+						},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.Invalid(field.NewPath("spec.allowed.subject.extraAttributes[0].oid"), "not-an-oid", "must be an object identifier in dotted decimal form: invalid oid"),
+					field.Invalid(field.NewPath("spec.allowed.subject.extraAttributes[1].oid"), "2.5.4.10", "subject attribute has a dedicated field which must be used instead"),
+					field.Required(field.NewPath("spec.allowed.subject.dn.value"), "at least one of 'value' or 'validations' must be defined if field is 'required'"),
+				},
+			},
+		},
 	}
 
 	for name, test := range tests {
@@ -0,0 +1,185 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package constraints
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"net"
+	"strings"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/internal/util"
+)
+
+// evaluateCA evaluates whether a request for a CA certificate satisfies the
+// CA constraints. Requests for non-CA certificates are not constrained.
+func evaluateCA(fldPath *field.Path, consts *policyapi.CertificateRequestPolicyConstraintsCA, request *cmapi.CertificateRequest, csr *x509.CertificateRequest) field.ErrorList {
+	if !request.Spec.IsCA {
+		return nil
+	}
+
+	var el field.ErrorList
+
+	if consts.NameConstraints != nil {
+		el = append(el, evaluateNameConstraints(fldPath.Child("nameConstraints"), consts.NameConstraints, csr)...)
+	}
+
+	return el
+}
+
+// evaluateNameConstraints evaluates whether the name constraints extension
+// requested in the CSR is within the name constraints constraint.
+func evaluateNameConstraints(fldPath *field.Path, consts *policyapi.CertificateRequestPolicyConstraintsCANameConstraints, csr *x509.CertificateRequest) field.ErrorList {
+	ext := findExtension(csr, oidExtensionNameConstraints)
+	if ext == nil {
+		return field.ErrorList{field.Required(fldPath, "CA must request name constraints")}
+	}
+
+	nameConstraints, err := utilpki.UnmarshalNameConstraints(ext.Value)
+	if err != nil {
+		return field.ErrorList{field.Invalid(fldPath, describeExtension(*ext), "failed to decode name constraints extension")}
+	}
+
+	var el field.ErrorList
+
+	if consts.Critical && !ext.Critical {
+		el = append(el, field.Invalid(fldPath.Child("critical"), false, "name constraints extension must be critical"))
+	}
+
+	el = append(el, evaluatePermittedSubtrees(fldPath.Child("permittedDNSDomains"), consts.PermittedDNSDomains, nameConstraints.PermittedDNSDomains)...)
+	el = append(el, evaluatePermittedSubtrees(fldPath.Child("permittedEmailAddresses"), consts.PermittedEmailAddresses, nameConstraints.PermittedEmailAddresses)...)
+	el = append(el, evaluatePermittedSubtrees(fldPath.Child("permittedURIDomains"), consts.PermittedURIDomains, nameConstraints.PermittedURIDomains)...)
+
+	var ipRanges []string
+	for _, ipRange := range nameConstraints.PermittedIPRanges {
+		ipRanges = append(ipRanges, ipRange.String())
+	}
+	if len(consts.PermittedIPRanges) > 0 && len(ipRanges) == 0 {
+		el = append(el, field.Required(fldPath.Child("permittedIPRanges"), "CA must request at least one permitted IP range"))
+	}
+	for i, ipRange := range nameConstraints.PermittedIPRanges {
+		if !cidrsContain(consts.PermittedIPRanges, ipRange) {
+			el = append(el, field.Invalid(fldPath.Child("permittedIPRanges"), ipRanges[i], strings.Join(consts.PermittedIPRanges, ", ")))
+		}
+	}
+
+	return el
+}
+
+// evaluatePermittedSubtrees evaluates whether the requested permitted
+// subtrees are all matched by the given patterns, and that at least one
+// subtree is requested if patterns are defined.
+func evaluatePermittedSubtrees(fldPath *field.Path, patterns, requested []string) field.ErrorList {
+	if len(patterns) > 0 && len(requested) == 0 {
+		return field.ErrorList{field.Required(fldPath, "CA must request at least one permitted subtree")}
+	}
+
+	var el field.ErrorList
+	for _, subtree := range requested {
+		if !util.WildcardContains(patterns, subtree) {
+			el = append(el, field.Invalid(fldPath, subtree, strings.Join(patterns, ", ")))
+		}
+	}
+	return el
+}
+
+// cidrsContain returns true if the IP range is contained within at least one
+// of the given CIDRs.
+func cidrsContain(cidrs []string, ipRange *net.IPNet) bool {
+	rangeOnes, rangeBits := ipRange.Mask.Size()
+	for _, cidr := range cidrs {
+		_, permitted, err := net.ParseCIDR(cidr)
+		if err != nil {
+			continue
+		}
+		ones, bits := permitted.Mask.Size()
+		if bits == rangeBits && ones <= rangeOnes && permitted.Contains(ipRange.IP) {
+			return true
+		}
+	}
+	return false
+}
+
+// findExtension returns the extension requested in the CSR with the given
+// OID, or nil if not requested.
+func findExtension(csr *x509.CertificateRequest, oid string) *pkix.Extension {
+	for i := range csr.Extensions {
+		if csr.Extensions[i].Id.String() == oid {
+			return &csr.Extensions[i]
+		}
+	}
+	return nil
+}
+
+// validateCA validates that the CA constraints are valid, and are only
+// defined if the policy allows requesting CA certificates.
+func validateCA(fldPath *field.Path, consts *policyapi.CertificateRequestPolicyConstraintsCA, allowed *policyapi.CertificateRequestPolicyAllowed) field.ErrorList {
+	var el field.ErrorList
+
+	if allowed == nil || allowed.IsCA == nil || !*allowed.IsCA {
+		el = append(el, field.Forbidden(fldPath, "ca constraints may only be defined if spec.allowed.isCA is true"))
+	}
+
+	if nc := consts.NameConstraints; nc != nil {
+		fldPath := fldPath.Child("nameConstraints")
+
+		if len(nc.PermittedDNSDomains) == 0 && len(nc.PermittedIPRanges) == 0 && len(nc.PermittedEmailAddresses) == 0 && len(nc.PermittedURIDomains) == 0 {
+			el = append(el, field.Required(fldPath, "at least one permitted subtree type must be defined"))
+		}
+
+		for i, cidr := range nc.PermittedIPRanges {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				el = append(el, field.Invalid(fldPath.Child("permittedIPRanges").Index(i), cidr, err.Error()))
+			}
+		}
+	}
+
+	return el
+}
+
+// unconstrainedNameTypes returns a warning if the name constraints don't
+// define permitted values for every name type. A CA whose name constraints
+// request no permitted subtrees of a name type may issue certificates for any
+// name of that type.
+func unconstrainedNameTypes(fldPath *field.Path, nc *policyapi.CertificateRequestPolicyConstraintsCANameConstraints) admission.Warnings {
+	var unconstrained []string
+	for _, nameType := range []struct {
+		name   string
+		values []string
+	}{
+		{"permittedDNSDomains", nc.PermittedDNSDomains},
+		{"permittedIPRanges", nc.PermittedIPRanges},
+		{"permittedEmailAddresses", nc.PermittedEmailAddresses},
+		{"permittedURIDomains", nc.PermittedURIDomains},
+	} {
+		if len(nameType.values) == 0 {
+			unconstrained = append(unconstrained, nameType.name)
+		}
+	}
+
+	if len(unconstrained) == 0 {
+		return nil
+	}
+	return admission.Warnings{fmt.Sprintf("%s does not define %s: CAs may issue certificates for any names of these types", fldPath, strings.Join(unconstrained, ", "))}
+}
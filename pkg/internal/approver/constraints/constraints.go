@@ -18,9 +18,16 @@ package constraints
 
 import (
 	"context"
+	"fmt"
 
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/go-logr/logr"
 	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
@@ -35,35 +42,115 @@ func init() {
 
 // Approver returns an instance on the constraints approver.
 func Approver() approver.Interface {
-	return constraints{}
+	return &constraints{
+		clock: clock.RealClock{},
+	}
 }
 
 // constraints is a base approver-policy Approver that is responsible for
 // ensuring incoming requests satisfy the constraints defined on
 // CertificateRequestPolicies. It is expected that constraints must _always_ be
 // registered for all approver-policy builds.
-type constraints struct{}
+type constraints struct {
+	// clock returns time which can be overwritten for testing.
+	clock clock.Clock
+
+	// reader is used to read the Issuers and CA Secrets of requests, for
+	// evaluating the withinIssuerCAValidity constraint. Nil if the constraint
+	// is not enabled.
+	reader client.Reader
+
+	// issuerCAValidity enables the withinIssuerCAValidity constraint, caching
+	// Issuers, ClusterIssuers and Secrets.
+	issuerCAValidity bool
+
+	// clusterResourceNamespace is the namespace in which the CA Secrets of
+	// ClusterIssuers are stored.
+	clusterResourceNamespace string
+}
 
 // Name of Approver is "constraints"
-func (c constraints) Name() string {
+func (c *constraints) Name() string {
 	return "constraints"
 }
 
-// RegisterFlags is a no-op, constraints doesn't need any flags.
-func (c constraints) RegisterFlags(_ *pflag.FlagSet) {}
+// RegisterFlags registers the flags of the withinIssuerCAValidity constraint.
+func (c *constraints) RegisterFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&c.issuerCAValidity, "enable-issuer-ca-validity", false,
+		"Enable the withinIssuerCAValidity constraint. approver-policy must be granted "+
+			"permission to list and watch Issuers, ClusterIssuers and Secrets, which are "+
+			"cached. Only the CA certificate of Secrets is kept in the cache. If disabled, "+
+			"requests evaluated against the constraint are denied.")
+
+	fs.StringVar(&c.clusterResourceNamespace, "cluster-resource-namespace", "cert-manager",
+		"Namespace in which the CA Secrets of ClusterIssuers are stored, matching "+
+			"the --cluster-resource-namespace of cert-manager. Used to evaluate the "+
+			"withinIssuerCAValidity constraint.")
+}
+
+// Prepare builds the cache of Issuers, ClusterIssuers and Secrets used to
+// evaluate the withinIssuerCAValidity constraint, if enabled. Secrets are
+// stripped of everything but their CA certificate so that caching every
+// Secret in the cluster stays cheap.
+func (c *constraints) Prepare(ctx context.Context, _ logr.Logger, mgr manager.Manager) error {
+	if !c.issuerCAValidity {
+		return nil
+	}
+
+	issuerCache, err := cache.New(mgr.GetConfig(), cache.Options{
+		Scheme:                      mgr.GetScheme(),
+		Mapper:                      mgr.GetRESTMapper(),
+		ReaderFailOnMissingInformer: true,
+		ByObject: map[client.Object]cache.ByObject{
+			new(corev1.Secret): {Transform: caCertificateOnly},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to build issuer cache: %w", err)
+	}
+
+	for _, obj := range []client.Object{new(cmapi.Issuer), new(cmapi.ClusterIssuer), new(corev1.Secret)} {
+		if _, err := issuerCache.GetInformer(ctx, obj); err != nil {
+			return fmt.Errorf("failed to build %T informer: %w", obj, err)
+		}
+	}
 
-// Prepare is a no-op, constraints doesn't need to prepare anything.
-func (c constraints) Prepare(_ context.Context, _ logr.Logger, _ manager.Manager) error {
+	if err := mgr.Add(issuerCache); err != nil {
+		return fmt.Errorf("failed to add issuer cache to manager: %w", err)
+	}
+
+	c.reader = issuerCache
 	return nil
 }
 
+// caCertificateOnly strips a Secret of everything but its name and CA
+// certificate.
+func caCertificateOnly(obj interface{}) (interface{}, error) {
+	secret, ok := obj.(*corev1.Secret)
+	if !ok {
+		return obj, nil
+	}
+	stripped := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       secret.Namespace,
+			Name:            secret.Name,
+			UID:             secret.UID,
+			ResourceVersion: secret.ResourceVersion,
+		},
+	}
+	if cert, ok := secret.Data[corev1.TLSCertKey]; ok {
+		stripped.Data = map[string][]byte{corev1.TLSCertKey: cert}
+	}
+	return stripped, nil
+}
+
 // Ready always returns ready, constraints doesn't have any dependencies to
 // block readiness.
-func (c constraints) Ready(_ context.Context, _ *policyapi.CertificateRequestPolicy) (approver.ReconcilerReadyResponse, error) {
+func (c *constraints) Ready(_ context.Context, _ *policyapi.CertificateRequestPolicy) (approver.ReconcilerReadyResponse, error) {
 	return approver.ReconcilerReadyResponse{Ready: true}, nil
 }
 
 // constraints never needs to manually enqueue policies.
-func (c constraints) EnqueueChan() <-chan string {
+func (c *constraints) EnqueueChan() <-chan string {
 	return nil
 }
@@ -0,0 +1,188 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package constraints
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+)
+
+// evaluateIssuerCA evaluates whether a certificate of the given duration,
+// issued now, would expire before the CA certificate of the request's issuer.
+func (c *constraints) evaluateIssuerCA(ctx context.Context, fldPath *field.Path, request *cmapi.CertificateRequest, duration *metav1.Duration) (field.ErrorList, error) {
+	if duration == nil {
+		return field.ErrorList{field.Invalid(fldPath, duration.String(), "a duration must be requested to be evaluated against the issuer CA expiry")}, nil
+	}
+
+	notAfter, err := c.issuerCANotAfter(ctx, request)
+	var denied deniedError
+	if errors.As(err, &denied) {
+		return field.ErrorList{field.Invalid(fldPath, describeIssuerRef(request.Spec.IssuerRef), denied.Error())}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if expiry := c.clock.Now().Add(duration.Duration); expiry.After(notAfter) {
+		return field.ErrorList{field.Invalid(fldPath, expiry.UTC().Format(time.RFC3339), fmt.Sprintf("certificate would be valid beyond the issuer CA expiry %s", notAfter.UTC().Format(time.RFC3339)))}, nil
+	}
+
+	return nil, nil
+}
+
+// deniedError is returned when the issuer CA of a request cannot be
+// determined because of the request or its issuer, rather than a transient
+// error, and so the request should be denied.
+type deniedError string
+
+func (e deniedError) Error() string {
+	return string(e)
+}
+
+// issuerCANotAfter returns the expiry of the CA certificate of the CA Issuer
+// or ClusterIssuer referenced by the request.
+func (c *constraints) issuerCANotAfter(ctx context.Context, request *cmapi.CertificateRequest) (time.Time, error) {
+	if c.reader == nil {
+		return time.Time{}, deniedError("withinIssuerCAValidity is not enabled, approver-policy must be run with --enable-issuer-ca-validity")
+	}
+
+	ref := request.Spec.IssuerRef
+	if ref.Group != "" && ref.Group != "cert-manager.io" {
+		return time.Time{}, deniedError("issuer is not a cert-manager CA issuer")
+	}
+
+	var (
+		issuer          cmapi.GenericIssuer
+		key             = client.ObjectKey{Name: ref.Name}
+		secretNamespace string
+	)
+	switch ref.Kind {
+	case "", cmapi.IssuerKind:
+		issuer = new(cmapi.Issuer)
+		key.Namespace = request.Namespace
+		secretNamespace = request.Namespace
+	case cmapi.ClusterIssuerKind:
+		issuer = new(cmapi.ClusterIssuer)
+		secretNamespace = c.clusterResourceNamespace
+	default:
+		return time.Time{}, deniedError("issuer is not a cert-manager CA issuer")
+	}
+
+	if err := c.reader.Get(ctx, key, issuer); apierrors.IsNotFound(err) {
+		return time.Time{}, deniedError("issuer not found")
+	} else if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get issuer %s: %w", describeIssuerRef(ref), err)
+	}
+
+	ca := issuer.GetSpec().CA
+	if ca == nil {
+		return time.Time{}, deniedError("issuer is not a cert-manager CA issuer")
+	}
+
+	var secret corev1.Secret
+	if err := c.reader.Get(ctx, client.ObjectKey{Namespace: secretNamespace, Name: ca.SecretName}, &secret); apierrors.IsNotFound(err) {
+		return time.Time{}, deniedError("issuer CA Secret not found")
+	} else if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get issuer CA Secret %s/%s: %w", secretNamespace, ca.SecretName, err)
+	}
+
+	cert, err := utilpki.DecodeX509CertificateBytes(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return time.Time{}, deniedError("failed to decode issuer CA certificate")
+	}
+
+	return cert.NotAfter, nil
+}
+
+// describeIssuerRef returns a human readable description of an issuer
+// reference, e.g. "ClusterIssuer.cert-manager.io/my-ca".
+func describeIssuerRef(ref cmmeta.ObjectReference) string {
+	kind, group := ref.Kind, ref.Group
+	if kind == "" {
+		kind = cmapi.IssuerKind
+	}
+	if group == "" {
+		group = "cert-manager.io"
+	}
+	return fmt.Sprintf("%s.%s/%s", kind, group, ref.Name)
+}
+
+// evaluateRenewBefore evaluates whether the renewal percentage annotated on
+// the request is within the renew before constraints. Requests without an
+// annotated renewal are not constrained.
+func evaluateRenewBefore(fldPath *field.Path, consts *policyapi.CertificateRequestPolicyConstraintsRenewBefore, request *cmapi.CertificateRequest, duration *metav1.Duration) field.ErrorList {
+	var percentage float64
+
+	if value, ok := request.Annotations[cmapi.RenewBeforePercentageAnnotationKey]; ok {
+		p, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return field.ErrorList{field.Invalid(fldPath, value, fmt.Sprintf("failed to parse %s annotation", cmapi.RenewBeforePercentageAnnotationKey))}
+		}
+		percentage = float64(p)
+	} else if value, ok := request.Annotations[cmapi.RenewBeforeAnnotationKey]; ok {
+		renewBefore, err := time.ParseDuration(value)
+		if err != nil {
+			return field.ErrorList{field.Invalid(fldPath, value, fmt.Sprintf("failed to parse %s annotation", cmapi.RenewBeforeAnnotationKey))}
+		}
+		if duration == nil || duration.Duration <= 0 {
+			return field.ErrorList{field.Invalid(fldPath, duration.String(), "a duration must be requested to be evaluated against the renewBefore annotation")}
+		}
+		percentage = float64(renewBefore) / float64(duration.Duration) * 100
+	} else {
+		return nil
+	}
+
+	var el field.ErrorList
+	formatted := strconv.FormatFloat(percentage, 'f', -1, 64) + "%"
+	if consts.MinPercentage != nil && percentage < float64(*consts.MinPercentage) {
+		el = append(el, field.Invalid(fldPath.Child("minPercentage"), formatted, fmt.Sprintf("%d%%", *consts.MinPercentage)))
+	}
+	if consts.MaxPercentage != nil && percentage > float64(*consts.MaxPercentage) {
+		el = append(el, field.Invalid(fldPath.Child("maxPercentage"), formatted, fmt.Sprintf("%d%%", *consts.MaxPercentage)))
+	}
+	return el
+}
+
+// validateRenewBefore validates that the renew before percentages are within
+// range.
+func validateRenewBefore(fldPath *field.Path, consts *policyapi.CertificateRequestPolicyConstraintsRenewBefore) field.ErrorList {
+	var el field.ErrorList
+	if consts.MinPercentage != nil && (*consts.MinPercentage < 1 || *consts.MinPercentage > 99) {
+		el = append(el, field.Invalid(fldPath.Child("minPercentage"), *consts.MinPercentage, "must be between 1 and 99 inclusive"))
+	}
+	if consts.MaxPercentage != nil && (*consts.MaxPercentage < 1 || *consts.MaxPercentage > 99) {
+		el = append(el, field.Invalid(fldPath.Child("maxPercentage"), *consts.MaxPercentage, "must be between 1 and 99 inclusive"))
+	}
+	if consts.MinPercentage != nil && consts.MaxPercentage != nil && *consts.MaxPercentage < *consts.MinPercentage {
+		el = append(el, field.Invalid(fldPath.Child("maxPercentage"), *consts.MaxPercentage, "maxPercentage must be the same value as minPercentage or larger"))
+	}
+	return el
+}
@@ -21,12 +21,17 @@ import (
 	"crypto/ecdsa"
 	"crypto/ed25519"
 	"crypto/rsa"
+	"crypto/x509"
 	"fmt"
+	"slices"
 	"strconv"
+	"strings"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
 
 	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
 	"github.com/cert-manager/approver-policy/pkg/approver"
@@ -38,7 +43,7 @@ import (
 // permitted by the passed policy.
 // If the request is denied by the constraints an explanation is returned.
 // An error signals that the policy couldn't be evaluated to completion.
-func (c constraints) Evaluate(_ context.Context, policy *policyapi.CertificateRequestPolicy, request *cmapi.CertificateRequest) (approver.EvaluationResponse, error) {
+func (c *constraints) Evaluate(ctx context.Context, policy *policyapi.CertificateRequestPolicy, request *cmapi.CertificateRequest) (approver.EvaluationResponse, error) {
 	// If no constraints defined, exit early.
 	if policy.Spec.Constraints == nil {
 		return approver.EvaluationResponse{Result: approver.ResultNotDenied, Message: ""}, nil
@@ -52,74 +57,184 @@ func (c constraints) Evaluate(_ context.Context, policy *policyapi.CertificateRe
 		fldPath = field.NewPath("spec", "constraints")
 	)
 
+	// If the request contains no duration, assume cert-manager's default
+	// duration if enabled.
+	duration := request.Spec.Duration
+	if duration == nil && ptr.Deref(consts.AssumeDefaultDuration, false) {
+		duration = &metav1.Duration{Duration: cmapi.DefaultCertificateDuration}
+	}
+
 	if consts.MaxDuration != nil {
 		// If the request contains no duration or the maxDuration is smaller than requested, append error.
-		if request.Spec.Duration == nil {
-			el = append(el, field.Invalid(fldPath.Child("maxDuration"), request.Spec.Duration.String(), consts.MaxDuration.Duration.String()))
-		} else if consts.MaxDuration.Duration < request.Spec.Duration.Duration {
-			el = append(el, field.Invalid(fldPath.Child("maxDuration"), request.Spec.Duration.Duration.String(), consts.MaxDuration.Duration.String()))
+		if duration == nil {
+			el = append(el, field.Invalid(fldPath.Child("maxDuration"), duration.String(), consts.MaxDuration.Duration.String()))
+		} else if consts.MaxDuration.Duration < duration.Duration {
+			el = append(el, field.Invalid(fldPath.Child("maxDuration"), duration.Duration.String(), consts.MaxDuration.Duration.String()))
 		}
 	}
 
 	if consts.MinDuration != nil {
 		// If the request contains no duration or the minDuration is larger than requested, append error.
-		if request.Spec.Duration == nil {
-			el = append(el, field.Invalid(fldPath.Child("minDuration"), request.Spec.Duration.String(), consts.MinDuration.Duration.String()))
-		} else if consts.MinDuration.Duration > request.Spec.Duration.Duration {
-			el = append(el, field.Invalid(fldPath.Child("minDuration"), request.Spec.Duration.Duration.String(), consts.MinDuration.Duration.String()))
+		if duration == nil {
+			el = append(el, field.Invalid(fldPath.Child("minDuration"), duration.String(), consts.MinDuration.Duration.String()))
+		} else if consts.MinDuration.Duration > duration.Duration {
+			el = append(el, field.Invalid(fldPath.Child("minDuration"), duration.Duration.String(), consts.MinDuration.Duration.String()))
 		}
 	}
 
-	if consts.PrivateKey != nil {
-		fldPath := fldPath.Child("privateKey")
+	if ptr.Deref(consts.WithinIssuerCAValidity, false) {
+		issuerCAErrs, err := c.evaluateIssuerCA(ctx, fldPath.Child("withinIssuerCAValidity"), request, duration)
+		if err != nil {
+			return approver.EvaluationResponse{}, err
+		}
+		el = append(el, issuerCAErrs...)
+	}
 
-		// Decode CSR from CertificateRequest
-		csr, err := utilpki.DecodeX509CertificateRequestBytes(request.Spec.Request)
+	if consts.RenewBefore != nil {
+		el = append(el, evaluateRenewBefore(fldPath.Child("renewBefore"), consts.RenewBefore, request, duration)...)
+	}
+
+	// Decode CSR from CertificateRequest if any constraints need it.
+	var csr *x509.CertificateRequest
+	if consts.PrivateKey != nil || consts.Extensions != nil || consts.CA != nil {
+		var err error
+		csr, err = utilpki.DecodeX509CertificateRequestBytes(request.Spec.Request)
 		if err != nil {
 			return approver.EvaluationResponse{}, err
 		}
+	}
 
-		alg, size, err := decodePublicKey(csr.PublicKey)
+	if consts.PrivateKey != nil {
+		fldPath := fldPath.Child("privateKey")
+
+		key, err := decodePublicKey(csr.PublicKey)
 		if err != nil {
 			return approver.EvaluationResponse{}, err
 		}
 
-		if consts.PrivateKey.Algorithm != nil && *consts.PrivateKey.Algorithm != alg {
-			el = append(el, field.Invalid(fldPath.Child("algorithm"), string(alg), string(*consts.PrivateKey.Algorithm)))
+		if consts.PrivateKey.Algorithm != nil && *consts.PrivateKey.Algorithm != key.algorithm {
+			el = append(el, field.Invalid(fldPath.Child("algorithm"), string(key.algorithm), string(*consts.PrivateKey.Algorithm)))
+		}
+
+		if consts.PrivateKey.MaxSize != nil && *consts.PrivateKey.MaxSize < key.size {
+			el = append(el, field.Invalid(fldPath.Child("maxSize"), strconv.Itoa(key.size), strconv.Itoa(*consts.PrivateKey.MaxSize)))
+		}
+
+		if consts.PrivateKey.MinSize != nil && *consts.PrivateKey.MinSize > key.size {
+			el = append(el, field.Invalid(fldPath.Child("minSize"), strconv.Itoa(key.size), strconv.Itoa(*consts.PrivateKey.MinSize)))
+		}
+
+		if len(consts.PrivateKey.Curves) > 0 && key.algorithm == cmapi.ECDSAKeyAlgorithm && !slices.Contains(consts.PrivateKey.Curves, key.curve) {
+			el = append(el, field.Invalid(fldPath.Child("curves"), key.curve, strings.Join(consts.PrivateKey.Curves, ", ")))
+		}
+
+		if len(consts.PrivateKey.SignatureAlgorithms) > 0 && !slices.Contains(consts.PrivateKey.SignatureAlgorithms, csr.SignatureAlgorithm.String()) {
+			el = append(el, field.Invalid(fldPath.Child("signatureAlgorithms"), csr.SignatureAlgorithm.String(), strings.Join(consts.PrivateKey.SignatureAlgorithms, ", ")))
 		}
 
-		if consts.PrivateKey.MaxSize != nil && *consts.PrivateKey.MaxSize < size {
-			el = append(el, field.Invalid(fldPath.Child("maxSize"), strconv.Itoa(size), strconv.Itoa(*consts.PrivateKey.MaxSize)))
+		if len(consts.PrivateKey.AnyOf) > 0 && !slices.ContainsFunc(consts.PrivateKey.AnyOf, key.matches) {
+			options := make([]string, 0, len(consts.PrivateKey.AnyOf))
+			for _, option := range consts.PrivateKey.AnyOf {
+				options = append(options, describePrivateKeyOption(option))
+			}
+			el = append(el, field.Invalid(fldPath.Child("anyOf"), key.String(), strings.Join(options, " OR ")))
 		}
+	}
 
-		if consts.PrivateKey.MinSize != nil && *consts.PrivateKey.MinSize > size {
-			el = append(el, field.Invalid(fldPath.Child("minSize"), strconv.Itoa(size), strconv.Itoa(*consts.PrivateKey.MinSize)))
+	if consts.Extensions != nil {
+		var allowedUsages *[]cmapi.KeyUsage
+		if policy.Spec.Allowed != nil {
+			allowedUsages = policy.Spec.Allowed.Usages
 		}
+		el = append(el, evaluateExtensions(fldPath.Child("extensions"), consts.Extensions, allowedUsages, request, csr)...)
+	}
+
+	if consts.CA != nil {
+		el = append(el, evaluateCA(fldPath.Child("ca"), consts.CA, request, csr)...)
 	}
 
 	// If there are errors, then return not approved and the aggregated errors
 	if len(el) > 0 {
-		return approver.EvaluationResponse{Result: approver.ResultDenied, Message: el.ToAggregate().Error()}, nil
+		return approver.EvaluationResponse{Result: approver.ResultDenied, Message: el.ToAggregate().Error(), Errors: el}, nil
 	}
 
 	// If no evaluation errors resulting from this policy, return not denied
 	return approver.EvaluationResponse{Result: approver.ResultNotDenied}, nil
 }
 
-// decodePublicKey will return the algorithm and size of the given public key.
-// If the public key cannot be decoded, an error is returned.
-func decodePublicKey(pub interface{}) (cmapi.PrivateKeyAlgorithm, int, error) {
+// publicKey is the algorithm and parameters of a decoded public key.
+type publicKey struct {
+	algorithm cmapi.PrivateKeyAlgorithm
+
+	// size is the size of the key in bits, or -1 for Ed25519 keys.
+	size int
+
+	// curve is the name of the elliptic curve of ECDSA keys, e.g. "P-256".
+	curve string
+}
+
+// String returns a human readable description of the public key, e.g. "RSA
+// 2048" or "ECDSA P-256".
+func (k publicKey) String() string {
+	switch k.algorithm {
+	case cmapi.ECDSAKeyAlgorithm:
+		return fmt.Sprintf("%s %s", k.algorithm, k.curve)
+	case cmapi.RSAKeyAlgorithm:
+		return fmt.Sprintf("%s %d", k.algorithm, k.size)
+	default:
+		return string(k.algorithm)
+	}
+}
+
+// matches returns true if the public key satisfies the given private key
+// option.
+func (k publicKey) matches(option policyapi.CertificateRequestPolicyConstraintsPrivateKeyOption) bool {
+	if option.Algorithm != k.algorithm {
+		return false
+	}
+	if option.MinSize != nil && *option.MinSize > k.size {
+		return false
+	}
+	if option.MaxSize != nil && *option.MaxSize < k.size {
+		return false
+	}
+	if len(option.Curves) > 0 && !slices.Contains(option.Curves, k.curve) {
+		return false
+	}
+	return true
+}
+
+// describePrivateKeyOption returns a human readable description of a private
+// key option, e.g. "RSA >=3072" or "ECDSA P-256/P-384".
+func describePrivateKeyOption(option policyapi.CertificateRequestPolicyConstraintsPrivateKeyOption) string {
+	description := []string{string(option.Algorithm)}
+	if option.MinSize != nil {
+		description = append(description, fmt.Sprintf(">=%d", *option.MinSize))
+	}
+	if option.MaxSize != nil {
+		description = append(description, fmt.Sprintf("<=%d", *option.MaxSize))
+	}
+	if len(option.Curves) > 0 {
+		description = append(description, strings.Join(option.Curves, "/"))
+	}
+	return strings.Join(description, " ")
+}
+
+// decodePublicKey will return the algorithm and parameters of the given
+// public key. If the public key cannot be decoded, an error is returned.
+func decodePublicKey(pub interface{}) (publicKey, error) {
 	switch pubKey := pub.(type) {
 	case *rsa.PublicKey:
-		return cmapi.RSAKeyAlgorithm, pubKey.N.BitLen(), nil
+		return publicKey{algorithm: cmapi.RSAKeyAlgorithm, size: pubKey.N.BitLen()}, nil
 
 	case *ecdsa.PublicKey:
-		return cmapi.ECDSAKeyAlgorithm, pubKey.Curve.Params().BitSize, nil
+		params := pubKey.Curve.Params()
+		return publicKey{algorithm: cmapi.ECDSAKeyAlgorithm, size: params.BitSize, curve: params.Name}, nil
 
 	case ed25519.PublicKey:
-		return cmapi.Ed25519KeyAlgorithm, -1, nil
+		return publicKey{algorithm: cmapi.Ed25519KeyAlgorithm, size: -1}, nil
 
 	default:
-		return "", -1, fmt.Errorf("unrecognised public key type %T", pub)
+		return publicKey{}, fmt.Errorf("unrecognised public key type %T", pub)
 	}
 }
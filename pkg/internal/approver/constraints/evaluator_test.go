@@ -17,16 +17,32 @@ limitations under the License.
 package constraints
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
 	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"math/big"
+	"net"
 	"testing"
 	"time"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	fakeclock "k8s.io/utils/clock/testing"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
 	"github.com/cert-manager/approver-policy/pkg/approver"
@@ -37,6 +53,12 @@ func Test_Evaluate(t *testing.T) {
 		ecdsaAlg   = cmapi.ECDSAKeyAlgorithm
 		ed25519Alg = cmapi.Ed25519KeyAlgorithm
 		rsaAlg     = cmapi.RSAKeyAlgorithm
+
+		anyOfOptions = []policyapi.CertificateRequestPolicyConstraintsPrivateKeyOption{
+			{Algorithm: cmapi.RSAKeyAlgorithm, MinSize: ptr.To(3072)},
+			{Algorithm: cmapi.ECDSAKeyAlgorithm, Curves: []string{"P-256", "P-384"}},
+			{Algorithm: cmapi.Ed25519KeyAlgorithm},
+		}
 	)
 
 	tests := map[string]struct {
@@ -73,13 +95,76 @@ func Test_Evaluate(t *testing.T) {
 					MaxDuration: &metav1.Duration{Duration: time.Hour * 24},
 				},
 			},
-			expResponse: approver.EvaluationResponse{
-				Result: approver.ResultDenied,
-				Message: field.ErrorList{
-					field.Invalid(field.NewPath("spec.constraints.maxDuration"), "nil", "24h0m0s"),
-					field.Invalid(field.NewPath("spec.constraints.minDuration"), "nil", "1h0m0s"),
-				}.ToAggregate().Error(),
+			expResponse: deniedResponse(field.ErrorList{
+				field.Invalid(field.NewPath("spec.constraints.maxDuration"), "nil", "24h0m0s"),
+				field.Invalid(field.NewPath("spec.constraints.minDuration"), "nil", "1h0m0s"),
+			}),
+		},
+		"if constraints contains duration and assumes the default duration, duration wasn't requested, evaluate cert-manager's default duration": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestDuration(nil),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					MinDuration:           &metav1.Duration{Duration: time.Hour},
+					MaxDuration:           &metav1.Duration{Duration: time.Hour * 24},
+					AssumeDefaultDuration: ptr.To(true),
+				},
+			},
+			expResponse: deniedResponse(field.ErrorList{
+				field.Invalid(field.NewPath("spec.constraints.maxDuration"), "2160h0m0s", "24h0m0s"),
+			}),
+		},
+		"if constraints contains renewBefore and the request is not annotated with a renewal, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestDuration(&metav1.Duration{Duration: time.Hour}),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					RenewBefore: &policyapi.CertificateRequestPolicyConstraintsRenewBefore{MinPercentage: ptr.To[int32](20), MaxPercentage: ptr.To[int32](50)},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints contains renewBefore and the annotated renewal percentage is out of range, return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestDuration(&metav1.Duration{Duration: time.Hour}),
+				gen.AddCertificateRequestAnnotations(map[string]string{cmapi.RenewBeforePercentageAnnotationKey: "10"}),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					RenewBefore: &policyapi.CertificateRequestPolicyConstraintsRenewBefore{MinPercentage: ptr.To[int32](20), MaxPercentage: ptr.To[int32](50)},
+				},
+			},
+			expResponse: deniedResponse(field.ErrorList{
+				field.Invalid(field.NewPath("spec.constraints.renewBefore.minPercentage"), "10%", "20%"),
+			}),
+		},
+		"if constraints contains renewBefore and the annotated renewBefore is too large a ratio of the duration, return Denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestDuration(&metav1.Duration{Duration: time.Hour}),
+				gen.AddCertificateRequestAnnotations(map[string]string{cmapi.RenewBeforeAnnotationKey: "45m"}),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					RenewBefore: &policyapi.CertificateRequestPolicyConstraintsRenewBefore{MinPercentage: ptr.To[int32](20), MaxPercentage: ptr.To[int32](50)},
+				},
+			},
+			expResponse: deniedResponse(field.ErrorList{
+				field.Invalid(field.NewPath("spec.constraints.renewBefore.maxPercentage"), "75%", "50%"),
+			}),
+		},
+		"if constraints contains renewBefore and the annotated renewBefore is within range of the duration, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestDuration(&metav1.Duration{Duration: time.Hour}),
+				gen.AddCertificateRequestAnnotations(map[string]string{cmapi.RenewBeforeAnnotationKey: "20m"}),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					RenewBefore: &policyapi.CertificateRequestPolicyConstraintsRenewBefore{MinPercentage: ptr.To[int32](20), MaxPercentage: ptr.To[int32](50)},
+				},
 			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
 		},
 		"if constraints contains duration but requested duration is too small, return Denied": {
 			request: gen.CertificateRequest("",
@@ -91,10 +176,7 @@ func Test_Evaluate(t *testing.T) {
 					MaxDuration: &metav1.Duration{Duration: time.Hour * 24},
 				},
 			},
-			expResponse: approver.EvaluationResponse{
-				Result:  approver.ResultDenied,
-				Message: field.ErrorList{field.Invalid(field.NewPath("spec.constraints.minDuration"), "1m0s", "1h0m0s")}.ToAggregate().Error(),
-			},
+			expResponse: deniedResponse(field.ErrorList{field.Invalid(field.NewPath("spec.constraints.minDuration"), "1m0s", "1h0m0s")}),
 		},
 		"if constraints contains duration but requested duration is too large, return Denied": {
 			request: gen.CertificateRequest("",
@@ -106,12 +188,9 @@ func Test_Evaluate(t *testing.T) {
 					MaxDuration: &metav1.Duration{Duration: time.Hour * 24},
 				},
 			},
-			expResponse: approver.EvaluationResponse{
-				Result: approver.ResultDenied,
-				Message: field.ErrorList{
-					field.Invalid(field.NewPath("spec.constraints.maxDuration"), "48h0m0s", "24h0m0s"),
-				}.ToAggregate().Error(),
-			},
+			expResponse: deniedResponse(field.ErrorList{
+				field.Invalid(field.NewPath("spec.constraints.maxDuration"), "48h0m0s", "24h0m0s"),
+			}),
 		},
 		"if constraints contains private key but CSR fails to decode, return error": {
 			request: gen.CertificateRequest("",
@@ -137,13 +216,10 @@ func Test_Evaluate(t *testing.T) {
 					},
 				},
 			},
-			expResponse: approver.EvaluationResponse{
-				Result: approver.ResultDenied,
-				Message: field.ErrorList{
-					field.Invalid(field.NewPath("spec.constraints.privateKey.algorithm"), "RSA", "ECDSA"),
-					field.Invalid(field.NewPath("spec.constraints.privateKey.minSize"), "2048", "4000"),
-				}.ToAggregate().Error(),
-			},
+			expResponse: deniedResponse(field.ErrorList{
+				field.Invalid(field.NewPath("spec.constraints.privateKey.algorithm"), "RSA", "ECDSA"),
+				field.Invalid(field.NewPath("spec.constraints.privateKey.minSize"), "2048", "4000"),
+			}),
 		},
 		"if constraints contains private key but CSR uses the wrong key type and is too large, return error": {
 			request: gen.CertificateRequest("",
@@ -157,13 +233,308 @@ func Test_Evaluate(t *testing.T) {
 					},
 				},
 			},
-			expResponse: approver.EvaluationResponse{
-				Result: approver.ResultDenied,
-				Message: field.ErrorList{
-					field.Invalid(field.NewPath("spec.constraints.privateKey.algorithm"), "ECDSA", "RSA"),
-					field.Invalid(field.NewPath("spec.constraints.privateKey.maxSize"), "256", "200"),
-				}.ToAggregate().Error(),
+			expResponse: deniedResponse(field.ErrorList{
+				field.Invalid(field.NewPath("spec.constraints.privateKey.algorithm"), "ECDSA", "RSA"),
+				field.Invalid(field.NewPath("spec.constraints.privateKey.maxSize"), "256", "200"),
+			}),
+		},
+		"if constraints contains curves and CSR uses a different ECDSA curve, return denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrWithSigner(t, ecdsaKey(t, elliptic.P256()))),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					PrivateKey: &policyapi.CertificateRequestPolicyConstraintsPrivateKey{
+						Curves: []string{"P-384", "P-521"},
+					},
+				},
+			},
+			expResponse: deniedResponse(field.ErrorList{
+				field.Invalid(field.NewPath("spec.constraints.privateKey.curves"), "P-256", "P-384, P-521"),
+			}),
+		},
+		"if constraints contains curves and CSR uses a non-ECDSA key, return NotDenied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t, x509.RSA))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					PrivateKey: &policyapi.CertificateRequestPolicyConstraintsPrivateKey{
+						Curves: []string{"P-384"},
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints contains signature algorithms and CSR is signed with a different algorithm, return denied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrFrom(t, x509.RSA))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					PrivateKey: &policyapi.CertificateRequestPolicyConstraintsPrivateKey{
+						SignatureAlgorithms: []string{"SHA256-RSAPSS", "SHA384-RSAPSS"},
+					},
+				},
+			},
+			expResponse: deniedResponse(field.ErrorList{
+				field.Invalid(field.NewPath("spec.constraints.privateKey.signatureAlgorithms"), "SHA256-RSA", "SHA256-RSAPSS, SHA384-RSAPSS"),
+			}),
+		},
+		"if constraints contains signature algorithms and CSR is signed with an allowed algorithm, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrWithSigner(t, rsaKey(t, 2048), func(cr *x509.CertificateRequest) error {
+					cr.SignatureAlgorithm = x509.SHA256WithRSAPSS
+					return nil
+				})),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					PrivateKey: &policyapi.CertificateRequestPolicyConstraintsPrivateKey{
+						SignatureAlgorithms: []string{"SHA256-RSAPSS", "SHA384-RSAPSS"},
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints contains anyOf and CSR key matches no option, return denied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrWithSigner(t, rsaKey(t, 2048)))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					PrivateKey: &policyapi.CertificateRequestPolicyConstraintsPrivateKey{
+						AnyOf: anyOfOptions,
+					},
+				},
+			},
+			expResponse: deniedResponse(field.ErrorList{
+				field.Invalid(field.NewPath("spec.constraints.privateKey.anyOf"), "RSA 2048", "RSA >=3072 OR ECDSA P-256/P-384 OR Ed25519"),
+			}),
+		},
+		"if constraints contains anyOf and CSR key uses a curve of no option, return denied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrWithSigner(t, ecdsaKey(t, elliptic.P521())))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					PrivateKey: &policyapi.CertificateRequestPolicyConstraintsPrivateKey{
+						AnyOf: anyOfOptions,
+					},
+				},
+			},
+			expResponse: deniedResponse(field.ErrorList{
+				field.Invalid(field.NewPath("spec.constraints.privateKey.anyOf"), "ECDSA P-521", "RSA >=3072 OR ECDSA P-256/P-384 OR Ed25519"),
+			}),
+		},
+		"if constraints contains anyOf and CSR key matches an option, return NotDenied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrWithSigner(t, ecdsaKey(t, elliptic.P384())))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					PrivateKey: &policyapi.CertificateRequestPolicyConstraintsPrivateKey{
+						AnyOf: anyOfOptions,
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints contains denied extensions and CSR requests a denied extension, return denied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrWithExtensions(t, customExtension(true)))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					Extensions: &policyapi.CertificateRequestPolicyConstraintsExtensions{
+						Denied: []policyapi.CertificateRequestPolicyConstraintsExtension{{OID: "1.2.3.4"}},
+					},
+				},
+			},
+			expResponse: deniedResponse(field.ErrorList{
+				field.Invalid(field.NewPath("spec.constraints.extensions.denied"), "1.2.3.4 (critical)", "extension is denied"),
+			}),
+		},
+		"if constraints contains allowed extensions and CSR requests an extension with a different critical flag, return denied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrWithExtensions(t, customExtension(true)))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					Extensions: &policyapi.CertificateRequestPolicyConstraintsExtensions{
+						Allowed: []policyapi.CertificateRequestPolicyConstraintsExtension{{OID: "1.2.3.4", Critical: ptr.To(false)}},
+					},
+				},
+			},
+			expResponse: deniedResponse(field.ErrorList{
+				field.Invalid(field.NewPath("spec.constraints.extensions.allowed"), "1.2.3.4 (critical)", "extension is not allowed"),
+			}),
+		},
+		"if constraints contains allowed extensions and CSR only requests allowed extensions, return NotDenied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrWithExtensions(t, customExtension(false), gen.SetCSRDNSNames("example.com")))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					Extensions: &policyapi.CertificateRequestPolicyConstraintsExtensions{
+						Allowed: []policyapi.CertificateRequestPolicyConstraintsExtension{{OID: "1.2.3.4", Critical: ptr.To(false)}},
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints contains extensions and CSR requests an extended key usage without a key usage which is not allowed, return denied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrWithExtensions(t, extendedKeyUsageExtension(t,
+				asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 3, 1},
+				asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 311, 20, 2, 2},
+			)))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{Usages: &[]cmapi.KeyUsage{cmapi.UsageServerAuth}},
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					Extensions: &policyapi.CertificateRequestPolicyConstraintsExtensions{
+						ExtendedKeyUsages: []string{"1.3.6.1.4.1.311.10.3.4"},
+					},
+				},
+			},
+			expResponse: deniedResponse(field.ErrorList{
+				field.Invalid(field.NewPath("spec.constraints.extensions.extendedKeyUsages"), "1.3.6.1.4.1.311.20.2.2", "1.3.6.1.4.1.311.10.3.4"),
+			}),
+		},
+		"if constraints contains extensions and CSR requests an allowed extended key usage, return NotDenied": {
+			request: gen.CertificateRequest("", gen.SetCertificateRequestCSR(csrWithExtensions(t, extendedKeyUsageExtension(t,
+				asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 3, 1},
+				asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 311, 20, 2, 2},
+			)))),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{Usages: &[]cmapi.KeyUsage{cmapi.UsageServerAuth}},
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					Extensions: &policyapi.CertificateRequestPolicyConstraintsExtensions{
+						ExtendedKeyUsages: []string{"1.3.6.1.4.1.311.20.2.2"},
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints contains extensions and CSR requests usages which are not allowed, return denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestKeyUsages(cmapi.UsageDigitalSignature),
+				gen.SetCertificateRequestCSR(csrWithExtensions(t,
+					keyUsageExtension(t, x509.KeyUsageDigitalSignature|x509.KeyUsageCertSign),
+					withExtension(extendedKeyUsageExtension(t, asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 3, 2})),
+				)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{Usages: &[]cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageServerAuth}},
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					Extensions: &policyapi.CertificateRequestPolicyConstraintsExtensions{},
+				},
+			},
+			expResponse: deniedResponse(field.ErrorList{
+				field.Invalid(field.NewPath("spec.constraints.extensions"), "extended key usage 1.3.6.1.5.5.7.3.2", "extended key usage must be allowed by spec.allowed.usages"),
+				field.Invalid(field.NewPath("spec.constraints.extensions"), "key usage cert sign", "key usage must be allowed by spec.allowed.usages"),
+			}),
+		},
+		"if constraints contains extensions and CSR requests the default usages of a request without usages, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestCSR(csrWithExtensions(t, keyUsageExtension(t, x509.KeyUsageDigitalSignature|x509.KeyUsageKeyEncipherment))),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					Extensions: &policyapi.CertificateRequestPolicyConstraintsExtensions{},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints contains extensions and CSR basic constraints disagree with the request, return denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestIsCA(false),
+				gen.SetCertificateRequestCSR(csrWithExtensions(t, basicConstraintsExtension(t, true, ptr.To(1)))),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					Extensions: &policyapi.CertificateRequestPolicyConstraintsExtensions{},
+				},
+			},
+			expResponse: deniedResponse(field.ErrorList{
+				field.Invalid(field.NewPath("spec.constraints.extensions"), "basic constraints isCA=true", "must match the request spec.isCA=false"),
+			}),
+		},
+		"if constraints contains extensions and CSR basic constraints agree with the request, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestIsCA(true),
+				gen.SetCertificateRequestCSR(csrWithExtensions(t, basicConstraintsExtension(t, true, ptr.To(1)))),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					Extensions: &policyapi.CertificateRequestPolicyConstraintsExtensions{},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints contains ca but the request is not for a CA, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestIsCA(false),
+				gen.SetCertificateRequestCSR(csrFrom(t, x509.ECDSA)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					CA: &policyapi.CertificateRequestPolicyConstraintsCA{
+						NameConstraints: &policyapi.CertificateRequestPolicyConstraintsCANameConstraints{PermittedDNSDomains: []string{"example.com"}},
+					},
+				},
+			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if constraints contains ca and CA requests no name constraints, return denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestIsCA(true),
+				gen.SetCertificateRequestCSR(csrWithExtensions(t, basicConstraintsExtension(t, true, nil))),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					CA: &policyapi.CertificateRequestPolicyConstraintsCA{
+						NameConstraints: &policyapi.CertificateRequestPolicyConstraintsCANameConstraints{PermittedDNSDomains: []string{"example.com"}},
+					},
+				},
+			},
+			expResponse: deniedResponse(field.ErrorList{
+				field.Required(field.NewPath("spec.constraints.ca.nameConstraints"), "CA must request name constraints"),
+			}),
+		},
+		"if constraints contains ca and CA requests name constraints which are too broad, return denied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestIsCA(true),
+				gen.SetCertificateRequestCSR(csrWithExtensions(t, basicConstraintsExtension(t, true, ptr.To(2)),
+					withExtension(nameConstraintsExtension(t, &utilpki.NameConstraints{
+						PermittedDNSDomains: []string{"team.example.com", "example.org"},
+						PermittedIPRanges:   []*net.IPNet{mustParseCIDR(t, "10.0.0.0/8")},
+					}, false)),
+				)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					CA: &policyapi.CertificateRequestPolicyConstraintsCA{
+						NameConstraints: &policyapi.CertificateRequestPolicyConstraintsCANameConstraints{
+							Critical:            true,
+							PermittedDNSDomains: []string{"team.example.com", "*.team.example.com"},
+							PermittedIPRanges:   []string{"10.1.0.0/16"},
+						},
+					},
+				},
+			},
+			expResponse: deniedResponse(field.ErrorList{
+				field.Invalid(field.NewPath("spec.constraints.ca.nameConstraints.critical"), false, "name constraints extension must be critical"),
+				field.Invalid(field.NewPath("spec.constraints.ca.nameConstraints.permittedDNSDomains"), "example.org", "team.example.com, *.team.example.com"),
+				field.Invalid(field.NewPath("spec.constraints.ca.nameConstraints.permittedIPRanges"), "10.0.0.0/8", "10.1.0.0/16"),
+			}),
+		},
+		"if constraints contains ca and CA requests name constraints within the constraints, return NotDenied": {
+			request: gen.CertificateRequest("",
+				gen.SetCertificateRequestIsCA(true),
+				gen.SetCertificateRequestCSR(csrWithExtensions(t, basicConstraintsExtension(t, true, ptr.To(0)),
+					withExtension(nameConstraintsExtension(t, &utilpki.NameConstraints{
+						PermittedDNSDomains: []string{"team.example.com", "api.team.example.com"},
+						PermittedIPRanges:   []*net.IPNet{mustParseCIDR(t, "10.1.2.0/24")},
+						ExcludedDNSDomains:  []string{"secret.team.example.com"},
+					}, true)),
+				)),
+			),
+			policy: policyapi.CertificateRequestPolicySpec{
+				Constraints: &policyapi.CertificateRequestPolicyConstraints{
+					CA: &policyapi.CertificateRequestPolicyConstraintsCA{
+						NameConstraints: &policyapi.CertificateRequestPolicyConstraintsCANameConstraints{
+							Critical:            true,
+							PermittedDNSDomains: []string{"team.example.com", "*.team.example.com"},
+							PermittedIPRanges:   []string{"10.1.0.0/16"},
+						},
+					},
+				},
 			},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
 		},
 	}
 
@@ -176,6 +547,134 @@ func Test_Evaluate(t *testing.T) {
 	}
 }
 
+func Test_Evaluate_WithinIssuerCAValidity(t *testing.T) {
+	fixedTime := time.Date(2021, 01, 01, 01, 0, 0, 0, time.UTC)
+
+	caSecret := func(namespace, name string, notAfter time.Time) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Data:       map[string][]byte{corev1.TLSCertKey: caCertificate(t, notAfter)},
+		}
+	}
+
+	policy := &policyapi.CertificateRequestPolicy{
+		Spec: policyapi.CertificateRequestPolicySpec{
+			Constraints: &policyapi.CertificateRequestPolicyConstraints{
+				WithinIssuerCAValidity: ptr.To(true),
+				AssumeDefaultDuration:  ptr.To(true),
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		existingObjects []client.Object
+		request         *cmapi.CertificateRequest
+		expResponse     approver.EvaluationResponse
+		expErr          bool
+	}{
+		"if the issuer does not exist, return Denied": {
+			request: gen.CertificateRequest("test", gen.SetCertificateRequestNamespace("test-ns"),
+				gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Name: "my-ca"}),
+			),
+			expResponse: deniedResponse(field.ErrorList{
+				field.Invalid(field.NewPath("spec.constraints.withinIssuerCAValidity"), "Issuer.cert-manager.io/my-ca", "issuer not found"),
+			}),
+		},
+		"if the issuer is not a CA issuer, return Denied": {
+			existingObjects: []client.Object{
+				gen.Issuer("my-ca", gen.SetIssuerNamespace("test-ns"), gen.SetIssuerSelfSigned(cmapi.SelfSignedIssuer{})),
+			},
+			request: gen.CertificateRequest("test", gen.SetCertificateRequestNamespace("test-ns"),
+				gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Name: "my-ca", Kind: "Issuer"}),
+			),
+			expResponse: deniedResponse(field.ErrorList{
+				field.Invalid(field.NewPath("spec.constraints.withinIssuerCAValidity"), "Issuer.cert-manager.io/my-ca", "issuer is not a cert-manager CA issuer"),
+			}),
+		},
+		"if the certificate would outlive the Issuer CA, return Denied": {
+			existingObjects: []client.Object{
+				gen.Issuer("my-ca", gen.SetIssuerNamespace("test-ns"), gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca"})),
+				caSecret("test-ns", "ca", fixedTime.Add(time.Hour*24)),
+			},
+			request: gen.CertificateRequest("test", gen.SetCertificateRequestNamespace("test-ns"),
+				gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Name: "my-ca"}),
+				gen.SetCertificateRequestDuration(&metav1.Duration{Duration: time.Hour * 48}),
+			),
+			expResponse: deniedResponse(field.ErrorList{
+				field.Invalid(field.NewPath("spec.constraints.withinIssuerCAValidity"), "2021-01-03T01:00:00Z", "certificate would be valid beyond the issuer CA expiry 2021-01-02T01:00:00Z"),
+			}),
+		},
+		"if the default duration would outlive the ClusterIssuer CA, return Denied": {
+			existingObjects: []client.Object{
+				gen.ClusterIssuer("my-ca", gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca"})),
+				caSecret("cert-manager", "ca", fixedTime.Add(time.Hour*24)),
+			},
+			request: gen.CertificateRequest("test", gen.SetCertificateRequestNamespace("test-ns"),
+				gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Name: "my-ca", Kind: "ClusterIssuer", Group: "cert-manager.io"}),
+			),
+			expResponse: deniedResponse(field.ErrorList{
+				field.Invalid(field.NewPath("spec.constraints.withinIssuerCAValidity"), "2021-04-01T01:00:00Z", "certificate would be valid beyond the issuer CA expiry 2021-01-02T01:00:00Z"),
+			}),
+		},
+		"if the certificate would expire before the ClusterIssuer CA, return NotDenied": {
+			existingObjects: []client.Object{
+				gen.ClusterIssuer("my-ca", gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca"})),
+				caSecret("cert-manager", "ca", fixedTime.Add(time.Hour*24)),
+			},
+			request: gen.CertificateRequest("test", gen.SetCertificateRequestNamespace("test-ns"),
+				gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Name: "my-ca", Kind: "ClusterIssuer"}),
+				gen.SetCertificateRequestDuration(&metav1.Duration{Duration: time.Hour}),
+			),
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+	}
+
+	t.Run("if the constraint is not enabled, return Denied", func(t *testing.T) {
+		c := &constraints{clock: fakeclock.NewFakeClock(fixedTime)}
+		response, err := c.Evaluate(t.Context(), policy, gen.CertificateRequest("test", gen.SetCertificateRequestNamespace("test-ns"),
+			gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Name: "my-ca"}),
+		))
+		assert.NoError(t, err)
+		assert.Equal(t, deniedResponse(field.ErrorList{
+			field.Invalid(field.NewPath("spec.constraints.withinIssuerCAValidity"), "Issuer.cert-manager.io/my-ca", "withinIssuerCAValidity is not enabled, approver-policy must be run with --enable-issuer-ca-validity"),
+		}), response)
+	})
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &constraints{
+				clock: fakeclock.NewFakeClock(fixedTime),
+				reader: fakeclient.NewClientBuilder().
+					WithScheme(policyapi.GlobalScheme).
+					WithObjects(test.existingObjects...).
+					Build(),
+				clusterResourceNamespace: "cert-manager",
+			}
+
+			response, err := c.Evaluate(t.Context(), policy, test.request)
+			assert.Equal(t, test.expErr, err != nil, "%v", err)
+			assert.Equal(t, test.expResponse, response, "unexpected evaluation response")
+		})
+	}
+}
+
+func caCertificate(t *testing.T, notAfter time.Time) []byte {
+	sk := ecdsaKey(t, elliptic.P256())
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotBefore:             notAfter.Add(-time.Hour * 24 * 365),
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, sk.Public(), sk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
 func csrFrom(t *testing.T, keyAlgorithm x509.PublicKeyAlgorithm) []byte {
 	csr, _, err := gen.CSR(keyAlgorithm)
 	if err != nil {
@@ -183,3 +682,92 @@ func csrFrom(t *testing.T, keyAlgorithm x509.PublicKeyAlgorithm) []byte {
 	}
 	return csr
 }
+
+func csrWithSigner(t *testing.T, sk crypto.Signer, mods ...gen.CSRModifier) []byte {
+	csr, err := gen.CSRWithSigner(sk, mods...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return csr
+}
+
+func ecdsaKey(t *testing.T, curve elliptic.Curve) crypto.Signer {
+	sk, err := ecdsa.GenerateKey(curve, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sk
+}
+
+func rsaKey(t *testing.T, bits int) crypto.Signer {
+	sk, err := rsa.GenerateKey(rand.Reader, bits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sk
+}
+
+func csrWithExtensions(t *testing.T, ext pkix.Extension, mods ...gen.CSRModifier) []byte {
+	csr, _, err := gen.CSR(x509.ECDSA, append(mods, withExtension(ext))...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return csr
+}
+
+func withExtension(ext pkix.Extension) gen.CSRModifier {
+	return func(cr *x509.CertificateRequest) error {
+		cr.ExtraExtensions = append(cr.ExtraExtensions, ext)
+		return nil
+	}
+}
+
+func nameConstraintsExtension(t *testing.T, nameConstraints *utilpki.NameConstraints, critical bool) pkix.Extension {
+	ext, err := utilpki.MarshalNameConstraints(nameConstraints, critical)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ext
+}
+
+func mustParseCIDR(t *testing.T, cidr string) *net.IPNet {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ipNet
+}
+
+func customExtension(critical bool) pkix.Extension {
+	return pkix.Extension{Id: asn1.ObjectIdentifier{1, 2, 3, 4}, Critical: critical, Value: asn1.NullBytes}
+}
+
+func extendedKeyUsageExtension(t *testing.T, oids ...asn1.ObjectIdentifier) pkix.Extension {
+	value, err := asn1.Marshal(oids)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pkix.Extension{Id: asn1.ObjectIdentifier{2, 5, 29, 37}, Value: value}
+}
+
+func keyUsageExtension(t *testing.T, usage x509.KeyUsage) pkix.Extension {
+	ext, err := utilpki.MarshalKeyUsage(usage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ext
+}
+
+func basicConstraintsExtension(t *testing.T, isCA bool, maxPathLen *int) pkix.Extension {
+	ext, err := utilpki.MarshalBasicConstraints(isCA, maxPathLen)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ext
+}
+
+// deniedResponse returns the response of an evaluator denying a request with
+// the given field errors.
+func deniedResponse(el field.ErrorList) approver.EvaluationResponse {
+	return approver.EvaluationResponse{Result: approver.ResultDenied, Message: el.ToAggregate().Error(), Errors: el}
+}
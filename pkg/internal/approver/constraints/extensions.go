@@ -0,0 +1,218 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package constraints
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+	"slices"
+	"strings"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+)
+
+const (
+	oidExtensionSubjectAltName   = "2.5.29.17"
+	oidExtensionKeyUsage         = "2.5.29.15"
+	oidExtensionExtendedKeyUsage = "2.5.29.37"
+	oidExtensionBasicConstraints = "2.5.29.19"
+	oidExtensionNameConstraints  = "2.5.29.30"
+)
+
+var (
+	// alwaysAllowedExtensions are the extensions which are not subject to the
+	// allowed extensions list, since their contents are evaluated against
+	// `spec.allowed`: subject alternative names by the allowed evaluator, key
+	// usages and extended key usages against `spec.allowed.usages`, and basic
+	// constraints against the request `spec.isCA`.
+	alwaysAllowedExtensions = sets.New(
+		oidExtensionSubjectAltName,
+		oidExtensionKeyUsage,
+		oidExtensionExtendedKeyUsage,
+		oidExtensionBasicConstraints,
+	)
+
+	// keyUsageExtendedKeyUsages are the extended key usage OIDs which have a
+	// corresponding cert-manager key usage.
+	keyUsageExtendedKeyUsages = sets.New(
+		"2.5.29.37.0",            // any
+		"1.3.6.1.5.5.7.3.1",      // server auth
+		"1.3.6.1.5.5.7.3.2",      // client auth
+		"1.3.6.1.5.5.7.3.3",      // code signing
+		"1.3.6.1.5.5.7.3.4",      // email protection, s/mime
+		"1.3.6.1.5.5.7.3.5",      // ipsec end system
+		"1.3.6.1.5.5.7.3.6",      // ipsec tunnel
+		"1.3.6.1.5.5.7.3.7",      // ipsec user
+		"1.3.6.1.5.5.7.3.8",      // timestamping
+		"1.3.6.1.5.5.7.3.9",      // ocsp signing
+		"1.3.6.1.4.1.311.10.3.3", // microsoft sgc
+		"2.16.840.1.113730.4.1",  // netscape sgc
+	)
+)
+
+// evaluateExtensions evaluates whether the extensions requested in the CSR
+// satisfy the extension constraints. The key usages and extended key usages
+// requested in the CSR must be allowed by the given allowed usages.
+func evaluateExtensions(fldPath *field.Path, consts *policyapi.CertificateRequestPolicyConstraintsExtensions, allowedUsages *[]cmapi.KeyUsage, request *cmapi.CertificateRequest, csr *x509.CertificateRequest) field.ErrorList {
+	var el field.ErrorList
+
+	permittedKeyUsages, permittedExtKeyUsages := permittedUsages(allowedUsages, request)
+
+	for _, ext := range csr.Extensions {
+		oid := ext.Id.String()
+		matches := func(match policyapi.CertificateRequestPolicyConstraintsExtension) bool {
+			return match.OID == oid && (match.Critical == nil || *match.Critical == ext.Critical)
+		}
+
+		if slices.ContainsFunc(consts.Denied, matches) {
+			el = append(el, field.Invalid(fldPath.Child("denied"), describeExtension(ext), "extension is denied"))
+			continue
+		}
+
+		if len(consts.Allowed) > 0 && !alwaysAllowedExtensions.Has(oid) && !slices.ContainsFunc(consts.Allowed, matches) {
+			el = append(el, field.Invalid(fldPath.Child("allowed"), describeExtension(ext), "extension is not allowed"))
+			continue
+		}
+
+		switch oid {
+		case oidExtensionKeyUsage:
+			usage, err := utilpki.UnmarshalKeyUsage(ext.Value)
+			if err != nil {
+				el = append(el, field.Invalid(fldPath, describeExtension(ext), "failed to decode key usage extension"))
+				continue
+			}
+			for _, usage := range apiutil.KeyUsageStrings(usage &^ permittedKeyUsages) {
+				el = append(el, field.Invalid(fldPath, fmt.Sprintf("key usage %s", usage), "key usage must be allowed by spec.allowed.usages"))
+			}
+
+		case oidExtensionExtendedKeyUsage:
+			var oids []asn1.ObjectIdentifier
+			if rest, err := asn1.Unmarshal(ext.Value, &oids); err != nil || len(rest) > 0 {
+				el = append(el, field.Invalid(fldPath.Child("extendedKeyUsages"), describeExtension(ext), "failed to decode extended key usage extension"))
+				continue
+			}
+			for _, eku := range oids {
+				switch {
+				case keyUsageExtendedKeyUsages.Has(eku.String()):
+					if !permittedExtKeyUsages.Has(eku.String()) {
+						el = append(el, field.Invalid(fldPath, fmt.Sprintf("extended key usage %s", eku), "extended key usage must be allowed by spec.allowed.usages"))
+					}
+				case !slices.Contains(consts.ExtendedKeyUsages, eku.String()):
+					el = append(el, field.Invalid(fldPath.Child("extendedKeyUsages"), eku.String(), strings.Join(consts.ExtendedKeyUsages, ", ")))
+				}
+			}
+
+		case oidExtensionBasicConstraints:
+			isCA, _, err := utilpki.UnmarshalBasicConstraints(ext.Value)
+			if err != nil {
+				el = append(el, field.Invalid(fldPath, describeExtension(ext), "failed to decode basic constraints extension"))
+				continue
+			}
+			if isCA != request.Spec.IsCA {
+				el = append(el, field.Invalid(fldPath, fmt.Sprintf("basic constraints isCA=%t", isCA), fmt.Sprintf("must match the request spec.isCA=%t", request.Spec.IsCA)))
+			}
+		}
+	}
+
+	return el
+}
+
+// permittedUsages returns the key usages and the extended key usage OIDs that
+// a CSR may request. These are the usages allowed by the policy, as well as
+// the usages cert-manager encodes in the CSR regardless of the requested
+// usages: the default usages of requests which don't request any usages, and
+// certificate signing for CAs.
+func permittedUsages(allowedUsages *[]cmapi.KeyUsage, request *cmapi.CertificateRequest) (x509.KeyUsage, sets.Set[string]) {
+	var usages []cmapi.KeyUsage
+	if allowedUsages != nil {
+		usages = append(usages, *allowedUsages...)
+	}
+	if len(request.Spec.Usages) == 0 {
+		usages = append(usages, cmapi.DefaultKeyUsages()...)
+	}
+
+	var keyUsages x509.KeyUsage
+	if request.Spec.IsCA {
+		keyUsages |= x509.KeyUsageCertSign
+	}
+
+	extKeyUsages := sets.New[string]()
+	for _, usage := range usages {
+		if keyUsage, ok := apiutil.KeyUsageType(usage); ok {
+			keyUsages |= keyUsage
+		} else if extKeyUsage, ok := apiutil.ExtKeyUsageType(usage); ok {
+			if oid, ok := utilpki.OIDFromExtKeyUsage(extKeyUsage); ok {
+				extKeyUsages.Insert(oid.String())
+			}
+		}
+	}
+
+	return keyUsages, extKeyUsages
+}
+
+// describeExtension returns a human readable description of an extension,
+// e.g. "2.5.29.30 (critical)".
+func describeExtension(ext pkix.Extension) string {
+	if ext.Critical {
+		return fmt.Sprintf("%s (critical)", ext.Id)
+	}
+	return ext.Id.String()
+}
+
+// validateExtensions validates that the extension constraints contain valid
+// OIDs.
+func validateExtensions(fldPath *field.Path, consts *policyapi.CertificateRequestPolicyConstraintsExtensions) field.ErrorList {
+	var el field.ErrorList
+
+	for i, ext := range consts.Allowed {
+		if err := validateOID(ext.OID); err != nil {
+			el = append(el, field.Invalid(fldPath.Child("allowed").Index(i).Child("oid"), ext.OID, err.Error()))
+		}
+	}
+
+	for i, ext := range consts.Denied {
+		if err := validateOID(ext.OID); err != nil {
+			el = append(el, field.Invalid(fldPath.Child("denied").Index(i).Child("oid"), ext.OID, err.Error()))
+		}
+	}
+
+	for i, eku := range consts.ExtendedKeyUsages {
+		if err := validateOID(eku); err != nil {
+			el = append(el, field.Invalid(fldPath.Child("extendedKeyUsages").Index(i), eku, err.Error()))
+		} else if keyUsageExtendedKeyUsages.Has(eku) {
+			el = append(el, field.Invalid(fldPath.Child("extendedKeyUsages").Index(i), eku, "extended key usage has a corresponding key usage which must be allowed with spec.allowed.usages"))
+		}
+	}
+
+	return el
+}
+
+// validateOID validates that the string is an OID in dotted decimal form.
+func validateOID(s string) error {
+	if _, err := x509.ParseOID(s); err != nil {
+		return fmt.Errorf("must be an object identifier in dotted decimal form: %w", err)
+	}
+	return nil
+}
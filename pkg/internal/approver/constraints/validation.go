@@ -18,10 +18,13 @@ package constraints
 
 import (
 	"context"
+	"crypto/x509"
 	"fmt"
+	"slices"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
 	"github.com/cert-manager/approver-policy/pkg/approver"
@@ -29,7 +32,7 @@ import (
 
 // Validate validates that the processed CertificateRequestPolicy has valid
 // constraint fields defined and there are no parsing errors in the values.
-func (c constraints) Validate(_ context.Context, policy *policyapi.CertificateRequestPolicy) (approver.WebhookValidationResponse, error) {
+func (c *constraints) Validate(_ context.Context, policy *policyapi.CertificateRequestPolicy) (approver.WebhookValidationResponse, error) {
 	// If no constraints are defined we can exit early
 	if policy.Spec.Constraints == nil {
 		return approver.WebhookValidationResponse{
@@ -78,6 +81,29 @@ func (c constraints) Validate(_ context.Context, policy *policyapi.CertificateRe
 		if maxSize != nil && minSize != nil && *maxSize < *minSize {
 			el = append(el, field.Invalid(fldPath.Child("maxSize"), *maxSize, "maxSize must be the same value as minSize or larger"))
 		}
+
+		if len(consts.PrivateKey.Curves) > 0 && consts.PrivateKey.Algorithm != nil && *consts.PrivateKey.Algorithm != cmapi.ECDSAKeyAlgorithm {
+			el = append(el, field.Invalid(fldPath.Child("curves"), consts.PrivateKey.Curves, fmt.Sprintf("curves cannot be defined with algorithm constraint %s", *consts.PrivateKey.Algorithm)))
+		}
+		el = append(el, validateCurves(fldPath.Child("curves"), consts.PrivateKey.Curves)...)
+
+		for i, signatureAlgorithm := range consts.PrivateKey.SignatureAlgorithms {
+			if !slices.Contains(supportedSignatureAlgorithms, signatureAlgorithm) {
+				el = append(el, field.NotSupported(fldPath.Child("signatureAlgorithms").Index(i), signatureAlgorithm, supportedSignatureAlgorithms))
+			}
+		}
+
+		for i, option := range consts.PrivateKey.AnyOf {
+			el = append(el, validatePrivateKeyOption(fldPath.Child("anyOf").Index(i), option)...)
+		}
+	}
+
+	if consts.Extensions != nil {
+		el = append(el, validateExtensions(fldPath.Child("extensions"), consts.Extensions)...)
+	}
+
+	if consts.CA != nil {
+		el = append(el, validateCA(fldPath.Child("ca"), consts.CA, policy.Spec.Allowed)...)
 	}
 
 	if consts.MaxDuration != nil && consts.MinDuration != nil && consts.MaxDuration.Duration < consts.MinDuration.Duration {
@@ -90,8 +116,154 @@ func (c constraints) Validate(_ context.Context, policy *policyapi.CertificateRe
 		el = append(el, field.Invalid(fldPath.Child("minDuration"), consts.MinDuration.Duration.String(), "minDuration must be a value greater or equal to 0"))
 	}
 
+	if consts.RenewBefore != nil {
+		el = append(el, validateRenewBefore(fldPath.Child("renewBefore"), consts.RenewBefore)...)
+	}
+
+	// Only warn about anyOf options which can never be satisfied, and name
+	// types which are not constrained, once the policy is otherwise valid.
+	var warnings admission.Warnings
+	if len(el) == 0 && consts.PrivateKey != nil {
+		warnings = unsatisfiablePrivateKeyOptions(fldPath.Child("privateKey", "anyOf"), consts.PrivateKey)
+	}
+	if len(el) == 0 && consts.CA != nil && consts.CA.NameConstraints != nil {
+		warnings = append(warnings, unconstrainedNameTypes(fldPath.Child("ca", "nameConstraints"), consts.CA.NameConstraints)...)
+	}
+
 	return approver.WebhookValidationResponse{
-		Allowed: len(el) == 0,
-		Errors:  el,
+		Allowed:  len(el) == 0,
+		Errors:   el,
+		Warnings: warnings,
 	}, nil
 }
+
+// supportedCurves are the elliptic curves which may be constrained for ECDSA
+// private keys.
+var supportedCurves = []string{"P-256", "P-384", "P-521"}
+
+// supportedSignatureAlgorithms are the names of the signature algorithms
+// which may be constrained, as named by crypto/x509.
+var supportedSignatureAlgorithms = func() []string {
+	var algorithms []string
+	for alg := x509.MD5WithRSA; alg <= x509.PureEd25519; alg++ {
+		algorithms = append(algorithms, alg.String())
+	}
+	return algorithms
+}()
+
+// signatureKeyAlgorithms are the private key algorithms of the signature
+// algorithms which may be constrained, by name.
+var signatureKeyAlgorithms = func() map[string]cmapi.PrivateKeyAlgorithm {
+	algorithms := make(map[string]cmapi.PrivateKeyAlgorithm)
+	for _, alg := range []x509.SignatureAlgorithm{
+		x509.MD2WithRSA, x509.MD5WithRSA, x509.SHA1WithRSA, x509.SHA256WithRSA, x509.SHA384WithRSA, x509.SHA512WithRSA,
+		x509.SHA256WithRSAPSS, x509.SHA384WithRSAPSS, x509.SHA512WithRSAPSS,
+	} {
+		algorithms[alg.String()] = cmapi.RSAKeyAlgorithm
+	}
+	for _, alg := range []x509.SignatureAlgorithm{x509.ECDSAWithSHA1, x509.ECDSAWithSHA256, x509.ECDSAWithSHA384, x509.ECDSAWithSHA512} {
+		algorithms[alg.String()] = cmapi.ECDSAKeyAlgorithm
+	}
+	algorithms[x509.PureEd25519.String()] = cmapi.Ed25519KeyAlgorithm
+	return algorithms
+}()
+
+// unsatisfiablePrivateKeyOptions returns a warning for every anyOf option
+// which no key can satisfy together with the other private key constraints.
+// Such options are valid, but never match.
+func unsatisfiablePrivateKeyOptions(fldPath *field.Path, privateKey *policyapi.CertificateRequestPolicyConstraintsPrivateKey) admission.Warnings {
+	var warnings admission.Warnings
+	for i, option := range privateKey.AnyOf {
+		if reason := unsatisfiablePrivateKeyOption(privateKey, option); len(reason) > 0 {
+			warnings = append(warnings, fmt.Sprintf("%s can never be satisfied: %s", fldPath.Index(i), reason))
+		}
+	}
+	return warnings
+}
+
+// unsatisfiablePrivateKeyOption returns the reason no key can satisfy the
+// option together with the other private key constraints, or an empty string
+// if a key may satisfy both.
+func unsatisfiablePrivateKeyOption(privateKey *policyapi.CertificateRequestPolicyConstraintsPrivateKey, option policyapi.CertificateRequestPolicyConstraintsPrivateKeyOption) string {
+	if privateKey.Algorithm != nil && *privateKey.Algorithm != option.Algorithm {
+		return fmt.Sprintf("algorithm is constrained to %s", *privateKey.Algorithm)
+	}
+
+	if len(privateKey.SignatureAlgorithms) > 0 && !slices.ContainsFunc(privateKey.SignatureAlgorithms, func(name string) bool {
+		return signatureKeyAlgorithms[name] == option.Algorithm
+	}) {
+		return fmt.Sprintf("none of the signatureAlgorithms are for %s keys", option.Algorithm)
+	}
+
+	if option.Algorithm == cmapi.Ed25519KeyAlgorithm && privateKey.MinSize != nil {
+		return fmt.Sprintf("%s keys have no size, so never satisfy minSize", cmapi.Ed25519KeyAlgorithm)
+	}
+
+	minSize, maxSize := privateKey.MinSize, privateKey.MaxSize
+	if option.MinSize != nil && (minSize == nil || *option.MinSize > *minSize) {
+		minSize = option.MinSize
+	}
+	if option.MaxSize != nil && (maxSize == nil || *option.MaxSize < *maxSize) {
+		maxSize = option.MaxSize
+	}
+	if minSize != nil && maxSize != nil && *minSize > *maxSize {
+		return fmt.Sprintf("no key size is both at least %d and at most %d", *minSize, *maxSize)
+	}
+
+	if option.Algorithm == cmapi.ECDSAKeyAlgorithm && len(privateKey.Curves) > 0 && len(option.Curves) > 0 &&
+		!slices.ContainsFunc(option.Curves, func(curve string) bool { return slices.Contains(privateKey.Curves, curve) }) {
+		return "none of its curves are allowed by curves"
+	}
+
+	return ""
+}
+
+// validateCurves validates that the given curves are supported.
+func validateCurves(fldPath *field.Path, curves []string) field.ErrorList {
+	var el field.ErrorList
+	for i, curve := range curves {
+		if !slices.Contains(supportedCurves, curve) {
+			el = append(el, field.NotSupported(fldPath.Index(i), curve, supportedCurves))
+		}
+	}
+	return el
+}
+
+// validatePrivateKeyOption validates that the private key option has a
+// supported algorithm, and parameters which are valid for it.
+func validatePrivateKeyOption(fldPath *field.Path, option policyapi.CertificateRequestPolicyConstraintsPrivateKeyOption) field.ErrorList {
+	var el field.ErrorList
+
+	switch option.Algorithm {
+	case cmapi.RSAKeyAlgorithm, cmapi.ECDSAKeyAlgorithm, cmapi.Ed25519KeyAlgorithm:
+		break
+	default:
+		el = append(el, field.NotSupported(fldPath.Child("algorithm"), option.Algorithm, []string{string(cmapi.RSAKeyAlgorithm), string(cmapi.ECDSAKeyAlgorithm), string(cmapi.Ed25519KeyAlgorithm)}))
+	}
+
+	if option.Algorithm == cmapi.Ed25519KeyAlgorithm {
+		if option.MaxSize != nil {
+			el = append(el, field.Invalid(fldPath.Child("maxSize"), *option.MaxSize, fmt.Sprintf("maxSize cannot be defined with algorithm %s", cmapi.Ed25519KeyAlgorithm)))
+		}
+		if option.MinSize != nil {
+			el = append(el, field.Invalid(fldPath.Child("minSize"), *option.MinSize, fmt.Sprintf("minSize cannot be defined with algorithm %s", cmapi.Ed25519KeyAlgorithm)))
+		}
+	}
+
+	if option.MaxSize != nil && (*option.MaxSize < 0 || *option.MaxSize > 8192) {
+		el = append(el, field.Invalid(fldPath.Child("maxSize"), *option.MaxSize, "must be between 0 and 8192 inclusive"))
+	}
+	if option.MinSize != nil && (*option.MinSize < 0 || *option.MinSize > 8192) {
+		el = append(el, field.Invalid(fldPath.Child("minSize"), *option.MinSize, "must be between 0 and 8192 inclusive"))
+	}
+	if option.MaxSize != nil && option.MinSize != nil && *option.MaxSize < *option.MinSize {
+		el = append(el, field.Invalid(fldPath.Child("maxSize"), *option.MaxSize, "maxSize must be the same value as minSize or larger"))
+	}
+
+	if len(option.Curves) > 0 && option.Algorithm != cmapi.ECDSAKeyAlgorithm {
+		el = append(el, field.Invalid(fldPath.Child("curves"), option.Curves, fmt.Sprintf("curves cannot be defined with algorithm %s", option.Algorithm)))
+	}
+	el = append(el, validateCurves(fldPath.Child("curves"), option.Curves)...)
+
+	return el
+}
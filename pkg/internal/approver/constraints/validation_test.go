@@ -25,6 +25,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
 	"github.com/cert-manager/approver-policy/pkg/approver"
@@ -97,6 +98,229 @@ func Test_Validate(t *testing.T) {
 				},
 			},
 		},
+		"if policy contains invalid curves, signature algorithms and anyOf options, expect a Allowed=false response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Constraints: &policyapi.CertificateRequestPolicyConstraints{
+						PrivateKey: &policyapi.CertificateRequestPolicyConstraintsPrivateKey{
+							Algorithm:           &rsaAlg,
+							Curves:              []string{"P-224"},
+							SignatureAlgorithms: []string{"SHA256-RSA", "SHA3-RSA"},
+							AnyOf: []policyapi.CertificateRequestPolicyConstraintsPrivateKeyOption{
+								{Algorithm: badAlg},
+								{Algorithm: edAlg, MinSize: ptr.To(256)},
+								{Algorithm: rsaAlg, MinSize: ptr.To(4096), MaxSize: ptr.To(2048), Curves: []string{"P-256"}},
+							},
+						},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.privateKey.curves"), []string{"P-224"}, "curves cannot be defined with algorithm constraint RSA"),
+					field.NotSupported(field.NewPath("spec.constraints.privateKey.curves").Index(0), "P-224", []string{"P-256", "P-384", "P-521"}),
+					field.NotSupported(field.NewPath("spec.constraints.privateKey.signatureAlgorithms").Index(1), "SHA3-RSA", supportedSignatureAlgorithms),
+					field.NotSupported(field.NewPath("spec.constraints.privateKey.anyOf").Index(0).Child("algorithm"), badAlg, []string{"RSA", "ECDSA", "Ed25519"}),
+					field.Invalid(field.NewPath("spec.constraints.privateKey.anyOf").Index(1).Child("minSize"), 256, "minSize cannot be defined with algorithm Ed25519"),
+					field.Invalid(field.NewPath("spec.constraints.privateKey.anyOf").Index(2).Child("maxSize"), 2048, "maxSize must be the same value as minSize or larger"),
+					field.Invalid(field.NewPath("spec.constraints.privateKey.anyOf").Index(2).Child("curves"), []string{"P-256"}, "curves cannot be defined with algorithm RSA"),
+				},
+			},
+		},
+		"if policy contains valid curves, signature algorithms and anyOf options, expect a Allowed=true response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Constraints: &policyapi.CertificateRequestPolicyConstraints{
+						PrivateKey: &policyapi.CertificateRequestPolicyConstraintsPrivateKey{
+							Curves:              []string{"P-256", "P-384"},
+							SignatureAlgorithms: []string{"SHA256-RSAPSS", "ECDSA-SHA384", "Ed25519"},
+							AnyOf: []policyapi.CertificateRequestPolicyConstraintsPrivateKeyOption{
+								{Algorithm: rsaAlg, MinSize: ptr.To(3072)},
+								{Algorithm: cmapi.ECDSAKeyAlgorithm, Curves: []string{"P-256", "P-384"}},
+								{Algorithm: edAlg},
+							},
+						},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: true,
+				Errors:  nil,
+			},
+		},
+		"if policy contains anyOf options which can never be satisfied, expect a Allowed=true response with warnings": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Constraints: &policyapi.CertificateRequestPolicyConstraints{
+						PrivateKey: &policyapi.CertificateRequestPolicyConstraintsPrivateKey{
+							MinSize:             ptr.To(2048),
+							MaxSize:             ptr.To(4096),
+							Curves:              []string{"P-256"},
+							SignatureAlgorithms: []string{"SHA256-RSA", "Ed25519"},
+							AnyOf: []policyapi.CertificateRequestPolicyConstraintsPrivateKeyOption{
+								{Algorithm: rsaAlg, MinSize: ptr.To(3072)},
+								{Algorithm: rsaAlg, MinSize: ptr.To(8192)},
+								{Algorithm: cmapi.ECDSAKeyAlgorithm, Curves: []string{"P-384"}},
+								{Algorithm: edAlg},
+							},
+						},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: true,
+				Errors:  nil,
+				Warnings: admission.Warnings{
+					"spec.constraints.privateKey.anyOf[1] can never be satisfied: no key size is both at least 8192 and at most 4096",
+					"spec.constraints.privateKey.anyOf[2] can never be satisfied: none of the signatureAlgorithms are for ECDSA keys",
+					"spec.constraints.privateKey.anyOf[3] can never be satisfied: Ed25519 keys have no size, so never satisfy minSize",
+				},
+			},
+		},
+		"if policy contains anyOf options whose curves are not allowed, expect a Allowed=true response with warnings": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Constraints: &policyapi.CertificateRequestPolicyConstraints{
+						PrivateKey: &policyapi.CertificateRequestPolicyConstraintsPrivateKey{
+							Algorithm: ptr.To(cmapi.ECDSAKeyAlgorithm),
+							Curves:    []string{"P-256"},
+							AnyOf: []policyapi.CertificateRequestPolicyConstraintsPrivateKeyOption{
+								{Algorithm: cmapi.ECDSAKeyAlgorithm, Curves: []string{"P-384", "P-521"}},
+								{Algorithm: rsaAlg},
+							},
+						},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: true,
+				Errors:  nil,
+				Warnings: admission.Warnings{
+					"spec.constraints.privateKey.anyOf[0] can never be satisfied: none of its curves are allowed by curves",
+					"spec.constraints.privateKey.anyOf[1] can never be satisfied: algorithm is constrained to ECDSA",
+				},
+			},
+		},
+		"if policy contains invalid extension OIDs, expect a Allowed=false response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Constraints: &policyapi.CertificateRequestPolicyConstraints{
+						Extensions: &policyapi.CertificateRequestPolicyConstraintsExtensions{
+							Allowed:           []policyapi.CertificateRequestPolicyConstraintsExtension{{OID: "1.2.3.4"}, {OID: "name-constraints"}},
+							Denied:            []policyapi.CertificateRequestPolicyConstraintsExtension{{OID: "2.5.29.30"}, {OID: "2.5..29"}},
+							ExtendedKeyUsages: []string{"1.3.6.1.4.1.311.20.2.2", "1.3.6.1.5.5.7.3.1"},
+						},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.extensions.allowed").Index(1).Child("oid"), "name-constraints", validateOID("name-constraints").Error()),
+					field.Invalid(field.NewPath("spec.constraints.extensions.denied").Index(1).Child("oid"), "2.5..29", validateOID("2.5..29").Error()),
+					field.Invalid(field.NewPath("spec.constraints.extensions.extendedKeyUsages").Index(1), "1.3.6.1.5.5.7.3.1", "extended key usage has a corresponding key usage which must be allowed with spec.allowed.usages"),
+				},
+			},
+		},
+		"if policy contains ca constraints without allowing isCA, expect a Allowed=false response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Allowed: &policyapi.CertificateRequestPolicyAllowed{IsCA: ptr.To(false)},
+					Constraints: &policyapi.CertificateRequestPolicyConstraints{
+						CA: &policyapi.CertificateRequestPolicyConstraintsCA{
+							NameConstraints: &policyapi.CertificateRequestPolicyConstraintsCANameConstraints{Critical: true},
+						},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.Forbidden(field.NewPath("spec.constraints.ca"), "ca constraints may only be defined if spec.allowed.isCA is true"),
+					field.Required(field.NewPath("spec.constraints.ca.nameConstraints"), "at least one permitted subtree type must be defined"),
+				},
+			},
+		},
+		"if policy contains ca name constraints which don't define every name type, expect a Allowed=true response with warnings": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Allowed: &policyapi.CertificateRequestPolicyAllowed{IsCA: ptr.To(true)},
+					Constraints: &policyapi.CertificateRequestPolicyConstraints{
+						CA: &policyapi.CertificateRequestPolicyConstraintsCA{
+							NameConstraints: &policyapi.CertificateRequestPolicyConstraintsCANameConstraints{
+								PermittedDNSDomains: []string{"*.example.com"},
+								PermittedIPRanges:   []string{"10.0.0.0/8"},
+							},
+						},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: true,
+				Errors:  nil,
+				Warnings: admission.Warnings{
+					"spec.constraints.ca.nameConstraints does not define permittedEmailAddresses, permittedURIDomains: CAs may issue certificates for any names of these types",
+				},
+			},
+		},
+		"if policy contains ca constraints with an invalid IP range, expect a Allowed=false response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Allowed: &policyapi.CertificateRequestPolicyAllowed{IsCA: ptr.To(true)},
+					Constraints: &policyapi.CertificateRequestPolicyConstraints{
+						CA: &policyapi.CertificateRequestPolicyConstraintsCA{
+							NameConstraints: &policyapi.CertificateRequestPolicyConstraintsCANameConstraints{
+								PermittedDNSDomains: []string{"*.example.com"},
+								PermittedIPRanges:   []string{"10.0.0.0/8", "10.0.0.0"},
+							},
+						},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.ca.nameConstraints.permittedIPRanges").Index(1), "10.0.0.0", "invalid CIDR address: 10.0.0.0"),
+				},
+			},
+		},
+		"if policy contains invalid renewBefore percentages, expect a Allowed=false response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Constraints: &policyapi.CertificateRequestPolicyConstraints{
+						RenewBefore: &policyapi.CertificateRequestPolicyConstraintsRenewBefore{
+							MinPercentage: ptr.To[int32](50),
+							MaxPercentage: ptr.To[int32](100),
+						},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.renewBefore.maxPercentage"), int32(100), "must be between 1 and 99 inclusive"),
+				},
+			},
+		},
+		"if policy contains renewBefore with maxPercentage smaller than minPercentage, expect a Allowed=false response": {
+			policy: &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Constraints: &policyapi.CertificateRequestPolicyConstraints{
+						RenewBefore: &policyapi.CertificateRequestPolicyConstraintsRenewBefore{
+							MinPercentage: ptr.To[int32](50),
+							MaxPercentage: ptr.To[int32](20),
+						},
+					},
+				},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.Invalid(field.NewPath("spec.constraints.renewBefore.maxPercentage"), int32(20), "maxPercentage must be the same value as minPercentage or larger"),
+				},
+			},
+		},
 		"if policy contains no validation errors, expect a Allowed=true response": {
 			policy: &policyapi.CertificateRequestPolicy{
 				Spec: policyapi.CertificateRequestPolicySpec{
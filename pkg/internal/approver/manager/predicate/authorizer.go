@@ -0,0 +1,314 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package predicate
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	authzv1 "k8s.io/api/authorization/v1"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/cert-manager/approver-policy/pkg/internal/metrics"
+	"github.com/cert-manager/approver-policy/pkg/internal/rbac"
+	"github.com/cert-manager/approver-policy/pkg/internal/tracing"
+)
+
+// Authorizer decides whether the user in a CertificateRequest may use a
+// CertificateRequestPolicy.
+type Authorizer interface {
+	// Authorize returns true if the user in the CertificateRequest is
+	// authorized to use the named CertificateRequestPolicy in the namespace of
+	// the request.
+	Authorize(ctx context.Context, cr *cmapi.CertificateRequest, policy string) (bool, error)
+}
+
+// Invalidator is an Authorizer which holds state derived from the RBAC of the
+// cluster, and must be told when it changes.
+type Invalidator interface {
+	Authorizer
+
+	// Invalidate discards state derived from the RBAC of the namespace. An
+	// empty namespace discards all state, such as when cluster scoped RBAC
+	// changes.
+	Invalidate(namespace string)
+}
+
+// subjectAccessReviewAuthorizer authorizes users by creating a
+// SubjectAccessReview for every request.
+type subjectAccessReviewAuthorizer struct {
+	client client.Client
+}
+
+// NewSubjectAccessReviewAuthorizer returns an Authorizer which creates a
+// SubjectAccessReview for every request.
+func NewSubjectAccessReviewAuthorizer(client client.Client) Authorizer {
+	return &subjectAccessReviewAuthorizer{client: client}
+}
+
+func (s *subjectAccessReviewAuthorizer) Authorize(ctx context.Context, cr *cmapi.CertificateRequest, policy string) (bool, error) {
+	extra := make(map[string]authzv1.ExtraValue)
+	for k, v := range cr.Spec.Extra {
+		extra[k] = v
+	}
+
+	attrs := rbac.UsePolicy(policy)
+	rev := &authzv1.SubjectAccessReview{
+		Spec: authzv1.SubjectAccessReviewSpec{
+			User:   cr.Spec.Username,
+			Groups: cr.Spec.Groups,
+			Extra:  extra,
+			UID:    cr.Spec.UID,
+
+			ResourceAttributes: &authzv1.ResourceAttributes{
+				Group:     attrs.APIGroup,
+				Resource:  attrs.Resource,
+				Name:      attrs.Name,
+				Namespace: cr.Namespace,
+				Verb:      attrs.Verb,
+			},
+		},
+	}
+
+	start := time.Now()
+	err := createSubjectAccessReview(ctx, s.client, rev)
+	metrics.ObserveSubjectAccessReview(time.Since(start), err)
+	if err != nil {
+		return false, fmt.Errorf("failed to create subjectaccessreview: %w", err)
+	}
+
+	return rev.Status.Allowed, nil
+}
+
+// createSubjectAccessReview creates the SubjectAccessReview, recording a span
+// for the round-trip.
+func createSubjectAccessReview(ctx context.Context, client client.Client, rev *authzv1.SubjectAccessReview) error {
+	ctx, span := tracing.Tracer().Start(ctx, "SubjectAccessReview", trace.WithAttributes(
+		attribute.String("policy.name", rev.Spec.ResourceAttributes.Name),
+	))
+	defer span.End()
+
+	if err := client.Create(ctx, rev); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	span.SetAttributes(attribute.Bool("subjectaccessreview.allowed", rev.Status.Allowed))
+	return nil
+}
+
+// cachedAuthorizerMaxEntries is the number of results above which a
+// CachedAuthorizer discards expired results, or all results if none have
+// expired.
+const cachedAuthorizerMaxEntries = 10000
+
+// CachedAuthorizer caches the results of another Authorizer, keyed by user,
+// groups, extra, namespace and policy. Results are discarded when the RBAC of
+// their namespace changes, or once they are older than the TTL so that
+// changes to authorization other than RBAC, such as authorization webhooks,
+// are eventually observed.
+type CachedAuthorizer struct {
+	authorizer Authorizer
+	ttl        time.Duration
+	clock      clock.PassiveClock
+
+	lock    sync.Mutex
+	entries map[cacheKey]cacheEntry
+
+	// generation is incremented on every invalidation, so that results of
+	// requests which were in flight during an invalidation are not cached.
+	generation uint64
+}
+
+type cacheKey struct {
+	user, uid, groups, extra, namespace, policy string
+}
+
+type cacheEntry struct {
+	allowed bool
+	expires time.Time
+}
+
+var _ Invalidator = &CachedAuthorizer{}
+
+// NewCachedAuthorizer returns an Authorizer which caches the results of
+// authorizer for the TTL.
+func NewCachedAuthorizer(authorizer Authorizer, ttl time.Duration, clock clock.PassiveClock) *CachedAuthorizer {
+	return &CachedAuthorizer{
+		authorizer: authorizer,
+		ttl:        ttl,
+		clock:      clock,
+		entries:    make(map[cacheKey]cacheEntry),
+	}
+}
+
+// Authorize returns the cached result for the user, policy and namespace of
+// the request, or authorizes the request and caches the result.
+func (c *CachedAuthorizer) Authorize(ctx context.Context, cr *cmapi.CertificateRequest, policy string) (bool, error) {
+	key := keyFor(cr, policy)
+
+	c.lock.Lock()
+	entry, ok := c.entries[key]
+	generation := c.generation
+	c.lock.Unlock()
+
+	if ok && c.clock.Now().Before(entry.expires) {
+		metrics.ObserveSubjectAccessReviewCache(true)
+		return entry.allowed, nil
+	}
+	metrics.ObserveSubjectAccessReviewCache(false)
+
+	allowed, err := c.authorizer.Authorize(ctx, cr, policy)
+	if err != nil {
+		return false, err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.generation == generation {
+		if len(c.entries) >= cachedAuthorizerMaxEntries {
+			c.prune()
+		}
+		c.entries[key] = cacheEntry{allowed: allowed, expires: c.clock.Now().Add(c.ttl)}
+	}
+
+	return allowed, nil
+}
+
+// Invalidate discards the cached results of the namespace, or all results if
+// the namespace is empty.
+func (c *CachedAuthorizer) Invalidate(namespace string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.generation++
+	if len(namespace) == 0 {
+		clear(c.entries)
+		return
+	}
+	for key := range c.entries {
+		if key.namespace == namespace {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// prune discards expired results, or all results if none have expired. Must
+// be called with the lock held.
+func (c *CachedAuthorizer) prune() {
+	now := c.clock.Now()
+	for key, entry := range c.entries {
+		if !now.Before(entry.expires) {
+			delete(c.entries, key)
+		}
+	}
+	if len(c.entries) >= cachedAuthorizerMaxEntries {
+		clear(c.entries)
+	}
+}
+
+// keyFor returns the cache key of the user of the request using the policy.
+func keyFor(cr *cmapi.CertificateRequest, policy string) cacheKey {
+	groups := slices.Clone(cr.Spec.Groups)
+	slices.Sort(groups)
+
+	extra := make([]string, 0, len(cr.Spec.Extra))
+	for k, v := range cr.Spec.Extra {
+		values := slices.Clone(v)
+		slices.Sort(values)
+		extra = append(extra, k+"="+strings.Join(values, "\x00"))
+	}
+	slices.Sort(extra)
+
+	return cacheKey{
+		user:      cr.Spec.Username,
+		uid:       cr.Spec.UID,
+		groups:    strings.Join(groups, "\x00"),
+		extra:     strings.Join(extra, "\x01"),
+		namespace: cr.Namespace,
+		policy:    policy,
+	}
+}
+
+// RBACAuthorizer authorizes users against the RBAC Roles, ClusterRoles,
+// RoleBindings and ClusterRoleBindings read from a cache, without making
+// requests to the API server. Authorization other than RBAC, such as
+// authorization webhooks, is not considered.
+type RBACAuthorizer struct {
+	lister client.Reader
+
+	lock     sync.Mutex
+	snapshot *rbac.Snapshot
+
+	// generation is incremented on every invalidation, so that a snapshot
+	// which was being built during an invalidation is not stored.
+	generation uint64
+}
+
+var _ Invalidator = &RBACAuthorizer{}
+
+// NewRBACAuthorizer returns an Authorizer which authorizes users against the
+// RBAC objects read from lister.
+func NewRBACAuthorizer(lister client.Reader) *RBACAuthorizer {
+	return &RBACAuthorizer{lister: lister}
+}
+
+// Authorize returns true if the user or groups of the request are bound to
+// the policy in the namespace of the request.
+func (r *RBACAuthorizer) Authorize(ctx context.Context, cr *cmapi.CertificateRequest, policy string) (bool, error) {
+	r.lock.Lock()
+	snapshot := r.snapshot
+	generation := r.generation
+	r.lock.Unlock()
+
+	if snapshot == nil {
+		var err error
+		snapshot, err = rbac.List(ctx, r.lister)
+		if err != nil {
+			return false, err
+		}
+
+		// The snapshot may still be used for this request, since the request
+		// is reconciled again after the invalidation.
+		r.lock.Lock()
+		if r.generation == generation {
+			r.snapshot = snapshot
+		}
+		r.lock.Unlock()
+	}
+
+	return snapshot.Allows(cr.Spec.Username, cr.Spec.Groups, cr.Namespace, rbac.UsePolicy(policy)), nil
+}
+
+// Invalidate discards the snapshot of the RBAC objects, which is rebuilt on
+// the next request.
+func (r *RBACAuthorizer) Invalidate(_ string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.generation++
+	r.snapshot = nil
+}
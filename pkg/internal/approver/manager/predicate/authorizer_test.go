@@ -0,0 +1,184 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package predicate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclock "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+)
+
+// countingAuthorizer allows every request, counting the requests it receives.
+type countingAuthorizer struct {
+	calls int
+}
+
+func (c *countingAuthorizer) Authorize(_ context.Context, _ *cmapi.CertificateRequest, _ string) (bool, error) {
+	c.calls++
+	return true, nil
+}
+
+func Test_CachedAuthorizer(t *testing.T) {
+	var (
+		ctx   = t.Context()
+		clock = fakeclock.NewFakeClock(time.Now())
+		inner = new(countingAuthorizer)
+		c     = NewCachedAuthorizer(inner, time.Minute, clock)
+	)
+
+	request := func(namespace string, groups ...string) *cmapi.CertificateRequest {
+		return &cmapi.CertificateRequest{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace},
+			Spec:       cmapi.CertificateRequestSpec{Username: "user-a", Groups: groups},
+		}
+	}
+	authorize := func(cr *cmapi.CertificateRequest, policy string) {
+		t.Helper()
+		allowed, err := c.Authorize(ctx, cr, policy)
+		require.NoError(t, err)
+		assert.True(t, allowed)
+	}
+
+	authorize(request("ns-a", "group-a", "group-b"), "policy-a")
+	authorize(request("ns-a", "group-b", "group-a"), "policy-a")
+	assert.Equal(t, 1, inner.calls, "expected the order of groups not to affect the cache")
+
+	authorize(request("ns-a", "group-a"), "policy-a")
+	authorize(request("ns-a", "group-a", "group-b"), "policy-b")
+	authorize(request("ns-b", "group-a", "group-b"), "policy-a")
+	assert.Equal(t, 4, inner.calls, "expected different groups, policies and namespaces not to be cached together")
+
+	c.Invalidate("ns-b")
+	authorize(request("ns-a", "group-a", "group-b"), "policy-a")
+	assert.Equal(t, 4, inner.calls, "expected invalidating another namespace to keep the result")
+	authorize(request("ns-b", "group-a", "group-b"), "policy-a")
+	assert.Equal(t, 5, inner.calls, "expected invalidating the namespace to discard the result")
+
+	c.Invalidate("")
+	authorize(request("ns-a", "group-a", "group-b"), "policy-a")
+	assert.Equal(t, 6, inner.calls, "expected invalidating all namespaces to discard the result")
+
+	clock.Step(time.Minute)
+	authorize(request("ns-a", "group-a", "group-b"), "policy-a")
+	assert.Equal(t, 7, inner.calls, "expected expired results to be discarded")
+}
+
+func Test_RBACAuthorizer(t *testing.T) {
+	ctx := t.Context()
+
+	cl := fakeclient.NewClientBuilder().
+		WithScheme(policyapi.GlobalScheme).
+		WithObjects(
+			&rbacv1.ClusterRole{
+				ObjectMeta: metav1.ObjectMeta{Name: "use-policy"},
+				Rules:      []rbacv1.PolicyRule{{APIGroups: []string{"policy.cert-manager.io"}, Resources: []string{"certificaterequestpolicies"}, Verbs: []string{"use"}}},
+			},
+			&rbacv1.RoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns-a", Name: "user-a"},
+				RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "use-policy"},
+				Subjects:   []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "user-a"}},
+			},
+		).
+		Build()
+
+	a := NewRBACAuthorizer(cl)
+	cr := &cmapi.CertificateRequest{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns-b"},
+		Spec:       cmapi.CertificateRequestSpec{Username: "user-a"},
+	}
+
+	allowed, err := a.Authorize(ctx, cr, "policy-a")
+	require.NoError(t, err)
+	assert.False(t, allowed, "expected user not to be bound in ns-b")
+
+	require.NoError(t, cl.Create(ctx, &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns-b", Name: "user-a"},
+		RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "use-policy"},
+		Subjects:   []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "user-a"}},
+	}))
+
+	allowed, err = a.Authorize(ctx, cr, "policy-a")
+	require.NoError(t, err)
+	assert.False(t, allowed, "expected the snapshot to be used until invalidated")
+
+	a.Invalidate("ns-b")
+	allowed, err = a.Authorize(ctx, cr, "policy-a")
+	require.NoError(t, err)
+	assert.True(t, allowed, "expected user to be bound in ns-b once invalidated")
+}
+
+// listHook calls onList before every List of the wrapped reader.
+type listHook struct {
+	client.Reader
+	onList func()
+}
+
+func (l *listHook) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	l.onList()
+	return l.Reader.List(ctx, list, opts...)
+}
+
+func Test_RBACAuthorizerInvalidatedDuringBuild(t *testing.T) {
+	ctx := t.Context()
+
+	cl := fakeclient.NewClientBuilder().WithScheme(policyapi.GlobalScheme).Build()
+
+	var (
+		a          *RBACAuthorizer
+		lists      int
+		invalidate bool
+	)
+	a = NewRBACAuthorizer(&listHook{Reader: cl, onList: func() {
+		lists++
+		if invalidate {
+			// Simulate RBAC changing while the snapshot is being built.
+			invalidate = false
+			a.Invalidate("")
+		}
+	}})
+	cr := &cmapi.CertificateRequest{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns-a"},
+		Spec:       cmapi.CertificateRequestSpec{Username: "user-a"},
+	}
+
+	invalidate = true
+	_, err := a.Authorize(ctx, cr, "policy-a")
+	require.NoError(t, err)
+	assert.Nil(t, a.snapshot, "expected a snapshot built during an invalidation not to be stored")
+
+	built := lists
+	_, err = a.Authorize(ctx, cr, "policy-a")
+	require.NoError(t, err)
+	assert.Greater(t, lists, built, "expected the snapshot to be rebuilt")
+	assert.NotNil(t, a.snapshot)
+
+	built = lists
+	_, err = a.Authorize(ctx, cr, "policy-a")
+	require.NoError(t, err)
+	assert.Equal(t, built, lists, "expected the stored snapshot to be used")
+}
@@ -21,7 +21,6 @@ import (
 	"fmt"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
-	authzv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -172,37 +171,23 @@ func SelectorNamespace(lister client.Reader) Predicate {
 // CertificateRequestPolicies that have been RBAC bound to the user in the
 // CertificateRequest. Achieved using SubjectAccessReviews.
 func RBACBound(client client.Client) Predicate {
-	return func(ctx context.Context, cr *cmapi.CertificateRequest, policies []policyapi.CertificateRequestPolicy) ([]policyapi.CertificateRequestPolicy, error) {
-		extra := make(map[string]authzv1.ExtraValue)
-		for k, v := range cr.Spec.Extra {
-			extra[k] = v
-		}
+	return Bound(NewSubjectAccessReviewAuthorizer(client))
+}
 
+// Bound is a Predicate that returns the subset of CertificateRequestPolicies
+// that the user in the CertificateRequest is authorized to use by the
+// Authorizer.
+func Bound(authorizer Authorizer) Predicate {
+	return func(ctx context.Context, cr *cmapi.CertificateRequest, policies []policyapi.CertificateRequestPolicy) ([]policyapi.CertificateRequestPolicy, error) {
 		var boundPolicies []policyapi.CertificateRequestPolicy
 		for _, policy := range policies {
-			// Perform subject access review for this CertificateRequestPolicy
-			rev := &authzv1.SubjectAccessReview{
-				Spec: authzv1.SubjectAccessReviewSpec{
-					User:   cr.Spec.Username,
-					Groups: cr.Spec.Groups,
-					Extra:  extra,
-					UID:    cr.Spec.UID,
-
-					ResourceAttributes: &authzv1.ResourceAttributes{
-						Group:     "policy.cert-manager.io",
-						Resource:  "certificaterequestpolicies",
-						Name:      policy.Name,
-						Namespace: cr.Namespace,
-						Verb:      "use",
-					},
-				},
-			}
-			if err := client.Create(ctx, rev); err != nil {
-				return nil, fmt.Errorf("failed to create subjectaccessreview: %w", err)
+			allowed, err := authorizer.Authorize(ctx, cr, policy.Name)
+			if err != nil {
+				return nil, err
 			}
 
 			// If the user is bound to this policy then append.
-			if rev.Status.Allowed {
+			if allowed {
 				boundPolicies = append(boundPolicies, policy)
 			}
 		}
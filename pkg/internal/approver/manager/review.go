@@ -21,14 +21,20 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
 	"github.com/cert-manager/approver-policy/pkg/approver"
 	"github.com/cert-manager/approver-policy/pkg/approver/manager"
 	"github.com/cert-manager/approver-policy/pkg/internal/approver/manager/predicate"
+	"github.com/cert-manager/approver-policy/pkg/internal/metrics"
+	"github.com/cert-manager/approver-policy/pkg/internal/tracing"
 )
 
 var _ manager.Interface = &mngr{}
@@ -38,10 +44,35 @@ var _ manager.Interface = &mngr{}
 // CertificateRequests using the registered evaluators.
 type mngr struct {
 	lister     client.Reader
-	predicates []predicate.Predicate
+	predicates []namedPredicate
 	evaluators []approver.Evaluator
+
+	// bound is the predicate filtering the policies selected by predicates to
+	// those bound to the requester. Selected policies which are not bound may
+	// deny the request through their defaultAction. If nil, all selected
+	// policies are considered bound.
+	bound predicate.Predicate
+
+	// evaluateOnly disables recording metrics and spans, for reviews which
+	// don't decide requests.
+	evaluateOnly bool
+
+	// caller is recorded as the caller label of the review, predicate and
+	// evaluator latencies, such as metrics.CallerController.
+	caller string
 }
 
+// namedPredicate is a predicate with a name, used to report how many policies
+// each predicate removed from consideration.
+type namedPredicate struct {
+	name string
+	predicate.Predicate
+}
+
+// boundPredicateName is the name reported for policies removed from
+// consideration by the bound predicate.
+const boundPredicateName = "RBACBound"
+
 // policyMessage holds the name of the CertificateRequestPolicy and aggregated
 // message when running the evaluators against the CertificateRequest.
 type policyMessage struct {
@@ -54,6 +85,20 @@ type policyMessage struct {
 	message string
 }
 
+// Selector selects the CertificateRequestPolicies which apply to a
+// CertificateRequest.
+type Selector interface {
+	// Select returns the CertificateRequestPolicies which are ready, select
+	// and are bound to the CertificateRequest.
+	Select(ctx context.Context, cr *cmapi.CertificateRequest) ([]policyapi.CertificateRequestPolicy, error)
+}
+
+// NewSelector constructs a Selector that filters CertificateRequestPolicies
+// with the same predicates as the Manager returned by New.
+func NewSelector(caller string, lister client.Reader, authorizer predicate.Authorizer) Selector {
+	return New(caller, lister, authorizer, nil).(*mngr)
+}
+
 // New constructs a new approver Manager that evaluates whether
 // CertificateRequests should be approved or denied, managing registered
 // evaluators.
@@ -63,24 +108,74 @@ type policyMessage struct {
 //
 // IssuerRef
 //   - CertificateRequestPolicy is bound to the user that appears in the
-//     CertificateRequest
-func New(lister client.Reader, client client.Client, evaluators []approver.Evaluator) manager.Interface {
+//     CertificateRequest, as decided by the authorizer
+//
+// If no policy is bound, a selected policy with a defaultAction of Deny will
+// deny the request.
+//
+// Latencies are recorded with the caller, such as metrics.CallerController,
+// so that reviews made by the controller and the webhook can be told apart.
+func New(caller string, lister client.Reader, authorizer predicate.Authorizer, evaluators []approver.Evaluator) manager.Interface {
 	return &mngr{
+		caller: caller,
 		lister: lister,
-		predicates: []predicate.Predicate{
-			predicate.Ready,
-			predicate.SelectorIssuerRef,
-			predicate.SelectorNamespace(lister),
-			predicate.RBACBound(client),
+		predicates: []namedPredicate{
+			{"Ready", predicate.Ready},
+			{"SelectorIssuerRef", predicate.SelectorIssuerRef},
+			{"SelectorNamespace", predicate.SelectorNamespace(lister)},
 		},
+		bound:      predicate.Bound(authorizer),
 		evaluators: evaluators,
 	}
 }
 
+// NewEvaluateOnly constructs an approver Manager that reviews
+// CertificateRequests without deciding them, such as when re-reviewing
+// approved requests. It filters policies with the same predicates as New,
+// including that policies are bound to the user, so that requests whose
+// requester has since lost access to the approving policy are not reported as
+// approved. No metrics or spans are recorded.
+func NewEvaluateOnly(lister client.Reader, authorizer predicate.Authorizer, evaluators []approver.Evaluator) manager.Interface {
+	m := New("", lister, authorizer, evaluators).(*mngr)
+	m.evaluateOnly = true
+	return m
+}
+
 // Review will evaluate whether the incoming CertificateRequest should be
 // approved. All evaluators will be called with CertificateRequestPolicys that
 // have passed all of the predicates.
 func (m *mngr) Review(ctx context.Context, cr *cmapi.CertificateRequest) (manager.ReviewResponse, error) {
+	if m.evaluateOnly {
+		return m.review(ctx, cr)
+	}
+
+	ctx, span := tracing.Tracer().Start(ctx, "manager.Review", trace.WithAttributes(
+		attribute.String("certificaterequest.namespace", cr.Namespace),
+		attribute.String("certificaterequest.name", cr.Name),
+	))
+	defer span.End()
+
+	start := time.Now()
+	response, err := m.review(ctx, cr)
+
+	result := response.Result.String()
+	if err != nil {
+		result = "error"
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	metrics.ObserveReview(m.caller, result, time.Since(start))
+
+	span.SetAttributes(attribute.String("review.result", result))
+	if len(response.Policy) > 0 {
+		span.SetAttributes(attribute.String("review.policy", response.Policy))
+	}
+
+	return response, err
+}
+
+// review evaluates whether the incoming CertificateRequest should be approved.
+func (m *mngr) review(ctx context.Context, cr *cmapi.CertificateRequest) (manager.ReviewResponse, error) {
 	policyList := new(policyapi.CertificateRequestPolicyList)
 	if err := m.lister.List(ctx, policyList); err != nil {
 		return manager.ReviewResponse{}, err
@@ -93,71 +188,326 @@ func (m *mngr) Review(ctx context.Context, cr *cmapi.CertificateRequest) (manage
 		return manager.ReviewResponse{Result: manager.ResultUnprocessed, Message: "No CertificateRequestPolicies exist"}, nil
 	}
 
-	var (
-		policies = policyList.Items
-		err      error
-	)
-	for _, predicate := range m.predicates {
-		policies, err = predicate(ctx, cr, policies)
-		if err != nil {
-			return manager.ReviewResponse{}, fmt.Errorf("failed to perform predicate on policies: %w", err)
+	selected, policies, eliminations, err := m.filter(ctx, cr, policyList.Items)
+	if err != nil {
+		return manager.ReviewResponse{}, err
+	}
+
+	// If no policies are bound, but a selected policy wants to deny requests
+	// it is not bound to, return ResultDenied.
+	if len(policies) == 0 {
+		var (
+			denying []string
+			denials []manager.Denial
+		)
+		for _, policy := range selected {
+			if policy.Spec.DefaultAction == policyapi.CertificateRequestPolicyDefaultActionDeny {
+				denying = append(denying, policy.Name)
+			}
+		}
+		if len(denying) > 0 {
+			sort.Strings(denying)
+			for _, name := range denying {
+				denials = append(denials, manager.Denial{Policy: name, Field: "spec.defaultAction"})
+			}
+			return manager.ReviewResponse{
+				Result:       manager.ResultDenied,
+				Message:      fmt.Sprintf("Request is selected by CertificateRequestPolicies with defaultAction Deny, but the requester is not bound to them: %s", strings.Join(denying, ", ")),
+				Denials:      denials,
+				Eliminations: eliminations,
+			}, nil
 		}
 	}
 
 	// If no policies are appropriate, return ResultUnprocessed.
 	if len(policies) == 0 {
 		return manager.ReviewResponse{
-			Result:  manager.ResultUnprocessed,
-			Message: "No CertificateRequestPolicies bound or applicable",
+			Result:       manager.ResultUnprocessed,
+			Message:      "No CertificateRequestPolicies bound or applicable",
+			Eliminations: eliminations,
 		}, nil
 	}
 
 	// policyMessages hold the aggregated messages of each evaluator response,
 	// keyed by the policy name that was executed.
-	var policyMessages []policyMessage
+	var policyMessages, pendingMessages []policyMessage
+
+	// denials are the reasons each evaluator denied each policy.
+	var denials []manager.Denial
+
+	// requeueAfter is the shortest duration after which a pending evaluator
+	// asked for the request to be evaluated again.
+	var requeueAfter time.Duration
 
 	// Run every evaluators against ever policy which is bound to the requesting
 	// user.
 	for _, policy := range policies {
-		var (
-			evaluatorDenied   bool
-			evaluatorMessages []string
-		)
-
-		for _, evaluator := range m.evaluators {
-			// #nosec G601 -- False positive. The function does not keep this pointer past its scope.
-			response, err := evaluator.Evaluate(ctx, &policy, cr)
-			if err != nil {
-				// if a single evaluator errors, then return early without trying
-				// others.
-				return manager.ReviewResponse{}, err
-			}
+		// #nosec G601 -- False positive. The function does not keep this pointer past its scope.
+		evaluation, err := m.evaluatePolicy(ctx, &policy, cr)
+		if err != nil {
+			// if a single evaluator errors, then return early without trying
+			// others.
+			return manager.ReviewResponse{}, err
+		}
 
-			if len(response.Message) > 0 {
-				evaluatorMessages = append(evaluatorMessages, response.Message)
-			}
+		if evaluation.requeueAfter > 0 && (requeueAfter == 0 || evaluation.requeueAfter < requeueAfter) {
+			requeueAfter = evaluation.requeueAfter
+		}
 
-			// evaluatorDenied will be set to true if any evaluator denies. We don't
-			// break early so that we can capture the responses from _all_
-			// evaluators.
-			if response.Result == approver.ResultDenied {
-				evaluatorDenied = true
-			}
+		// A pending policy may approve the request at a later time, so continue
+		// looking for a policy that approves the request now.
+		if !evaluation.denied && evaluation.pending {
+			pendingMessages = append(pendingMessages, policyMessage{name: policy.Name, message: strings.Join(evaluation.messages, ", ")})
+			continue
 		}
 
 		// If no evaluator denied the request, return with approved response.
-		if !evaluatorDenied {
+		// Messages from evaluators, such as who manually approved the request,
+		// are included in the response.
+		if !evaluation.denied {
+			message := fmt.Sprintf("Approved by CertificateRequestPolicy: %q", policy.Name)
+			if len(evaluation.messages) > 0 {
+				message = fmt.Sprintf("%s: %s", message, strings.Join(evaluation.messages, ", "))
+			}
 			return manager.ReviewResponse{
-				Result:  manager.ResultApproved,
-				Message: fmt.Sprintf("Approved by CertificateRequestPolicy: %q", policy.Name),
+				Result:       manager.ResultApproved,
+				Message:      message,
+				Policy:       policy.Name,
+				Eliminations: eliminations,
 			}, nil
 		}
 
 		// Collect evaluator messages that were executed for this policy.
-		policyMessages = append(policyMessages, policyMessage{name: policy.Name, message: strings.Join(evaluatorMessages, ", ")})
+		policyMessages = append(policyMessages, policyMessage{name: policy.Name, message: strings.Join(evaluation.messages, ", ")})
+		denials = append(denials, evaluation.denials...)
 	}
 
-	// Sort messages by policy name and build message string.
+	// If any policy is pending, the request may still be approved so is
+	// neither approved nor denied.
+	if len(pendingMessages) > 0 {
+		return manager.ReviewResponse{
+			Result:       manager.ResultPending,
+			Message:      fmt.Sprintf("Request is pending approval: %s", joinPolicyMessages(pendingMessages)),
+			RequeueAfter: requeueAfter,
+			Eliminations: eliminations,
+		}, nil
+	}
+
+	// Return with all policies that we consulted, and their errors to why the
+	// request was denied.
+	return manager.ReviewResponse{
+		Result:       manager.ResultDenied,
+		Message:      fmt.Sprintf("No policy approved this request: %s", joinPolicyMessages(policyMessages)),
+		Denials:      denials,
+		Eliminations: eliminations,
+	}, nil
+}
+
+// policyEvaluation is the aggregated result of running every evaluator
+// against a single CertificateRequestPolicy.
+type policyEvaluation struct {
+	// denied is true if any evaluator denied the request.
+	denied bool
+
+	// pending is true if any evaluator which did not deny the request is
+	// pending.
+	pending bool
+
+	// messages are the messages returned by the evaluators.
+	messages []string
+
+	// denials are the reasons the evaluators denied the request.
+	denials []manager.Denial
+
+	// requeueAfter is the shortest duration after which a pending evaluator
+	// asked for the request to be evaluated again.
+	requeueAfter time.Duration
+}
+
+// result returns the result of the evaluation, as recorded on traces.
+func (e policyEvaluation) result() string {
+	switch {
+	case e.denied:
+		return "denied"
+	case e.pending:
+		return "pending"
+	default:
+		return "approved"
+	}
+}
+
+// evaluatePolicy runs every evaluator against the policy. Every evaluator is
+// run, even after one has denied the request, so that the responses from
+// _all_ evaluators are captured.
+func (m *mngr) evaluatePolicy(ctx context.Context, policy *policyapi.CertificateRequestPolicy, cr *cmapi.CertificateRequest) (policyEvaluation, error) {
+	ctx, span := m.startSpan(ctx, "policy.Evaluate", trace.WithAttributes(
+		attribute.String("policy.name", policy.Name),
+	))
+	defer span.End()
+
+	var evaluation policyEvaluation
+	for _, evaluator := range m.evaluators {
+		response, err := m.evaluate(ctx, evaluator, policy, cr)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return policyEvaluation{}, err
+		}
+
+		if len(response.Message) > 0 {
+			evaluation.messages = append(evaluation.messages, response.Message)
+		}
+
+		if response.Result == approver.ResultDenied {
+			evaluation.denied = true
+			evaluation.denials = append(evaluation.denials, denialsFor(policy.Name, evaluator, response)...)
+		} else if response.Pending {
+			evaluation.pending = true
+			if response.RequeueAfter > 0 && (evaluation.requeueAfter == 0 || response.RequeueAfter < evaluation.requeueAfter) {
+				evaluation.requeueAfter = response.RequeueAfter
+			}
+		}
+	}
+
+	span.SetAttributes(attribute.String("policy.result", evaluation.result()))
+	return evaluation, nil
+}
+
+// evaluate runs a single evaluator against the policy, recording its latency
+// and a span. The span context is passed to the evaluator so plugins may add
+// their own spans.
+func (m *mngr) evaluate(ctx context.Context, evaluator approver.Evaluator, policy *policyapi.CertificateRequestPolicy, cr *cmapi.CertificateRequest) (approver.EvaluationResponse, error) {
+	name := evaluatorName(evaluator)
+	ctx, span := m.startSpan(ctx, "evaluator.Evaluate", trace.WithAttributes(
+		attribute.String("evaluator.name", name),
+		attribute.String("policy.name", policy.Name),
+	))
+	defer span.End()
+
+	start := time.Now()
+	response, err := evaluator.Evaluate(ctx, policy, cr)
+	if !m.evaluateOnly {
+		metrics.ObserveEvaluator(m.caller, name, time.Since(start))
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return response, err
+	}
+
+	result := "not-denied"
+	switch {
+	case response.Result == approver.ResultDenied:
+		result = "denied"
+	case response.Pending:
+		result = "pending"
+	}
+	span.SetAttributes(attribute.String("evaluator.result", result))
+
+	return response, nil
+}
+
+// Select returns the CertificateRequestPolicies which are selected by and
+// bound to the CertificateRequest, using the same predicates as Review.
+func (m *mngr) Select(ctx context.Context, cr *cmapi.CertificateRequest) ([]policyapi.CertificateRequestPolicy, error) {
+	policyList := new(policyapi.CertificateRequestPolicyList)
+	if err := m.lister.List(ctx, policyList); err != nil {
+		return nil, err
+	}
+
+	_, bound, _, err := m.filter(ctx, cr, policyList.Items)
+	return bound, err
+}
+
+// filter returns the policies which pass all predicates, and of those, the
+// policies which are bound to the CertificateRequest. The number of policies
+// removed by each predicate is returned, keyed by predicate name, omitting
+// predicates which removed none.
+func (m *mngr) filter(ctx context.Context, cr *cmapi.CertificateRequest, policies []policyapi.CertificateRequestPolicy) ([]policyapi.CertificateRequestPolicy, []policyapi.CertificateRequestPolicy, map[string]int, error) {
+	var eliminations map[string]int
+	eliminated := func(name string, before, after int) {
+		if before > after {
+			if eliminations == nil {
+				eliminations = make(map[string]int)
+			}
+			eliminations[name] += before - after
+		}
+	}
+
+	for _, predicate := range m.predicates {
+		filtered, err := m.runPredicate(ctx, predicate, cr, policies)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to perform predicate on policies: %w", err)
+		}
+		eliminated(predicate.name, len(policies), len(filtered))
+		policies = filtered
+	}
+
+	selected := policies
+	if m.bound != nil {
+		var err error
+		policies, err = m.runPredicate(ctx, namedPredicate{boundPredicateName, m.bound}, cr, selected)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to perform predicate on policies: %w", err)
+		}
+		eliminated(boundPredicateName, len(selected), len(policies))
+	}
+
+	return selected, policies, eliminations, nil
+}
+
+// runPredicate runs the predicate over the policies, recording its latency and
+// a span.
+func (m *mngr) runPredicate(ctx context.Context, predicate namedPredicate, cr *cmapi.CertificateRequest, policies []policyapi.CertificateRequestPolicy) ([]policyapi.CertificateRequestPolicy, error) {
+	ctx, span := m.startSpan(ctx, "predicate.Filter", trace.WithAttributes(
+		attribute.String("predicate.name", predicate.name),
+		attribute.Int("predicate.policies.in", len(policies)),
+	))
+	defer span.End()
+
+	start := time.Now()
+	filtered, err := predicate.Predicate(ctx, cr, policies)
+	if !m.evaluateOnly {
+		metrics.ObservePredicate(m.caller, predicate.name, time.Since(start))
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("predicate.policies.out", len(filtered)))
+	return filtered, nil
+}
+
+// startSpan starts a span, unless the manager is evaluate only, in which case
+// a span which records nothing is returned.
+func (m *mngr) startSpan(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	if m.evaluateOnly {
+		return ctx, trace.SpanFromContext(context.Background())
+	}
+	return tracing.Tracer().Start(ctx, name, opts...)
+}
+
+// denialsFor returns the denials of an evaluator which denied the policy, one
+// for each field error given by the evaluator.
+func denialsFor(policy string, evaluator approver.Evaluator, response approver.EvaluationResponse) []manager.Denial {
+	name := evaluatorName(evaluator)
+
+	if len(response.Errors) == 0 {
+		return []manager.Denial{{Policy: policy, Evaluator: name}}
+	}
+
+	denials := make([]manager.Denial, 0, len(response.Errors))
+	for _, err := range response.Errors {
+		denials = append(denials, manager.Denial{Policy: policy, Evaluator: name, Field: err.Field})
+	}
+	return denials
+}
+
+// joinPolicyMessages sorts messages by policy name and builds a message
+// string.
+func joinPolicyMessages(policyMessages []policyMessage) string {
 	sort.SliceStable(policyMessages, func(i, j int) bool {
 		return policyMessages[i].name < policyMessages[j].name
 	})
@@ -165,11 +515,14 @@ func (m *mngr) Review(ctx context.Context, cr *cmapi.CertificateRequest) (manage
 	for _, policyMessage := range policyMessages {
 		messages = append(messages, fmt.Sprintf("[%s: %s]", policyMessage.name, policyMessage.message))
 	}
+	return strings.Join(messages, " ")
+}
 
-	// Return with all policies that we consulted, and their errors to why the
-	// request was denied.
-	return manager.ReviewResponse{
-		Result:  manager.ResultDenied,
-		Message: fmt.Sprintf("No policy approved this request: %s", strings.Join(messages, " ")),
-	}, nil
+// evaluatorName returns the name of the approver of an evaluator, or its type
+// if it has no name.
+func evaluatorName(evaluator approver.Evaluator) string {
+	if named, ok := evaluator.(interface{ Name() string }); ok {
+		return named.Name()
+	}
+	return fmt.Sprintf("%T", evaluator)
 }
@@ -21,6 +21,7 @@ import (
 	"errors"
 	"path"
 	"testing"
+	"time"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
@@ -51,6 +52,7 @@ func Test_Review(t *testing.T) {
 	tests := map[string]struct {
 		evaluator   func(t *testing.T) approver.Evaluator
 		predicate   func(t *testing.T) predicate.Predicate
+		bound       predicate.Predicate
 		policies    []policyapi.CertificateRequestPolicy
 		expResponse manager.ReviewResponse
 		expErr      bool
@@ -92,7 +94,7 @@ func Test_Review(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{Name: "test-policy-a"},
 				Spec:       policyapi.CertificateRequestPolicySpec{Selector: policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{}}},
 			}},
-			expResponse: manager.ReviewResponse{Result: manager.ResultUnprocessed, Message: "No CertificateRequestPolicies bound or applicable"},
+			expResponse: manager.ReviewResponse{Result: manager.ResultUnprocessed, Message: "No CertificateRequestPolicies bound or applicable", Eliminations: map[string]int{"Test": 1}},
 			expErr:      false,
 		},
 		"if single policy returns but evaluator denies, return ResultDenied": {
@@ -113,7 +115,7 @@ func Test_Review(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{Name: "test-policy-a"},
 				Spec:       policyapi.CertificateRequestPolicySpec{Selector: policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{}}},
 			}},
-			expResponse: manager.ReviewResponse{Result: manager.ResultDenied, Message: "No policy approved this request: [test-policy-a: this is a denied response]"},
+			expResponse: manager.ReviewResponse{Result: manager.ResultDenied, Message: "No policy approved this request: [test-policy-a: this is a denied response]", Denials: []manager.Denial{{Policy: "test-policy-a", Evaluator: "*fake.FakeEvaluator"}}},
 			expErr:      false,
 		},
 		"if single policy returns and evaluator returns not-denied, return ResultApproved": {
@@ -134,7 +136,7 @@ func Test_Review(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{Name: "test-policy-a"},
 				Spec:       policyapi.CertificateRequestPolicySpec{Selector: policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{}}},
 			}},
-			expResponse: manager.ReviewResponse{Result: manager.ResultApproved, Message: `Approved by CertificateRequestPolicy: "test-policy-a"`},
+			expResponse: manager.ReviewResponse{Result: manager.ResultApproved, Message: `Approved by CertificateRequestPolicy: "test-policy-a": this is a not-denied response`, Policy: "test-policy-a"},
 			expErr:      false,
 		},
 		"if two policies returned and evaluator returns one not-denied, return ResultApproved": {
@@ -170,7 +172,7 @@ func Test_Review(t *testing.T) {
 					Spec:       policyapi.CertificateRequestPolicySpec{Selector: policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{}}},
 				},
 			},
-			expResponse: manager.ReviewResponse{Result: manager.ResultApproved, Message: `Approved by CertificateRequestPolicy: "test-policy-b"`},
+			expResponse: manager.ReviewResponse{Result: manager.ResultApproved, Message: `Approved by CertificateRequestPolicy: "test-policy-b": this is an approved response`, Policy: "test-policy-b"},
 			expErr:      false,
 		},
 		"if two policies returned and both return denied, return ResultDenied": {
@@ -203,7 +205,127 @@ func Test_Review(t *testing.T) {
 					Spec:       policyapi.CertificateRequestPolicySpec{Selector: policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{}}},
 				},
 			},
-			expResponse: manager.ReviewResponse{Result: manager.ResultDenied, Message: "No policy approved this request: [test-policy-a: this is a denied response] [test-policy-b: this is a denied response]"},
+			expResponse: manager.ReviewResponse{Result: manager.ResultDenied, Message: "No policy approved this request: [test-policy-a: this is a denied response] [test-policy-b: this is a denied response]", Denials: []manager.Denial{{Policy: "test-policy-a", Evaluator: "*fake.FakeEvaluator"}, {Policy: "test-policy-b", Evaluator: "*fake.FakeEvaluator"}}},
+			expErr:      false,
+		},
+		"if two policies returned and one is pending while the other denies, return ResultPending": {
+			evaluator: func(t *testing.T) approver.Evaluator {
+				return fake.NewFakeEvaluator().WithEvaluate(func(_ context.Context, policy *policyapi.CertificateRequestPolicy, _ *cmapi.CertificateRequest) (approver.EvaluationResponse, error) {
+					if policy.Name == "test-policy-a" {
+						return approver.EvaluationResponse{Result: approver.ResultNotDenied, Pending: true, RequeueAfter: time.Minute, Message: "this is a pending response"}, nil
+					}
+					return approver.EvaluationResponse{Result: approver.ResultDenied, Message: "this is a denied response"}, nil
+				})
+			},
+			predicate: func(t *testing.T) predicate.Predicate {
+				return func(_ context.Context, _ *cmapi.CertificateRequest, _ []policyapi.CertificateRequestPolicy) ([]policyapi.CertificateRequestPolicy, error) {
+					return []policyapi.CertificateRequestPolicy{
+						{
+							ObjectMeta: metav1.ObjectMeta{Name: "test-policy-a"},
+							Spec:       policyapi.CertificateRequestPolicySpec{Selector: policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{}}},
+						},
+						{
+							ObjectMeta: metav1.ObjectMeta{Name: "test-policy-b"},
+							Spec:       policyapi.CertificateRequestPolicySpec{Selector: policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{}}},
+						},
+					}, nil
+				}
+			},
+			policies: []policyapi.CertificateRequestPolicy{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-policy-a"},
+					Spec:       policyapi.CertificateRequestPolicySpec{Selector: policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{}}},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-policy-b"},
+					Spec:       policyapi.CertificateRequestPolicySpec{Selector: policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{}}},
+				},
+			},
+			expResponse: manager.ReviewResponse{Result: manager.ResultPending, Message: "Request is pending approval: [test-policy-a: this is a pending response]", RequeueAfter: time.Minute},
+			expErr:      false,
+		},
+		"if two policies returned and one is pending while the other approves, return ResultApproved": {
+			evaluator: func(t *testing.T) approver.Evaluator {
+				return fake.NewFakeEvaluator().WithEvaluate(func(_ context.Context, policy *policyapi.CertificateRequestPolicy, _ *cmapi.CertificateRequest) (approver.EvaluationResponse, error) {
+					if policy.Name == "test-policy-a" {
+						return approver.EvaluationResponse{Result: approver.ResultNotDenied, Pending: true, Message: "this is a pending response"}, nil
+					}
+					return approver.EvaluationResponse{Result: approver.ResultNotDenied}, nil
+				})
+			},
+			predicate: func(t *testing.T) predicate.Predicate {
+				return func(_ context.Context, _ *cmapi.CertificateRequest, _ []policyapi.CertificateRequestPolicy) ([]policyapi.CertificateRequestPolicy, error) {
+					return []policyapi.CertificateRequestPolicy{
+						{
+							ObjectMeta: metav1.ObjectMeta{Name: "test-policy-a"},
+							Spec:       policyapi.CertificateRequestPolicySpec{Selector: policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{}}},
+						},
+						{
+							ObjectMeta: metav1.ObjectMeta{Name: "test-policy-b"},
+							Spec:       policyapi.CertificateRequestPolicySpec{Selector: policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{}}},
+						},
+					}, nil
+				}
+			},
+			policies: []policyapi.CertificateRequestPolicy{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-policy-a"},
+					Spec:       policyapi.CertificateRequestPolicySpec{Selector: policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{}}},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-policy-b"},
+					Spec:       policyapi.CertificateRequestPolicySpec{Selector: policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{}}},
+				},
+			},
+			expResponse: manager.ReviewResponse{Result: manager.ResultApproved, Message: `Approved by CertificateRequestPolicy: "test-policy-b"`, Policy: "test-policy-b"},
+			expErr:      false,
+		},
+		"if selected policies are not bound and one has defaultAction Deny, return ResultDenied": {
+			evaluator: expNoEvaluation,
+			predicate: func(t *testing.T) predicate.Predicate {
+				return func(_ context.Context, _ *cmapi.CertificateRequest, policies []policyapi.CertificateRequestPolicy) ([]policyapi.CertificateRequestPolicy, error) {
+					return policies, nil
+				}
+			},
+			bound: func(_ context.Context, _ *cmapi.CertificateRequest, _ []policyapi.CertificateRequestPolicy) ([]policyapi.CertificateRequestPolicy, error) {
+				return nil, nil
+			},
+			policies: []policyapi.CertificateRequestPolicy{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-policy-a"},
+					Spec:       policyapi.CertificateRequestPolicySpec{Selector: policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{}}},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-policy-b"},
+					Spec: policyapi.CertificateRequestPolicySpec{
+						Selector:      policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{}},
+						DefaultAction: policyapi.CertificateRequestPolicyDefaultActionDeny,
+					},
+				},
+			},
+			expResponse: manager.ReviewResponse{Result: manager.ResultDenied, Message: "Request is selected by CertificateRequestPolicies with defaultAction Deny, but the requester is not bound to them: test-policy-b", Denials: []manager.Denial{{Policy: "test-policy-b", Field: "spec.defaultAction"}}, Eliminations: map[string]int{"RBACBound": 2}},
+			expErr:      false,
+		},
+		"if selected policies are not bound and none have defaultAction Deny, return ResultUnprocessed": {
+			evaluator: expNoEvaluation,
+			predicate: func(t *testing.T) predicate.Predicate {
+				return func(_ context.Context, _ *cmapi.CertificateRequest, policies []policyapi.CertificateRequestPolicy) ([]policyapi.CertificateRequestPolicy, error) {
+					return policies, nil
+				}
+			},
+			bound: func(_ context.Context, _ *cmapi.CertificateRequest, _ []policyapi.CertificateRequestPolicy) ([]policyapi.CertificateRequestPolicy, error) {
+				return nil, nil
+			},
+			policies: []policyapi.CertificateRequestPolicy{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-policy-a"},
+					Spec: policyapi.CertificateRequestPolicySpec{
+						Selector:      policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{}},
+						DefaultAction: policyapi.CertificateRequestPolicyDefaultActionIgnore,
+					},
+				},
+			},
+			expResponse: manager.ReviewResponse{Result: manager.ResultUnprocessed, Message: "No CertificateRequestPolicies bound or applicable", Eliminations: map[string]int{"RBACBound": 1}},
 			expErr:      false,
 		},
 	}
@@ -229,8 +351,9 @@ func Test_Review(t *testing.T) {
 
 			mngr := &mngr{
 				lister:     env.AdminClient,
-				predicates: []predicate.Predicate{test.predicate(t)},
+				predicates: []namedPredicate{{"Test", test.predicate(t)}},
 				evaluators: []approver.Evaluator{test.evaluator(t)},
+				bound:      test.bound,
 			}
 
 			response, err := mngr.Review(ctx, &cmapi.CertificateRequest{
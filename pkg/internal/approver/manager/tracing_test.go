@@ -0,0 +1,152 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"context"
+	"testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver"
+	"github.com/cert-manager/approver-policy/pkg/approver/fake"
+	"github.com/cert-manager/approver-policy/pkg/approver/manager"
+	"github.com/cert-manager/approver-policy/pkg/internal/tracing"
+)
+
+func Test_ReviewTracing(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tracing.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	t.Cleanup(func() { tracing.SetTracerProvider(noop.NewTracerProvider()) })
+
+	lister := fakeclient.NewClientBuilder().
+		WithScheme(policyapi.GlobalScheme).
+		WithRuntimeObjects(
+			&policyapi.CertificateRequestPolicy{ObjectMeta: metav1.ObjectMeta{Name: "test-policy-a"}},
+			&policyapi.CertificateRequestPolicy{ObjectMeta: metav1.ObjectMeta{Name: "test-policy-b"}},
+		).
+		Build()
+
+	m := &mngr{
+		lister: lister,
+		predicates: []namedPredicate{{"Test", func(_ context.Context, _ *cmapi.CertificateRequest, policies []policyapi.CertificateRequestPolicy) ([]policyapi.CertificateRequestPolicy, error) {
+			return policies, nil
+		}}},
+		evaluators: []approver.Evaluator{
+			fake.NewFakeEvaluator().WithEvaluate(func(ctx context.Context, policy *policyapi.CertificateRequestPolicy, _ *cmapi.CertificateRequest) (approver.EvaluationResponse, error) {
+				assert.True(t, trace.SpanContextFromContext(ctx).IsValid(), "expected the span context to be propagated to the evaluator")
+				if policy.Name == "test-policy-b" {
+					return approver.EvaluationResponse{Result: approver.ResultNotDenied}, nil
+				}
+				return approver.EvaluationResponse{Result: approver.ResultDenied, Message: "this is a denied response"}, nil
+			}),
+		},
+	}
+
+	response, err := m.Review(t.Context(), &cmapi.CertificateRequest{ObjectMeta: metav1.ObjectMeta{Namespace: "test-ns", Name: "test-req"}})
+	require.NoError(t, err)
+	assert.Equal(t, manager.ResultApproved, response.Result)
+
+	spans := exporter.GetSpans()
+	byName := make(map[string][]tracetest.SpanStub)
+	for _, span := range spans {
+		byName[span.Name] = append(byName[span.Name], span)
+	}
+
+	require.Len(t, byName["manager.Review"], 1)
+	review := byName["manager.Review"][0]
+	assert.Contains(t, review.Attributes, attribute.String("review.result", "approved"))
+	assert.Contains(t, review.Attributes, attribute.String("review.policy", "test-policy-b"))
+
+	require.Len(t, byName["predicate.Filter"], 1)
+	assert.Contains(t, byName["predicate.Filter"][0].Attributes, attribute.String("predicate.name", "Test"))
+	assert.Equal(t, review.SpanContext.SpanID(), byName["predicate.Filter"][0].Parent.SpanID())
+
+	require.Len(t, byName["policy.Evaluate"], 2)
+	results := make(map[string]string)
+	for _, span := range byName["policy.Evaluate"] {
+		assert.Equal(t, review.SpanContext.SpanID(), span.Parent.SpanID())
+		var name, result string
+		for _, attr := range span.Attributes {
+			switch attr.Key {
+			case "policy.name":
+				name = attr.Value.AsString()
+			case "policy.result":
+				result = attr.Value.AsString()
+			}
+		}
+		results[name] = result
+	}
+	assert.Equal(t, map[string]string{"test-policy-a": "denied", "test-policy-b": "approved"}, results)
+
+	require.Len(t, byName["evaluator.Evaluate"], 2)
+	assert.Contains(t, byName["evaluator.Evaluate"][0].Attributes, attribute.String("evaluator.name", "*fake.FakeEvaluator"))
+}
+
+// authorizerFunc is an Authorizer which calls the function.
+type authorizerFunc func(ctx context.Context, cr *cmapi.CertificateRequest, policy string) (bool, error)
+
+func (f authorizerFunc) Authorize(ctx context.Context, cr *cmapi.CertificateRequest, policy string) (bool, error) {
+	return f(ctx, cr, policy)
+}
+
+func Test_ReviewTracingEvaluateOnly(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tracing.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	t.Cleanup(func() { tracing.SetTracerProvider(noop.NewTracerProvider()) })
+
+	lister := fakeclient.NewClientBuilder().
+		WithScheme(policyapi.GlobalScheme).
+		WithRuntimeObjects(&policyapi.CertificateRequestPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-policy"},
+			Status: policyapi.CertificateRequestPolicyStatus{Conditions: []policyapi.CertificateRequestPolicyCondition{
+				{Type: policyapi.CertificateRequestPolicyConditionReady, Status: corev1.ConditionTrue},
+			}},
+		}).
+		Build()
+
+	var bound bool
+	m := NewEvaluateOnly(lister, authorizerFunc(func(context.Context, *cmapi.CertificateRequest, string) (bool, error) {
+		return bound, nil
+	}), []approver.Evaluator{
+		fake.NewFakeEvaluator().WithEvaluate(func(context.Context, *policyapi.CertificateRequestPolicy, *cmapi.CertificateRequest) (approver.EvaluationResponse, error) {
+			return approver.EvaluationResponse{Result: approver.ResultNotDenied}, nil
+		}),
+	})
+	cr := &cmapi.CertificateRequest{ObjectMeta: metav1.ObjectMeta{Namespace: "test-ns", Name: "test-req"}}
+
+	response, err := m.Review(t.Context(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, manager.ResultUnprocessed, response.Result, "expected policies the requester is not bound to to be ignored")
+
+	bound = true
+	response, err = m.Review(t.Context(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, manager.ResultApproved, response.Result)
+	assert.Empty(t, exporter.GetSpans(), "expected evaluate only reviews not to record spans")
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manualapproval
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver"
+)
+
+// Evaluate evaluates whether the given CertificateRequest has been manually
+// approved by enough permitted users. Requests which have not are pending,
+// until they expire and are denied. The names of the approving users are
+// returned in the message.
+func (m *manualApproval) Evaluate(ctx context.Context, policy *policyapi.CertificateRequestPolicy, request *cmapi.CertificateRequest) (approver.EvaluationResponse, error) {
+	plugin, ok := policy.Spec.Plugins[Name]
+	if !ok {
+		return approver.EvaluationResponse{Result: approver.ResultNotDenied}, nil
+	}
+
+	cfg, err := parseConfig(plugin.Values)
+	if err != nil {
+		return approver.EvaluationResponse{}, err
+	}
+
+	var approvals policyapi.CertificateRequestApprovalList
+	if err := m.lister.List(ctx, &approvals, client.InNamespace(request.Namespace)); err != nil {
+		return approver.EvaluationResponse{}, fmt.Errorf("failed to list CertificateRequestApprovals: %w", err)
+	}
+
+	approvers := sets.New[string]()
+	for _, approval := range approvals.Items {
+		spec := approval.Spec
+		if spec.CertificateRequestName != request.Name {
+			continue
+		}
+		if len(spec.CertificateRequestUID) > 0 && spec.CertificateRequestUID != request.UID {
+			continue
+		}
+		// Users cannot approve their own requests. The requester of a request
+		// created from a Certificate is cert-manager, so this does not prevent
+		// the author of the Certificate from approving.
+		if len(spec.Username) == 0 || spec.Username == request.Spec.Username {
+			continue
+		}
+		if !cfg.permits(spec.Username, spec.Groups) {
+			continue
+		}
+		approvers.Insert(spec.Username)
+	}
+
+	if approvers.Len() >= cfg.approvals {
+		return approver.EvaluationResponse{
+			Result:  approver.ResultNotDenied,
+			Message: fmt.Sprintf("manually approved by %s", strings.Join(sets.List(approvers), ", ")),
+		}, nil
+	}
+
+	var requeueAfter time.Duration
+	if cfg.expiry > 0 {
+		requeueAfter = request.CreationTimestamp.Add(cfg.expiry).Sub(m.clock.Now())
+		if requeueAfter <= 0 {
+			return approver.EvaluationResponse{
+				Result:  approver.ResultDenied,
+				Message: fmt.Sprintf("manual approval expired after %s with %d of %d required approvals", cfg.expiry, approvers.Len(), cfg.approvals),
+			}, nil
+		}
+	}
+
+	return approver.EvaluationResponse{
+		Result:       approver.ResultNotDenied,
+		Pending:      true,
+		RequeueAfter: requeueAfter,
+		Message:      fmt.Sprintf("waiting for manual approval with %d of %d required approvals", approvers.Len(), cfg.approvals),
+	}, nil
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manualapproval
+
+import (
+	"testing"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakeclock "k8s.io/utils/clock/testing"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver"
+)
+
+func Test_Evaluate(t *testing.T) {
+	const (
+		namespace   = "test-namespace"
+		requestName = "test-request"
+		requestUID  = "test-uid"
+	)
+
+	var (
+		fixedTime = time.Date(2021, 01, 01, 01, 0, 0, 0, time.UTC)
+
+		request = gen.CertificateRequest(requestName,
+			gen.SetCertificateRequestNamespace(namespace),
+			func(cr *cmapi.CertificateRequest) {
+				cr.UID = requestUID
+				cr.Spec.Username = "requester"
+				cr.CreationTimestamp = metav1.NewTime(fixedTime.Add(-time.Hour))
+			},
+		)
+
+		approval = func(name, username string, groups ...string) *policyapi.CertificateRequestApproval {
+			return &policyapi.CertificateRequestApproval{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+				Spec: policyapi.CertificateRequestApprovalSpec{
+					CertificateRequestName: requestName,
+					Username:               username,
+					Groups:                 groups,
+				},
+			}
+		}
+
+		withValues = func(values map[string]string) policyapi.CertificateRequestPolicySpec {
+			return policyapi.CertificateRequestPolicySpec{
+				Plugins: map[string]policyapi.CertificateRequestPolicyPluginData{
+					Name: {Values: values},
+				},
+			}
+		}
+	)
+
+	tests := map[string]struct {
+		existingObjects []runtime.Object
+		policy          policyapi.CertificateRequestPolicySpec
+		expResponse     approver.EvaluationResponse
+		expErr          bool
+	}{
+		"if the policy doesn't use the manual-approval plugin, return NotDenied": {
+			policy:      policyapi.CertificateRequestPolicySpec{},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if no approvals exist, return pending": {
+			policy: withValues(map[string]string{}),
+			expResponse: approver.EvaluationResponse{
+				Result:  approver.ResultNotDenied,
+				Pending: true,
+				Message: "waiting for manual approval with 0 of 1 required approvals",
+			},
+		},
+		"if an approval exists, return NotDenied with the approver": {
+			existingObjects: []runtime.Object{approval("a", "alice")},
+			policy:          withValues(map[string]string{"approvers": "alice"}),
+			expResponse: approver.EvaluationResponse{
+				Result:  approver.ResultNotDenied,
+				Message: "manually approved by alice",
+			},
+		},
+		"if neither approvers nor approverGroups are defined, ignore approvals": {
+			existingObjects: []runtime.Object{approval("a", "alice")},
+			policy:          withValues(map[string]string{}),
+			expResponse: approver.EvaluationResponse{
+				Result:  approver.ResultNotDenied,
+				Pending: true,
+				Message: "waiting for manual approval with 0 of 1 required approvals",
+			},
+		},
+		"if the requester approved their own request, ignore the approval": {
+			existingObjects: []runtime.Object{approval("a", "requester")},
+			policy:          withValues(map[string]string{"approvers": "requester"}),
+			expResponse: approver.EvaluationResponse{
+				Result:  approver.ResultNotDenied,
+				Pending: true,
+				Message: "waiting for manual approval with 0 of 1 required approvals",
+			},
+		},
+		"if approvals are for another request, namespace or UID, ignore them": {
+			existingObjects: []runtime.Object{
+				func() runtime.Object {
+					a := approval("a", "alice")
+					a.Spec.CertificateRequestName = "other-request"
+					return a
+				}(),
+				func() runtime.Object {
+					a := approval("b", "bob")
+					a.Namespace = "other-namespace"
+					return a
+				}(),
+				func() runtime.Object {
+					a := approval("c", "carol")
+					a.Spec.CertificateRequestUID = "other-uid"
+					return a
+				}(),
+			},
+			policy: withValues(map[string]string{"approvers": "alice,bob,carol"}),
+			expResponse: approver.EvaluationResponse{
+				Result:  approver.ResultNotDenied,
+				Pending: true,
+				Message: "waiting for manual approval with 0 of 1 required approvals",
+			},
+		},
+		"if not enough distinct permitted users approved, return pending until expiry": {
+			existingObjects: []runtime.Object{
+				approval("a", "alice"),
+				approval("b", "alice"),
+				approval("c", "mallory"),
+			},
+			policy: withValues(map[string]string{"approvals": "2", "approvers": "alice, bob", "expiry": "3h"}),
+			expResponse: approver.EvaluationResponse{
+				Result:       approver.ResultNotDenied,
+				Pending:      true,
+				RequeueAfter: 2 * time.Hour,
+				Message:      "waiting for manual approval with 1 of 2 required approvals",
+			},
+		},
+		"if enough permitted users and group members approved, return NotDenied with the approvers": {
+			existingObjects: []runtime.Object{
+				approval("a", "bob"),
+				approval("b", "carol", "security"),
+				approval("c", "mallory", "developers"),
+			},
+			policy: withValues(map[string]string{"approvals": "2", "approvers": "alice,bob", "approverGroups": "security"}),
+			expResponse: approver.EvaluationResponse{
+				Result:  approver.ResultNotDenied,
+				Message: "manually approved by bob, carol",
+			},
+		},
+		"if the request has expired, return Denied": {
+			existingObjects: []runtime.Object{approval("a", "alice", "security")},
+			policy:          withValues(map[string]string{"approvals": "2", "approverGroups": "security", "expiry": "30m"}),
+			expResponse: approver.EvaluationResponse{
+				Result:  approver.ResultDenied,
+				Message: "manual approval expired after 30m0s with 1 of 2 required approvals",
+			},
+		},
+		"if the plugin values are invalid, return error": {
+			policy: withValues(map[string]string{"approvals": "two"}),
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := &manualApproval{
+				clock: fakeclock.NewFakeClock(fixedTime),
+				lister: fakeclient.NewClientBuilder().
+					WithScheme(policyapi.GlobalScheme).
+					WithRuntimeObjects(test.existingObjects...).
+					Build(),
+			}
+
+			policy := &policyapi.CertificateRequestPolicy{Spec: test.policy}
+			response, err := m.Evaluate(t.Context(), policy, request)
+			assert.Equal(t, test.expErr, err != nil, "%v", err)
+			assert.Equal(t, test.expResponse, response, "unexpected evaluation response")
+		})
+	}
+}
@@ -0,0 +1,223 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manualapproval
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/pflag"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver"
+	"github.com/cert-manager/approver-policy/pkg/registry"
+)
+
+// Load the manual-approval approver.
+func init() {
+	registry.Shared.Store(Approver())
+}
+
+const (
+	// Name is the name of the manual-approval approver, and the key
+	// CertificateRequestPolicies use to configure it under `spec.plugins`.
+	Name = "manual-approval"
+
+	// valueApprovals is the number of distinct users that must approve.
+	valueApprovals = "approvals"
+
+	// valueApprovers is a comma separated list of usernames permitted to
+	// approve.
+	valueApprovers = "approvers"
+
+	// valueApproverGroups is a comma separated list of groups whose members are
+	// permitted to approve.
+	valueApproverGroups = "approverGroups"
+
+	// valueExpiry is the duration after the creation of a request after which
+	// it is denied if not yet approved.
+	valueExpiry = "expiry"
+)
+
+// Approver returns an instance on the manual-approval approver.
+func Approver() approver.Interface {
+	return &manualApproval{
+		clock: clock.RealClock{},
+	}
+}
+
+// manualApproval is an approver-policy Approver that requires
+// CertificateRequests to be approved by one or more users before a policy
+// approves them. Users approve a CertificateRequest by creating a
+// CertificateRequestApproval in the namespace of the CertificateRequest.
+// Requests that are not approved in time may be denied.
+type manualApproval struct {
+	log logr.Logger
+
+	// clock returns time which can be overwritten for testing.
+	clock clock.Clock
+
+	// lister is used to list CertificateRequestApprovals.
+	lister client.Reader
+}
+
+// config is the parsed plugin values of a CertificateRequestPolicy.
+type config struct {
+	// approvals is the number of distinct users that must approve.
+	approvals int
+
+	// approvers are the usernames permitted to approve.
+	approvers []string
+
+	// approverGroups are the groups whose members are permitted to approve.
+	approverGroups []string
+
+	// expiry is the duration after the creation of a request after which it
+	// is denied. Zero means requests never expire.
+	expiry time.Duration
+}
+
+// Name of Approver is "manual-approval".
+func (m *manualApproval) Name() string {
+	return Name
+}
+
+// RegisterFlags is a no-op, manual-approval doesn't need any flags.
+func (m *manualApproval) RegisterFlags(_ *pflag.FlagSet) {}
+
+// Prepare registers the webhook which records the user creating
+// CertificateRequestApprovals.
+func (m *manualApproval) Prepare(_ context.Context, log logr.Logger, mgr manager.Manager) error {
+	m.log = log.WithName(Name)
+	m.lister = mgr.GetCache()
+
+	webhook := &approvalWebhook{log: m.log.WithName("webhook")}
+	if err := builder.WebhookManagedBy(mgr).
+		For(&policyapi.CertificateRequestApproval{}).
+		WithDefaulter(webhook).
+		WithValidator(webhook).
+		Complete(); err != nil {
+		return fmt.Errorf("error registering CertificateRequestApproval webhook: %w", err)
+	}
+
+	return nil
+}
+
+// Ready always returns ready, manual-approval doesn't have any dependencies to
+// block readiness.
+func (m *manualApproval) Ready(_ context.Context, _ *policyapi.CertificateRequestPolicy) (approver.ReconcilerReadyResponse, error) {
+	return approver.ReconcilerReadyResponse{Ready: true}, nil
+}
+
+// manual-approval never needs to manually enqueue policies.
+func (m *manualApproval) EnqueueChan() <-chan string {
+	return nil
+}
+
+// PluginSchema declares the values accepted by the manual-approval plugin.
+func (m *manualApproval) PluginSchema() approver.PluginSchema {
+	return approver.PluginSchema{
+		Description: "Requires requests to be approved by users creating CertificateRequestApprovals before the policy approves them.",
+		Values: []approver.PluginValueSchema{
+			{
+				Key:         valueApprovals,
+				Type:        approver.PluginValueTypeInt,
+				Description: "Number of distinct users that must approve a request. The user that created the request cannot approve it. For requests created by cert-manager from a Certificate the requester is cert-manager, so the author of the Certificate is not prevented from approving.",
+				Default:     "1",
+			},
+			{
+				Key:         valueApprovers,
+				Type:        approver.PluginValueTypeString,
+				Description: "Comma separated list of usernames permitted to approve. At least one of approvers or approverGroups must be defined.",
+			},
+			{
+				Key:         valueApproverGroups,
+				Type:        approver.PluginValueTypeString,
+				Description: "Comma separated list of groups whose members are permitted to approve. At least one of approvers or approverGroups must be defined.",
+			},
+			{
+				Key:         valueExpiry,
+				Type:        approver.PluginValueTypeDuration,
+				Description: "Duration after the creation of a request after which it is denied if it has not been approved. Requests never expire if not defined.",
+			},
+		},
+	}
+}
+
+// parseConfig parses the plugin values of a CertificateRequestPolicy.
+func parseConfig(values map[string]string) (config, error) {
+	cfg := config{
+		approvals:      1,
+		approvers:      splitList(values[valueApprovers]),
+		approverGroups: splitList(values[valueApproverGroups]),
+	}
+
+	if v, ok := values[valueApprovals]; ok {
+		approvals, err := strconv.Atoi(v)
+		if err != nil {
+			return config{}, fmt.Errorf("failed to parse %s: %w", valueApprovals, err)
+		}
+		cfg.approvals = approvals
+	}
+
+	if v, ok := values[valueExpiry]; ok {
+		expiry, err := time.ParseDuration(v)
+		if err != nil {
+			return config{}, fmt.Errorf("failed to parse %s: %w", valueExpiry, err)
+		}
+		cfg.expiry = expiry
+	}
+
+	return cfg, nil
+}
+
+// permits returns true if the given user is permitted to approve. No user is
+// permitted if neither approvers nor approverGroups are defined.
+func (c config) permits(username string, groups []string) bool {
+	for _, approver := range c.approvers {
+		if approver == username {
+			return true
+		}
+	}
+	for _, approverGroup := range c.approverGroups {
+		for _, group := range groups {
+			if approverGroup == group {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// splitList splits a comma separated list, ignoring empty entries.
+func splitList(s string) []string {
+	var list []string
+	for _, entry := range strings.Split(s, ",") {
+		if entry = strings.TrimSpace(entry); len(entry) > 0 {
+			list = append(list, entry)
+		}
+	}
+	return list
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manualapproval
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver"
+)
+
+// Validate validates that the manual-approval plugin values of the processed
+// CertificateRequestPolicy can be satisfied.
+func (m *manualApproval) Validate(_ context.Context, policy *policyapi.CertificateRequestPolicy) (approver.WebhookValidationResponse, error) {
+	plugin, ok := policy.Spec.Plugins[Name]
+	if !ok {
+		return approver.WebhookValidationResponse{
+			Allowed: true,
+			Errors:  nil,
+		}, nil
+	}
+
+	var (
+		el      field.ErrorList
+		fldPath = field.NewPath("spec", "plugins", Name, "values")
+	)
+
+	cfg, err := parseConfig(plugin.Values)
+	if err != nil {
+		// Type errors are reported by the plugin schema.
+		return approver.WebhookValidationResponse{Allowed: true}, nil
+	}
+
+	if len(cfg.approvers) == 0 && len(cfg.approverGroups) == 0 {
+		el = append(el, field.Required(fldPath.Key(valueApprovers), "at least one of approvers or approverGroups must be defined"))
+	}
+	if cfg.approvals < 1 {
+		el = append(el, field.Invalid(fldPath.Key(valueApprovals), plugin.Values[valueApprovals], "must be 1 or greater"))
+	}
+	if len(cfg.approverGroups) == 0 && len(cfg.approvers) > 0 && len(cfg.approvers) < cfg.approvals {
+		el = append(el, field.Invalid(fldPath.Key(valueApprovers), plugin.Values[valueApprovers],
+			fmt.Sprintf("must list at least as many approvers as the %d required approvals", cfg.approvals)))
+	}
+	if _, ok := plugin.Values[valueExpiry]; ok && cfg.expiry <= 0 {
+		el = append(el, field.Invalid(fldPath.Key(valueExpiry), plugin.Values[valueExpiry], "must be greater than 0"))
+	}
+
+	return approver.WebhookValidationResponse{
+		Allowed: len(el) == 0,
+		Errors:  el,
+	}, nil
+}
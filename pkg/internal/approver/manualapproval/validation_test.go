@@ -0,0 +1,69 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manualapproval
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver"
+)
+
+func Test_Validate(t *testing.T) {
+	fldPath := field.NewPath("spec", "plugins", Name, "values")
+
+	tests := map[string]struct {
+		values      map[string]string
+		expResponse approver.WebhookValidationResponse
+	}{
+		"if approvers are defined, return allowed": {
+			values:      map[string]string{"approvers": "alice"},
+			expResponse: approver.WebhookValidationResponse{Allowed: true},
+		},
+		"if approverGroups are defined, return allowed": {
+			values:      map[string]string{"approvals": "2", "approverGroups": "security"},
+			expResponse: approver.WebhookValidationResponse{Allowed: true},
+		},
+		"if neither approvers nor approverGroups are defined, return error": {
+			values: map[string]string{"approvals": "1"},
+			expResponse: approver.WebhookValidationResponse{Allowed: false, Errors: field.ErrorList{
+				field.Required(fldPath.Key("approvers"), "at least one of approvers or approverGroups must be defined"),
+			}},
+		},
+		"if fewer approvers than approvals are defined, return error": {
+			values: map[string]string{"approvals": "2", "approvers": "alice"},
+			expResponse: approver.WebhookValidationResponse{Allowed: false, Errors: field.ErrorList{
+				field.Invalid(fldPath.Key("approvers"), "alice", "must list at least as many approvers as the 2 required approvals"),
+			}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			policy := &policyapi.CertificateRequestPolicy{Spec: policyapi.CertificateRequestPolicySpec{
+				Plugins: map[string]policyapi.CertificateRequestPolicyPluginData{Name: {Values: test.values}},
+			}}
+			response, err := new(manualApproval).Validate(t.Context(), policy)
+			require.NoError(t, err)
+			assert.Equal(t, test.expResponse, response)
+		})
+	}
+}
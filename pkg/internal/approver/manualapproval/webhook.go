@@ -0,0 +1,129 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manualapproval
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	admissionv1 "k8s.io/api/admission/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+)
+
+// approvalWebhook records and enforces that the user of a
+// CertificateRequestApproval is the user that created it, and that
+// CertificateRequestApprovals are immutable.
+type approvalWebhook struct {
+	log logr.Logger
+}
+
+var (
+	_ admission.CustomDefaulter = &approvalWebhook{}
+	_ admission.CustomValidator = &approvalWebhook{}
+)
+
+// Default sets the username and groups of a CertificateRequestApproval being
+// created to those of the creating user, if not already set.
+func (a *approvalWebhook) Default(ctx context.Context, obj runtime.Object) error {
+	approval, ok := obj.(*policyapi.CertificateRequestApproval)
+	if !ok {
+		return fmt.Errorf("expected a CertificateRequestApproval, but got a %T", obj)
+	}
+
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Only record the user on creation. Updates are rejected by validation if
+	// they change the spec.
+	if req.Operation != admissionv1.Create {
+		return nil
+	}
+
+	if len(approval.Spec.Username) == 0 {
+		approval.Spec.Username = req.UserInfo.Username
+	}
+	if approval.Spec.Groups == nil {
+		approval.Spec.Groups = req.UserInfo.Groups
+	}
+
+	return nil
+}
+
+// ValidateCreate validates that the username and groups of the
+// CertificateRequestApproval are those of the creating user.
+func (a *approvalWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	approval, ok := obj.(*policyapi.CertificateRequestApproval)
+	if !ok {
+		return nil, fmt.Errorf("expected a CertificateRequestApproval, but got a %T", obj)
+	}
+
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		el      field.ErrorList
+		fldPath = field.NewPath("spec")
+	)
+
+	if approval.Spec.Username != req.UserInfo.Username {
+		el = append(el, field.Invalid(fldPath.Child("username"), approval.Spec.Username, "must be the user creating the CertificateRequestApproval"))
+	}
+	if !sets.New(approval.Spec.Groups...).Equal(sets.New(req.UserInfo.Groups...)) {
+		el = append(el, field.Invalid(fldPath.Child("groups"), approval.Spec.Groups, "must be the groups of the user creating the CertificateRequestApproval"))
+	}
+
+	if len(el) > 0 {
+		a.log.V(2).Info("rejecting CertificateRequestApproval", "namespace", approval.Namespace, "name", approval.Name, "user", req.UserInfo.Username)
+	}
+
+	return nil, el.ToAggregate()
+}
+
+// ValidateUpdate validates that the spec of the CertificateRequestApproval
+// has not changed.
+func (a *approvalWebhook) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldApproval, ok := oldObj.(*policyapi.CertificateRequestApproval)
+	if !ok {
+		return nil, fmt.Errorf("expected a CertificateRequestApproval, but got a %T", oldObj)
+	}
+	newApproval, ok := newObj.(*policyapi.CertificateRequestApproval)
+	if !ok {
+		return nil, fmt.Errorf("expected a CertificateRequestApproval, but got a %T", newObj)
+	}
+
+	if !apiequality.Semantic.DeepEqual(oldApproval.Spec, newApproval.Spec) {
+		return nil, field.ErrorList{field.Forbidden(field.NewPath("spec"), "CertificateRequestApproval spec is immutable")}.ToAggregate()
+	}
+
+	return nil, nil
+}
+
+// ValidateDelete always allows deletes.
+func (a *approvalWebhook) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
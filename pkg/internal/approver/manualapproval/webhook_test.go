@@ -0,0 +1,112 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manualapproval
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/klog/v2/ktesting"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+)
+
+func Test_approvalWebhook(t *testing.T) {
+	userInfo := authenticationv1.UserInfo{Username: "alice", Groups: []string{"security", "system:authenticated"}}
+
+	tests := map[string]struct {
+		operation admissionv1.Operation
+		old       *policyapi.CertificateRequestApproval
+		approval  *policyapi.CertificateRequestApproval
+
+		expSpec  policyapi.CertificateRequestApprovalSpec
+		expError string
+	}{
+		"on create, record the user creating the approval": {
+			operation: admissionv1.Create,
+			approval: &policyapi.CertificateRequestApproval{
+				Spec: policyapi.CertificateRequestApprovalSpec{CertificateRequestName: "test"},
+			},
+			expSpec: policyapi.CertificateRequestApprovalSpec{
+				CertificateRequestName: "test",
+				Username:               "alice",
+				Groups:                 []string{"security", "system:authenticated"},
+			},
+		},
+		"on create, reject an approval impersonating another user": {
+			operation: admissionv1.Create,
+			approval: &policyapi.CertificateRequestApproval{
+				Spec: policyapi.CertificateRequestApprovalSpec{CertificateRequestName: "test", Username: "bob", Groups: []string{"admins"}},
+			},
+			expSpec: policyapi.CertificateRequestApprovalSpec{
+				CertificateRequestName: "test",
+				Username:               "bob",
+				Groups:                 []string{"admins"},
+			},
+			expError: `[spec.username: Invalid value: "bob": must be the user creating the CertificateRequestApproval, spec.groups: Invalid value: []string{"admins"}: must be the groups of the user creating the CertificateRequestApproval]`,
+		},
+		"on update, reject changes to the spec": {
+			operation: admissionv1.Update,
+			old: &policyapi.CertificateRequestApproval{
+				Spec: policyapi.CertificateRequestApprovalSpec{CertificateRequestName: "test", Username: "bob"},
+			},
+			approval: &policyapi.CertificateRequestApproval{
+				Spec: policyapi.CertificateRequestApprovalSpec{CertificateRequestName: "test", Username: "alice"},
+			},
+			expSpec:  policyapi.CertificateRequestApprovalSpec{CertificateRequestName: "test", Username: "alice"},
+			expError: "spec: Forbidden: CertificateRequestApproval spec is immutable",
+		},
+		"on update, allow an unchanged spec": {
+			operation: admissionv1.Update,
+			old: &policyapi.CertificateRequestApproval{
+				Spec: policyapi.CertificateRequestApprovalSpec{CertificateRequestName: "test", Username: "bob"},
+			},
+			approval: &policyapi.CertificateRequestApproval{
+				Spec: policyapi.CertificateRequestApprovalSpec{CertificateRequestName: "test", Username: "bob"},
+			},
+			expSpec: policyapi.CertificateRequestApprovalSpec{CertificateRequestName: "test", Username: "bob"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			a := &approvalWebhook{log: ktesting.NewLogger(t, ktesting.DefaultConfig)}
+			ctx := admission.NewContextWithRequest(t.Context(), admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{Operation: test.operation, UserInfo: userInfo},
+			})
+
+			assert.NoError(t, a.Default(ctx, test.approval))
+
+			var err error
+			if test.operation == admissionv1.Create {
+				_, err = a.ValidateCreate(ctx, test.approval)
+			} else {
+				_, err = a.ValidateUpdate(ctx, test.old, test.approval)
+			}
+
+			var gotError string
+			if err != nil {
+				gotError = err.Error()
+			}
+			assert.Equal(t, test.expError, gotError)
+			assert.Equal(t, test.expSpec, test.approval.Spec)
+		})
+	}
+}
@@ -23,6 +23,8 @@ import (
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/google/cel-go/cel"
+	celast "github.com/google/cel-go/common/ast"
+	"github.com/google/cel-go/common/types"
 	"github.com/google/cel-go/ext"
 )
 
@@ -56,14 +58,7 @@ func (v *validator) compile() error {
 		return nil
 	}
 
-	env, err := cel.NewEnv(
-		cel.Types(&CertificateRequest{}),
-		cel.Variable(varSelf, cel.StringType),
-		cel.Variable(varRequest, cel.ObjectType("cm.io.policy.pkg.internal.approver.validation.CertificateRequest")),
-		ext.Strings(),
-		ServiceAccountLib(),
-	)
-
+	env, err := newEnv()
 	if err != nil {
 		return err
 	}
@@ -102,3 +97,43 @@ func (v *validator) Validate(value string, request cmapi.CertificateRequest) (bo
 
 	return out.Value().(bool), nil
 }
+
+// AlwaysTrue returns true if the CEL expression evaluates to true regardless
+// of the value and request it is evaluated against, i.e. the expression folds
+// to the constant `true`. Expressions which are not constant are not
+// considered, even if they are true for every input.
+func AlwaysTrue(expression string) (bool, error) {
+	env, err := newEnv()
+	if err != nil {
+		return false, err
+	}
+
+	ast, iss := env.Compile(expression)
+	if iss.Err() != nil {
+		return false, iss.Err()
+	}
+
+	folder, err := cel.NewConstantFoldingOptimizer()
+	if err != nil {
+		return false, err
+	}
+	ast, iss = cel.NewStaticOptimizer(folder).Optimize(env, ast)
+	if iss.Err() != nil {
+		return false, iss.Err()
+	}
+
+	expr := ast.NativeRep().Expr()
+	return expr.Kind() == celast.LiteralKind && expr.AsLiteral() == types.True, nil
+}
+
+// newEnv returns the CEL environment that validation expressions are compiled
+// in.
+func newEnv() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Types(&CertificateRequest{}),
+		cel.Variable(varSelf, cel.StringType),
+		cel.Variable(varRequest, cel.ObjectType("cm.io.policy.pkg.internal.approver.validation.CertificateRequest")),
+		ext.Strings(),
+		ServiceAccountLib(),
+	)
+}
@@ -140,3 +140,33 @@ func newCertificateRequestWithUsername(username string) cmapi.CertificateRequest
 	}
 	return request
 }
+
+func Test_AlwaysTrue(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		want    bool
+		wantErr bool
+	}{
+		{name: "literal-true", expr: "true", want: true},
+		{name: "constant-comparison", expr: "1 == 1", want: true},
+		{name: "constant-string-function", expr: "'www.example.com'.endsWith('.com')", want: true},
+		{name: "true-or-anything", expr: "true || self.startsWith('foo')", want: true},
+		{name: "literal-false", expr: "false", want: false},
+		{name: "uses-value", expr: "self.endsWith('.com')", want: false},
+		{name: "uses-request", expr: "size(cr.namespace) < 24", want: false},
+		{name: "err-undeclared-vars", expr: "foo == bar", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AlwaysTrue(tt.expr)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
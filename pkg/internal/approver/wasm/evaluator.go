@@ -0,0 +1,139 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wasm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/tetratelabs/wazero"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver"
+)
+
+const (
+	// exportAllocate is the function a module must export to allocate a
+	// buffer of the given length in its memory, returning a pointer to it.
+	//   allocate(len i32) i32
+	exportAllocate = "allocate"
+
+	// exportEvaluate is the function a module must export to evaluate the
+	// JSON encoded input at the given pointer and length. The pointer to the
+	// JSON encoded output is returned in the high 32 bits of the result, and
+	// its length in the low 32 bits.
+	//   evaluate(ptr i32, len i32) i64
+	exportEvaluate = "evaluate"
+)
+
+// evaluationInput is the JSON encoded input passed to a module.
+type evaluationInput struct {
+	Policy  *policyapi.CertificateRequestPolicy `json:"policy"`
+	Request *cmapi.CertificateRequest           `json:"request"`
+}
+
+// evaluationOutput is the JSON encoded output returned by a module.
+type evaluationOutput struct {
+	Denied  bool   `json:"denied"`
+	Message string `json:"message,omitempty"`
+}
+
+// Evaluate evaluates whether the given CertificateRequest should be denied by
+// running the WebAssembly module referenced by the CertificateRequestPolicy.
+// Requests are not denied by policies which don't use the wasm plugin. Module
+// traps, timeouts and malformed output are returned as errors.
+func (w *wasm) Evaluate(ctx context.Context, policy *policyapi.CertificateRequestPolicy, request *cmapi.CertificateRequest) (approver.EvaluationResponse, error) {
+	plugin, ok := policy.Spec.Plugins[Name]
+	if !ok {
+		return approver.EvaluationResponse{Result: approver.ResultNotDenied}, nil
+	}
+
+	src := sourceFromValues(plugin.Values)
+	compiled, err := w.compile(ctx, src)
+	if err != nil {
+		return approver.EvaluationResponse{}, err
+	}
+	defer w.release(ctx, src, compiled)
+
+	input, err := json.Marshal(evaluationInput{Policy: policy, Request: request})
+	if err != nil {
+		return approver.EvaluationResponse{}, fmt.Errorf("failed to encode module input: %w", err)
+	}
+
+	rawOutput, err := w.run(ctx, compiled.module, input)
+	if err != nil {
+		return approver.EvaluationResponse{}, err
+	}
+
+	var output evaluationOutput
+	if err := json.Unmarshal(rawOutput, &output); err != nil {
+		return approver.EvaluationResponse{}, fmt.Errorf("failed to decode module output: %w", err)
+	}
+
+	if output.Denied {
+		return approver.EvaluationResponse{Result: approver.ResultDenied, Message: output.Message}, nil
+	}
+
+	return approver.EvaluationResponse{Result: approver.ResultNotDenied, Message: output.Message}, nil
+}
+
+// run instantiates a fresh instance of the compiled module, writes the input
+// to its memory and calls its evaluate function, returning the output. Each
+// evaluation gets its own instance so no state is shared between requests.
+func (w *wasm) run(ctx context.Context, module wazero.CompiledModule, input []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, w.timeout)
+	defer cancel()
+
+	// Anonymous instances can be instantiated concurrently. Modules built as
+	// WASI reactors are initialised with _initialize, if exported.
+	instance, err := w.runtime.InstantiateModule(ctx, module, wazero.NewModuleConfig().
+		WithName("").
+		WithStartFunctions("_initialize"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to instantiate module: %w", err)
+	}
+	defer instance.Close(context.Background())
+
+	results, err := instance.ExportedFunction(exportAllocate).Call(ctx, uint64(len(input)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to allocate module input: %w", err)
+	}
+	ptr := uint32(results[0])
+
+	memory := instance.Memory()
+	if !memory.Write(ptr, input) {
+		return nil, fmt.Errorf("module allocated input out of memory range: ptr=%d len=%d", ptr, len(input))
+	}
+
+	results, err = instance.ExportedFunction(exportEvaluate).Call(ctx, uint64(ptr), uint64(len(input)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate module: %w", err)
+	}
+	outPtr, outLen := uint32(results[0]>>32), uint32(results[0])
+
+	output, ok := memory.Read(outPtr, outLen)
+	if !ok {
+		return nil, fmt.Errorf("module returned output out of memory range: ptr=%d len=%d", outPtr, outLen)
+	}
+
+	// Read returns a view of the instance's memory, which is released once
+	// the instance is closed.
+	return append([]byte(nil), output...), nil
+}
@@ -0,0 +1,321 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wasm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver"
+)
+
+func Test_Evaluate(t *testing.T) {
+	const configMapNamespace = "cert-manager"
+
+	modules := map[string][]byte{
+		"approve.wasm":      outputModule(`{"denied":false,"message":"looks good"}`),
+		"deny.wasm":         outputModule(`{"denied":true,"message":"common name is not allowed"}`),
+		"bad-output.wasm":   outputModule(`not json`),
+		"out-of-range.wasm": buildModule(1, nil, []byte{0x42, 0xff, 0xff, 0xff, 0xff, 0x0f}),
+		"trap.wasm":         buildModule(1, nil, []byte{0x00}),
+		"loop.wasm":         buildModule(1, nil, []byte{0x03, 0x40, 0x0c, 0x00, 0x0b, 0x42, 0x00}),
+		"big-memory.wasm":   buildModule(17, nil, []byte{0x42, 0x00}),
+		"invalid.wasm":      []byte("not a module"),
+	}
+
+	moduleDir := t.TempDir()
+	for name, data := range modules {
+		require.NoError(t, os.WriteFile(filepath.Join(moduleDir, name), data, 0600))
+	}
+
+	configMaps := fakeclient.NewClientBuilder().
+		WithScheme(policyapi.GlobalScheme).
+		WithObjects(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: configMapNamespace, Name: "modules"},
+			BinaryData: map[string][]byte{
+				defaultConfigMapKey: modules["deny.wasm"],
+				"approve":           modules["approve.wasm"],
+			},
+		}).
+		Build()
+
+	w := &wasm{
+		moduleDir:          moduleDir,
+		configMapNamespace: configMapNamespace,
+		configMaps:         configMaps,
+		memoryLimitMiB:     1,
+		timeout:            time.Second,
+	}
+	require.NoError(t, w.startRuntime(t.Context()))
+
+	withValues := func(values map[string]string) policyapi.CertificateRequestPolicySpec {
+		return policyapi.CertificateRequestPolicySpec{
+			Plugins: map[string]policyapi.CertificateRequestPolicyPluginData{
+				Name: {Values: values},
+			},
+		}
+	}
+
+	tests := map[string]struct {
+		policy      policyapi.CertificateRequestPolicySpec
+		expResponse approver.EvaluationResponse
+		expErr      bool
+	}{
+		"if the policy doesn't use the wasm plugin, return NotDenied": {
+			policy:      policyapi.CertificateRequestPolicySpec{},
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied},
+		},
+		"if the module approves, return NotDenied with message": {
+			policy:      withValues(map[string]string{valueModule: "approve.wasm"}),
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied, Message: "looks good"},
+		},
+		"if the module denies, return Denied with message": {
+			policy:      withValues(map[string]string{valueModule: "deny.wasm"}),
+			expResponse: approver.EvaluationResponse{Result: approver.ResultDenied, Message: "common name is not allowed"},
+		},
+		"if the module is loaded from a ConfigMap default key, return its response": {
+			policy:      withValues(map[string]string{valueConfigMap: "modules"}),
+			expResponse: approver.EvaluationResponse{Result: approver.ResultDenied, Message: "common name is not allowed"},
+		},
+		"if the module is loaded from a ConfigMap key, return its response": {
+			policy:      withValues(map[string]string{valueConfigMap: "modules", valueKey: "approve"}),
+			expResponse: approver.EvaluationResponse{Result: approver.ResultNotDenied, Message: "looks good"},
+		},
+		"if the ConfigMap key doesn't exist, return error": {
+			policy: withValues(map[string]string{valueConfigMap: "modules", valueKey: "missing"}),
+			expErr: true,
+		},
+		"if the ConfigMap doesn't exist, return error": {
+			policy: withValues(map[string]string{valueConfigMap: "missing"}),
+			expErr: true,
+		},
+		"if the module file doesn't exist, return error": {
+			policy: withValues(map[string]string{valueModule: "missing.wasm"}),
+			expErr: true,
+		},
+		"if the module references a path outside the module directory, return error": {
+			policy: withValues(map[string]string{valueModule: "../approve.wasm"}),
+			expErr: true,
+		},
+		"if the module is invalid, return error": {
+			policy: withValues(map[string]string{valueModule: "invalid.wasm"}),
+			expErr: true,
+		},
+		"if the module returns output which isn't valid JSON, return error": {
+			policy: withValues(map[string]string{valueModule: "bad-output.wasm"}),
+			expErr: true,
+		},
+		"if the module returns output out of memory range, return error": {
+			policy: withValues(map[string]string{valueModule: "out-of-range.wasm"}),
+			expErr: true,
+		},
+		"if the module traps, return error": {
+			policy: withValues(map[string]string{valueModule: "trap.wasm"}),
+			expErr: true,
+		},
+		"if the module exceeds the evaluation timeout, return error": {
+			policy: withValues(map[string]string{valueModule: "loop.wasm"}),
+			expErr: true,
+		},
+		"if the module exceeds the memory limit, return error": {
+			policy: withValues(map[string]string{valueModule: "big-memory.wasm"}),
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			policy := &policyapi.CertificateRequestPolicy{Spec: test.policy}
+			request := gen.CertificateRequest("test", func(cr *cmapi.CertificateRequest) {
+				cr.Spec.Username = "user-1"
+			})
+
+			response, err := w.Evaluate(t.Context(), policy, request)
+			assert.Equal(t, test.expErr, err != nil, "%v", err)
+			assert.Equal(t, test.expResponse, response, "unexpected evaluation response")
+		})
+	}
+}
+
+func Test_compile(t *testing.T) {
+	const configMapNamespace = "cert-manager"
+
+	moduleDir := t.TempDir()
+	modulePath := filepath.Join(moduleDir, "module.wasm")
+	require.NoError(t, os.WriteFile(modulePath, outputModule(`{"denied":false}`), 0600))
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: configMapNamespace, Name: "modules"},
+		BinaryData: map[string][]byte{defaultConfigMapKey: outputModule(`{"denied":false}`)},
+	}
+	configMaps := fakeclient.NewClientBuilder().WithScheme(policyapi.GlobalScheme).WithObjects(cm).Build()
+
+	w := &wasm{
+		moduleDir:          moduleDir,
+		configMapNamespace: configMapNamespace,
+		configMaps:         configMaps,
+		memoryLimitMiB:     1,
+		timeout:            time.Second,
+	}
+	require.NoError(t, w.startRuntime(t.Context()))
+
+	ctx := t.Context()
+	fileSource := sourceFromValues(map[string]string{valueModule: "module.wasm"})
+	configMapSource := sourceFromValues(map[string]string{valueConfigMap: "modules"})
+
+	first, err := w.compile(ctx, fileSource)
+	require.NoError(t, err)
+	again, err := w.compile(ctx, fileSource)
+	require.NoError(t, err)
+	assert.Same(t, first, again, "expected an unchanged file not to be re-compiled")
+	w.release(ctx, fileSource, again)
+
+	require.NoError(t, os.WriteFile(modulePath, outputModule(`{"denied":true,"message":"changed"}`), 0600))
+	require.NoError(t, os.Chtimes(modulePath, time.Now(), time.Now().Add(time.Minute)))
+	again, err = w.compile(ctx, fileSource)
+	require.NoError(t, err)
+	assert.NotSame(t, first, again, "expected a changed file to be re-compiled")
+	w.release(ctx, fileSource, again)
+
+	// The replaced module is still referenced, so must remain usable until it
+	// is released.
+	assert.True(t, first.stale)
+	output, err := w.run(ctx, first.module, []byte("{}"))
+	require.NoError(t, err, "expected a replaced module to be usable until released")
+	assert.JSONEq(t, `{"denied":false}`, string(output))
+	w.release(ctx, fileSource, first)
+	assert.Zero(t, first.refs)
+
+	require.NoError(t, configMaps.Get(ctx, client.ObjectKeyFromObject(cm), cm))
+	w.observeConfigMap(cm.Name, cm.ResourceVersion, false)
+	first, err = w.compile(ctx, configMapSource)
+	require.NoError(t, err)
+	w.release(ctx, configMapSource, first)
+
+	cm.BinaryData[defaultConfigMapKey] = outputModule(`{"denied":true,"message":"changed"}`)
+	require.NoError(t, configMaps.Update(ctx, cm))
+	again, err = w.compile(ctx, configMapSource)
+	require.NoError(t, err)
+	assert.Same(t, first, again, "expected the ConfigMap not to be read until the watch observes a new version")
+	w.release(ctx, configMapSource, again)
+
+	w.observeConfigMap(cm.Name, cm.ResourceVersion, false)
+	again, err = w.compile(ctx, configMapSource)
+	require.NoError(t, err)
+	assert.NotSame(t, first, again, "expected a changed ConfigMap to be re-compiled")
+	w.release(ctx, configMapSource, again)
+}
+
+// outputModule returns a module whose evaluate function always returns the
+// given output, regardless of input.
+func outputModule(output string) []byte {
+	// i64.const (0 << 32 | len(output))
+	evaluate := append([]byte{0x42}, sleb128(int64(len(output)))...)
+	return buildModule(1, []byte(output), evaluate)
+}
+
+// buildModule assembles a minimal WebAssembly binary module exporting a
+// memory of the given number of pages, an allocate function returning a
+// fixed pointer, and an evaluate function with the given body instructions.
+// The data is written at the start of memory.
+func buildModule(memoryPages uint32, data []byte, evaluate []byte) []byte {
+	section := func(id byte, contents ...[]byte) []byte {
+		var body []byte
+		for _, c := range contents {
+			body = append(body, c...)
+		}
+		return append(append([]byte{id}, uleb128(uint64(len(body)))...), body...)
+	}
+	name := func(s string) []byte {
+		return append(uleb128(uint64(len(s))), s...)
+	}
+	function := func(instructions []byte) []byte {
+		// No locals, and terminated with end.
+		body := append(append([]byte{0x00}, instructions...), 0x0b)
+		return append(uleb128(uint64(len(body))), body...)
+	}
+
+	// allocate always returns a pointer to offset 1024.
+	allocate := append([]byte{0x41}, sleb128(1024)...)
+
+	module := []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+	module = append(module, section(0x01,
+		[]byte{0x02},
+		[]byte{0x60, 0x01, 0x7f, 0x01, 0x7f},       // (i32) -> i32
+		[]byte{0x60, 0x02, 0x7f, 0x7f, 0x01, 0x7e}, // (i32, i32) -> i64
+	)...)
+	module = append(module, section(0x03, []byte{0x02, 0x00, 0x01})...)
+	module = append(module, section(0x05, []byte{0x01, 0x00}, uleb128(uint64(memoryPages)))...)
+	module = append(module, section(0x07,
+		[]byte{0x03},
+		name("memory"), []byte{0x02, 0x00},
+		name(exportAllocate), []byte{0x00, 0x00},
+		name(exportEvaluate), []byte{0x00, 0x01},
+	)...)
+	module = append(module, section(0x0a,
+		[]byte{0x02},
+		function(allocate),
+		function(evaluate),
+	)...)
+	if len(data) > 0 {
+		module = append(module, section(0x0b,
+			[]byte{0x01, 0x00, 0x41, 0x00, 0x0b},
+			uleb128(uint64(len(data))), data,
+		)...)
+	}
+
+	return module
+}
+
+func uleb128(v uint64) []byte {
+	var out []byte
+	for {
+		b := byte(v & 0x7f)
+		v >>= 7
+		if v != 0 {
+			b |= 0x80
+		}
+		out = append(out, b)
+		if v == 0 {
+			return out
+		}
+	}
+}
+
+func sleb128(v int64) []byte {
+	var out []byte
+	for {
+		b := byte(v & 0x7f)
+		v >>= 7
+		if (v == 0 && b&0x40 == 0) || (v == -1 && b&0x40 != 0) {
+			return append(out, b)
+		}
+		out = append(out, b|0x80)
+	}
+}
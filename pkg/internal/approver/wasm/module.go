@@ -0,0 +1,266 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wasm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/tetratelabs/wazero"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// valueModule is the plugin value referencing a module file name in the
+	// local module directory.
+	valueModule = "module"
+
+	// valueConfigMap is the plugin value referencing the name of a ConfigMap
+	// containing a module.
+	valueConfigMap = "configMap"
+
+	// valueKey is the plugin value referencing the key of the module in the
+	// ConfigMap. Defaults to defaultConfigMapKey.
+	valueKey = "key"
+
+	// defaultConfigMapKey is the ConfigMap key used when no key is given.
+	defaultConfigMapKey = "module.wasm"
+)
+
+// source identifies where the module of a CertificateRequestPolicy is loaded
+// from. Only one of module or configMap is expected to be set.
+type source struct {
+	module    string
+	configMap string
+	key       string
+}
+
+// compiledModule is a compiled module along with the version of the source
+// it was compiled from. Compiled modules are reference counted, so that a
+// module replaced by a newer version is only closed once no evaluation is
+// instantiating it.
+type compiledModule struct {
+	// version is the resourceVersion of the ConfigMap, or the modification
+	// time and size of the file, the module was compiled from.
+	version string
+	module  wazero.CompiledModule
+
+	// refs is the number of callers of compile which have not yet released
+	// the module. Guarded by wasm.compiledLock.
+	refs int
+	// stale is true once the module has been replaced, after which it is
+	// closed when refs reaches zero. Guarded by wasm.compiledLock.
+	stale bool
+}
+
+// sourceFromValues returns the module source from the given plugin values.
+func sourceFromValues(values map[string]string) source {
+	src := source{
+		module:    values[valueModule],
+		configMap: values[valueConfigMap],
+		key:       values[valueKey],
+	}
+	if len(src.configMap) > 0 && len(src.key) == 0 {
+		src.key = defaultConfigMapKey
+	}
+	return src
+}
+
+func (s source) String() string {
+	if len(s.configMap) > 0 {
+		return fmt.Sprintf("configmap:%s/%s", s.configMap, s.key)
+	}
+	return fmt.Sprintf("file:%s", s.module)
+}
+
+// load returns the module bytes of the given source, and the version of the
+// source they were read from.
+func (w *wasm) load(ctx context.Context, src source) ([]byte, string, error) {
+	switch {
+	case len(src.module) > 0:
+		path, err := w.modulePath(src)
+		if err != nil {
+			return nil, "", err
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, "", err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, "", err
+		}
+		return data, fileVersion(info), nil
+
+	case len(src.configMap) > 0:
+		if w.configMaps == nil {
+			return nil, "", errors.New("loading modules from ConfigMaps is not enabled")
+		}
+		var cm corev1.ConfigMap
+		if err := w.configMaps.Get(ctx, client.ObjectKey{Namespace: w.configMapNamespace, Name: src.configMap}, &cm); err != nil {
+			return nil, "", fmt.Errorf("failed to get ConfigMap %s/%s: %w", w.configMapNamespace, src.configMap, err)
+		}
+		if data, ok := cm.BinaryData[src.key]; ok {
+			return data, cm.ResourceVersion, nil
+		}
+		if data, ok := cm.Data[src.key]; ok {
+			return []byte(data), cm.ResourceVersion, nil
+		}
+		return nil, "", fmt.Errorf("ConfigMap %s/%s has no key %q", w.configMapNamespace, src.configMap, src.key)
+
+	default:
+		return nil, "", errors.New("no module source defined")
+	}
+}
+
+// version returns the current version of the given source without reading
+// the module. The version of a ConfigMap is the resourceVersion last observed
+// by the ConfigMap watch, and is unknown until the watch has observed it.
+func (w *wasm) version(src source) (string, bool, error) {
+	if len(src.module) == 0 {
+		w.compiledLock.Lock()
+		defer w.compiledLock.Unlock()
+		version, ok := w.configMapVersions[src.configMap]
+		return version, ok, nil
+	}
+
+	path, err := w.modulePath(src)
+	if err != nil {
+		return "", false, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", false, err
+	}
+	return fileVersion(info), true, nil
+}
+
+// modulePath returns the path of the module file of the given source.
+func (w *wasm) modulePath(src source) (string, error) {
+	if len(w.moduleDir) == 0 {
+		return "", errors.New("loading modules from local files is not enabled")
+	}
+	if filepath.Base(src.module) != src.module {
+		return "", fmt.Errorf("module %q must be a file name", src.module)
+	}
+	return filepath.Join(w.moduleDir, src.module), nil
+}
+
+// fileVersion returns the version of a module file from its modification
+// time and size.
+func fileVersion(info os.FileInfo) string {
+	return strconv.FormatInt(info.ModTime().UnixNano(), 10) + "/" + strconv.FormatInt(info.Size(), 10)
+}
+
+// observeConfigMap records the resourceVersion of a ConfigMap in the module
+// namespace observed by the ConfigMap watch, so that modules compiled from an
+// older version are re-compiled on their next use.
+func (w *wasm) observeConfigMap(name, resourceVersion string, deleted bool) {
+	w.compiledLock.Lock()
+	defer w.compiledLock.Unlock()
+	if deleted {
+		delete(w.configMapVersions, name)
+		return
+	}
+	w.configMapVersions[name] = resourceVersion
+}
+
+// compile returns the compiled module of the given source. Modules are only
+// read and re-compiled when the version of the source has changed since the
+// last compilation. The returned module must be released with release once
+// the caller has finished instantiating it.
+func (w *wasm) compile(ctx context.Context, src source) (*compiledModule, error) {
+	version, known, err := w.version(src)
+	if err != nil {
+		return nil, err
+	}
+
+	w.compiledLock.Lock()
+	if existing, ok := w.compiled[src]; ok && known && existing.version == version {
+		existing.refs++
+		w.compiledLock.Unlock()
+		return existing, nil
+	}
+	w.compiledLock.Unlock()
+
+	data, version, err := w.load(ctx, src)
+	if err != nil {
+		return nil, err
+	}
+
+	w.compiledLock.Lock()
+	defer w.compiledLock.Unlock()
+
+	if existing, ok := w.compiled[src]; ok {
+		if existing.version == version {
+			existing.refs++
+			return existing, nil
+		}
+		// Evaluations still instantiating the old module hold a reference,
+		// so it is only closed once they have released it.
+		existing.stale = true
+		w.closeIfUnused(ctx, src, existing)
+		delete(w.compiled, src)
+	}
+
+	module, err := w.runtime.CompileModule(ctx, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile module: %w", err)
+	}
+
+	for _, name := range []string{exportAllocate, exportEvaluate} {
+		if _, ok := module.ExportedFunctions()[name]; !ok {
+			_ = module.Close(ctx)
+			return nil, fmt.Errorf("module does not export the function %q", name)
+		}
+	}
+	if len(module.ExportedMemories()) == 0 {
+		_ = module.Close(ctx)
+		return nil, errors.New("module does not export a memory")
+	}
+
+	compiled := &compiledModule{version: version, module: module, refs: 1}
+	w.compiled[src] = compiled
+
+	return compiled, nil
+}
+
+// release releases a module returned by compile, closing it if it has been
+// replaced and is no longer used.
+func (w *wasm) release(ctx context.Context, src source, compiled *compiledModule) {
+	w.compiledLock.Lock()
+	defer w.compiledLock.Unlock()
+	compiled.refs--
+	w.closeIfUnused(ctx, src, compiled)
+}
+
+// closeIfUnused closes the module if it has been replaced and is no longer
+// used. Instances already running against the module keep working after it
+// is closed. Must be called with compiledLock held.
+func (w *wasm) closeIfUnused(ctx context.Context, src source, compiled *compiledModule) {
+	if !compiled.stale || compiled.refs > 0 {
+		return
+	}
+	if err := compiled.module.Close(ctx); err != nil {
+		w.log.Error(err, "failed to close stale compiled module", "source", src.String())
+	}
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wasm
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver"
+)
+
+// valuesPath is the field path of the wasm plugin values.
+var valuesPath = field.NewPath("spec", "plugins", Name, "values")
+
+// PluginSchema declares the values accepted by the wasm plugin. Unknown values
+// are rejected by the webhook using this schema.
+func (w *wasm) PluginSchema() approver.PluginSchema {
+	return approver.PluginSchema{
+		Description: "Evaluates requests using a WebAssembly module.",
+		Values: []approver.PluginValueSchema{
+			{
+				Key:         valueModule,
+				Type:        approver.PluginValueTypeString,
+				Description: "File name of the module in the --wasm-module-dir directory. Mutually exclusive with configMap.",
+			},
+			{
+				Key:         valueConfigMap,
+				Type:        approver.PluginValueTypeString,
+				Description: "Name of the ConfigMap in the --wasm-configmap-namespace namespace containing the module. Mutually exclusive with module.",
+			},
+			{
+				Key:         valueKey,
+				Type:        approver.PluginValueTypeString,
+				Description: fmt.Sprintf("Key of the module in the ConfigMap. Defaults to %q when configMap is defined.", defaultConfigMapKey),
+			},
+		},
+	}
+}
+
+// Validate validates that the processed CertificateRequestPolicy references
+// exactly one module source which is enabled.
+func (w *wasm) Validate(_ context.Context, policy *policyapi.CertificateRequestPolicy) (approver.WebhookValidationResponse, error) {
+	plugin, ok := policy.Spec.Plugins[Name]
+	if !ok {
+		return approver.WebhookValidationResponse{
+			Allowed: true,
+			Errors:  nil,
+		}, nil
+	}
+
+	var (
+		el     field.ErrorList
+		values = plugin.Values
+	)
+
+	module, hasModule := values[valueModule]
+	configMap, hasConfigMap := values[valueConfigMap]
+	key, hasKey := values[valueKey]
+
+	switch {
+	case hasModule && hasConfigMap:
+		el = append(el, field.Invalid(valuesPath.Key(valueConfigMap), configMap, "only one of module or configMap may be defined"))
+
+	case hasModule:
+		if len(w.moduleDir) == 0 {
+			el = append(el, field.Forbidden(valuesPath.Key(valueModule), "loading modules from local files is not enabled, hint: set --wasm-module-dir"))
+		}
+		if len(module) == 0 || filepath.Base(module) != module || module == "." || module == ".." {
+			el = append(el, field.Invalid(valuesPath.Key(valueModule), module, "must be the name of a file in the module directory"))
+		}
+		if hasKey {
+			el = append(el, field.Invalid(valuesPath.Key(valueKey), key, "key may only be defined with configMap"))
+		}
+
+	case hasConfigMap:
+		if len(w.configMapNamespace) == 0 {
+			el = append(el, field.Forbidden(valuesPath.Key(valueConfigMap), "loading modules from ConfigMaps is not enabled, hint: set --wasm-configmap-namespace"))
+		}
+		for _, msg := range validation.IsDNS1123Subdomain(configMap) {
+			el = append(el, field.Invalid(valuesPath.Key(valueConfigMap), configMap, msg))
+		}
+		if hasKey {
+			for _, msg := range validation.IsConfigMapKey(key) {
+				el = append(el, field.Invalid(valuesPath.Key(valueKey), key, msg))
+			}
+		}
+
+	default:
+		el = append(el, field.Required(valuesPath.Key(valueModule), "one of module or configMap must be defined"))
+	}
+
+	return approver.WebhookValidationResponse{
+		Allowed: len(el) == 0,
+		Errors:  el,
+	}, nil
+}
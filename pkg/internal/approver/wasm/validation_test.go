@@ -0,0 +1,141 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wasm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver"
+)
+
+func Test_Validate(t *testing.T) {
+	enabled := &wasm{moduleDir: "/modules", configMapNamespace: "cert-manager"}
+
+	tests := map[string]struct {
+		wasm        *wasm
+		plugins     map[string]policyapi.CertificateRequestPolicyPluginData
+		expResponse approver.WebhookValidationResponse
+	}{
+		"if the policy doesn't use the wasm plugin, return allowed": {
+			wasm:        &wasm{},
+			plugins:     nil,
+			expResponse: approver.WebhookValidationResponse{Allowed: true},
+		},
+		"if a module file is referenced, return allowed": {
+			wasm: enabled,
+			plugins: map[string]policyapi.CertificateRequestPolicyPluginData{
+				Name: {Values: map[string]string{"module": "policy.wasm"}},
+			},
+			expResponse: approver.WebhookValidationResponse{Allowed: true},
+		},
+		"if a ConfigMap is referenced with a key, return allowed": {
+			wasm: enabled,
+			plugins: map[string]policyapi.CertificateRequestPolicyPluginData{
+				Name: {Values: map[string]string{"configMap": "modules", "key": "policy.wasm"}},
+			},
+			expResponse: approver.WebhookValidationResponse{Allowed: true},
+		},
+		"if no source is referenced, return error": {
+			wasm: enabled,
+			plugins: map[string]policyapi.CertificateRequestPolicyPluginData{
+				Name: {Values: map[string]string{}},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.Required(field.NewPath("spec.plugins.wasm.values[module]"), "one of module or configMap must be defined"),
+				},
+			},
+		},
+		"if both sources are referenced, return error": {
+			wasm: enabled,
+			plugins: map[string]policyapi.CertificateRequestPolicyPluginData{
+				Name: {Values: map[string]string{"module": "policy.wasm", "configMap": "modules"}},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.Invalid(field.NewPath("spec.plugins.wasm.values[configMap]"), "modules", "only one of module or configMap may be defined"),
+				},
+			},
+		},
+		"if the module is a path, return error": {
+			wasm: enabled,
+			plugins: map[string]policyapi.CertificateRequestPolicyPluginData{
+				Name: {Values: map[string]string{"module": "../etc/policy.wasm"}},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.Invalid(field.NewPath("spec.plugins.wasm.values[module]"), "../etc/policy.wasm", "must be the name of a file in the module directory"),
+				},
+			},
+		},
+		"if a key is defined with a module, return error": {
+			wasm: enabled,
+			plugins: map[string]policyapi.CertificateRequestPolicyPluginData{
+				Name: {Values: map[string]string{"module": "policy.wasm", "key": "foo"}},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.Invalid(field.NewPath("spec.plugins.wasm.values[key]"), "foo", "key may only be defined with configMap"),
+				},
+			},
+		},
+		"if local modules are not enabled, return error": {
+			wasm: &wasm{},
+			plugins: map[string]policyapi.CertificateRequestPolicyPluginData{
+				Name: {Values: map[string]string{"module": "policy.wasm"}},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.Forbidden(field.NewPath("spec.plugins.wasm.values[module]"), "loading modules from local files is not enabled, hint: set --wasm-module-dir"),
+				},
+			},
+		},
+		"if ConfigMap modules are not enabled and the ConfigMap name is invalid, return errors": {
+			wasm: &wasm{},
+			plugins: map[string]policyapi.CertificateRequestPolicyPluginData{
+				Name: {Values: map[string]string{"configMap": "Modules"}},
+			},
+			expResponse: approver.WebhookValidationResponse{
+				Allowed: false,
+				Errors: field.ErrorList{
+					field.Forbidden(field.NewPath("spec.plugins.wasm.values[configMap]"), "loading modules from ConfigMaps is not enabled, hint: set --wasm-configmap-namespace"),
+					field.Invalid(field.NewPath("spec.plugins.wasm.values[configMap]"), "Modules", "a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')"),
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			policy := &policyapi.CertificateRequestPolicy{
+				Spec: policyapi.CertificateRequestPolicySpec{Plugins: test.plugins},
+			}
+			response, err := test.wasm.Validate(t.Context(), policy)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expResponse, response)
+		})
+	}
+}
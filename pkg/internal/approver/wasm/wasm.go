@@ -0,0 +1,271 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wasm
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/pflag"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver"
+	"github.com/cert-manager/approver-policy/pkg/registry"
+)
+
+// Load the wasm approver.
+func init() {
+	registry.Shared.Store(Approver())
+}
+
+const (
+	// Name is the name of the wasm approver, and the key CertificateRequestPolicies
+	// use to configure it under `spec.plugins`.
+	Name = "wasm"
+
+	// wasmPageSize is the size in bytes of a single WebAssembly memory page.
+	wasmPageSize = 64 * 1024
+)
+
+// Approver returns an instance on the wasm approver.
+func Approver() approver.Interface {
+	return &wasm{
+		memoryLimitMiB: 16,
+		timeout:        2 * time.Second,
+		enqueue:        make(chan string),
+	}
+}
+
+// wasm is an approver-policy Approver that evaluates CertificateRequests
+// using WebAssembly modules. Modules are executed in a sandboxed pure-Go
+// runtime with bounded memory and execution time. A module is referenced by a
+// CertificateRequestPolicy either by file name from a local directory, or by
+// name and key of a ConfigMap in a single configured namespace.
+type wasm struct {
+	log logr.Logger
+
+	// moduleDir is the directory local modules are loaded from. Loading local
+	// modules is disabled if empty.
+	moduleDir string
+
+	// configMapNamespace is the namespace that ConfigMap modules are loaded
+	// from. Loading modules from ConfigMaps is disabled if empty.
+	configMapNamespace string
+
+	// memoryLimitMiB is the maximum memory a single module instance may use.
+	memoryLimitMiB uint32
+
+	// timeout is the maximum duration of a single module evaluation.
+	timeout time.Duration
+
+	// configMaps is used to read ConfigMaps holding modules.
+	configMaps client.Reader
+
+	// runtime is the WebAssembly runtime all modules are compiled and
+	// instantiated in.
+	runtime wazero.Runtime
+
+	// compiledLock guards compiled and configMapVersions.
+	compiledLock sync.Mutex
+	// compiled holds the last compiled module for each module source, so
+	// modules are only re-compiled when their contents change.
+	compiled map[source]*compiledModule
+	// configMapVersions holds the resourceVersion of each ConfigMap in the
+	// module namespace last observed by the ConfigMap watch.
+	configMapVersions map[string]string
+
+	// enqueue is used to re-reconcile CertificateRequestPolicies that
+	// reference a ConfigMap module which has changed.
+	enqueue chan string
+}
+
+// Name of Approver is "wasm".
+func (w *wasm) Name() string {
+	return Name
+}
+
+// RegisterFlags registers the flags that control where modules may be
+// loaded from, and the resource limits applied to them.
+func (w *wasm) RegisterFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&w.moduleDir, "wasm-module-dir", "",
+		"Directory containing WebAssembly modules which CertificateRequestPolicies "+
+			"may reference by file name with the 'module' plugin value. Loading "+
+			"modules from local files is disabled if empty.")
+	fs.StringVar(&w.configMapNamespace, "wasm-configmap-namespace", "",
+		"Namespace of ConfigMaps containing WebAssembly modules which "+
+			"CertificateRequestPolicies may reference with the 'configMap' and 'key' "+
+			"plugin values. approver-policy must be granted permission to get, list "+
+			"and watch ConfigMaps in this namespace. Loading modules from ConfigMaps "+
+			"is disabled if empty.")
+	fs.Uint32Var(&w.memoryLimitMiB, "wasm-memory-limit", w.memoryLimitMiB,
+		"Maximum memory in MiB that a single WebAssembly module instance may use.")
+	fs.DurationVar(&w.timeout, "wasm-evaluation-timeout", w.timeout,
+		"Maximum duration of a single WebAssembly module evaluation. Evaluations "+
+			"exceeding this duration are aborted and return an error.")
+}
+
+// Prepare creates the WebAssembly runtime, and when ConfigMap modules are
+// enabled, a cache of ConfigMaps in the configured namespace.
+func (w *wasm) Prepare(ctx context.Context, log logr.Logger, mgr manager.Manager) error {
+	w.log = log.WithName(Name)
+
+	if w.memoryLimitMiB == 0 || w.memoryLimitMiB > 4096 {
+		return fmt.Errorf("--wasm-memory-limit must be between 1 and 4096, got %d", w.memoryLimitMiB)
+	}
+	if w.timeout <= 0 {
+		return fmt.Errorf("--wasm-evaluation-timeout must be greater than 0, got %s", w.timeout)
+	}
+
+	if w.configMapNamespace != "" {
+		configMapCache, err := cache.New(mgr.GetConfig(), cache.Options{
+			Scheme:            mgr.GetScheme(),
+			Mapper:            mgr.GetRESTMapper(),
+			DefaultNamespaces: map[string]cache.Config{w.configMapNamespace: {}},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to build ConfigMap cache: %w", err)
+		}
+
+		informer, err := configMapCache.GetInformer(ctx, new(corev1.ConfigMap))
+		if err != nil {
+			return fmt.Errorf("failed to build ConfigMap informer: %w", err)
+		}
+
+		lister := mgr.GetCache()
+		enqueue := func(obj interface{}, deleted bool) {
+			if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			cm, ok := obj.(*corev1.ConfigMap)
+			if !ok {
+				return
+			}
+			w.observeConfigMap(cm.Name, cm.ResourceVersion, deleted)
+			w.enqueuePoliciesForConfigMap(ctx, lister, cm.Name)
+		}
+		if _, err := informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+			AddFunc:    func(obj interface{}) { enqueue(obj, false) },
+			UpdateFunc: func(_, obj interface{}) { enqueue(obj, false) },
+			DeleteFunc: func(obj interface{}) { enqueue(obj, true) },
+		}); err != nil {
+			return fmt.Errorf("failed to add ConfigMap event handler: %w", err)
+		}
+
+		if err := mgr.Add(configMapCache); err != nil {
+			return fmt.Errorf("failed to add ConfigMap cache to manager: %w", err)
+		}
+
+		w.configMaps = configMapCache
+	}
+
+	if err := w.startRuntime(ctx); err != nil {
+		return err
+	}
+
+	go func() {
+		<-ctx.Done()
+		if err := w.runtime.Close(context.Background()); err != nil {
+			w.log.Error(err, "failed to close WebAssembly runtime")
+		}
+	}()
+
+	return nil
+}
+
+// startRuntime creates the WebAssembly runtime using the configured memory
+// limit. Module instances are closed as soon as their evaluation context is
+// done, which enforces the evaluation timeout.
+func (w *wasm) startRuntime(ctx context.Context) error {
+	w.runtime = wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().
+		WithMemoryLimitPages(w.memoryLimitMiB*(1024*1024/wasmPageSize)).
+		WithCloseOnContextDone(true),
+	)
+	w.compiled = make(map[source]*compiledModule)
+	w.configMapVersions = make(map[string]string)
+
+	// Expose WASI so that modules built with common toolchains can be
+	// instantiated. No filesystem, network or environment is mounted.
+	if _, err := wasi_snapshot_preview1.Instantiate(ctx, w.runtime); err != nil {
+		return fmt.Errorf("failed to instantiate WASI: %w", err)
+	}
+
+	return nil
+}
+
+// Ready returns ready if the CertificateRequestPolicy doesn't use the wasm
+// plugin, or the module it references could be loaded and compiled.
+func (w *wasm) Ready(ctx context.Context, policy *policyapi.CertificateRequestPolicy) (approver.ReconcilerReadyResponse, error) {
+	plugin, ok := policy.Spec.Plugins[Name]
+	if !ok {
+		return approver.ReconcilerReadyResponse{Ready: true}, nil
+	}
+
+	src := sourceFromValues(plugin.Values)
+	compiled, err := w.compile(ctx, src)
+	if err != nil {
+		return approver.ReconcilerReadyResponse{
+			Ready:  false,
+			Errors: field.ErrorList{field.Invalid(valuesPath, src.String(), err.Error())},
+		}, nil
+	}
+	w.release(ctx, src, compiled)
+
+	return approver.ReconcilerReadyResponse{Ready: true}, nil
+}
+
+// EnqueueChan returns a channel of CertificateRequestPolicy names that
+// reference a ConfigMap module which has changed.
+func (w *wasm) EnqueueChan() <-chan string {
+	return w.enqueue
+}
+
+// enqueuePoliciesForConfigMap sends the names of all CertificateRequestPolicies
+// that reference the named ConfigMap to the enqueue channel.
+func (w *wasm) enqueuePoliciesForConfigMap(ctx context.Context, lister client.Reader, name string) {
+	var policies policyapi.CertificateRequestPolicyList
+	if err := lister.List(ctx, &policies); err != nil {
+		w.log.Error(err, "failed to list CertificateRequestPolicies for ConfigMap change", "configmap", name)
+		return
+	}
+
+	for _, policy := range policies.Items {
+		plugin, ok := policy.Spec.Plugins[Name]
+		if !ok || sourceFromValues(plugin.Values).configMap != name {
+			continue
+		}
+
+		// Don't block the informer while the policy controller is not
+		// consuming events, for example when not the leader.
+		go func(name string) {
+			select {
+			case w.enqueue <- name:
+			case <-ctx.Done():
+			}
+		}(policy.Name)
+	}
+}
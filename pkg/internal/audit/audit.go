@@ -0,0 +1,231 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package audit records an append-only audit trail of the approvals and
+// denials of CertificateRequests. Entries are buffered by a Recorder and
+// written in batches to a Sink.
+//
+// The audit trail is best effort. Entries are recorded after the decision has
+// been written to the CertificateRequest, so an entry is lost if
+// approver-policy exits between writing the decision and the entry being
+// written to the sink, or if the buffer is full.
+package audit
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/cert-manager/approver-policy/pkg/internal/metrics"
+)
+
+// ErrBufferFull is returned when an entry is dropped because the buffer of
+// the Recorder is full.
+var ErrBufferFull = errors.New("audit buffer is full")
+
+// maxRecordedKeys is the number of most recently recorded decisions which are
+// remembered to drop duplicate entries.
+const maxRecordedKeys = 10000
+
+var _ manager.Runnable = &Recorder{}
+
+// Interface records audit entries.
+type Interface interface {
+	// Record queues the entry to be written to the audit sink. An error is
+	// returned if the entry could not be queued.
+	Record(ctx context.Context, entry Entry) error
+}
+
+// Recorder buffers audit entries and writes them in batches to a Sink. Record
+// never blocks: when the buffer is full, entries are dropped and counted by
+// the approverpolicy_audit_entries_dropped_total metric, so that a slow sink
+// doesn't stall the review of CertificateRequests. Entries which fail to be
+// written are retried with backoff until they are written or the Recorder is
+// stopped.
+type Recorder struct {
+	log     logr.Logger
+	sink    Sink
+	entries chan Entry
+
+	// lock guards recorded and recordedOrder.
+	lock sync.Mutex
+
+	// recorded is the set of the most recently recorded decisions, so that a
+	// decision written again to the same CertificateRequest, such as when it
+	// was reviewed again before the cache observed the first decision, is
+	// only recorded once.
+	recorded map[recordedKey]struct{}
+
+	// recordedOrder is a ring of the keys in recorded, in the order they were
+	// recorded, so that the oldest key is forgotten once maxRecordedKeys
+	// decisions have been recorded.
+	recordedOrder []recordedKey
+	recordedNext  int
+
+	// maxBatch is the maximum number of entries written to the sink at once.
+	maxBatch int
+
+	// backoff is the backoff between attempts to write a batch which failed.
+	backoff wait.Backoff
+}
+
+// recordedKey identifies a decision made on a CertificateRequest.
+type recordedKey struct {
+	uid      string
+	decision Decision
+}
+
+// NewRecorder returns a Recorder which buffers up to bufferSize entries before
+// dropping entries, writing them to sink once started.
+func NewRecorder(log logr.Logger, sink Sink, bufferSize int) *Recorder {
+	return &Recorder{
+		log:           log,
+		sink:          sink,
+		entries:       make(chan Entry, bufferSize),
+		recorded:      make(map[recordedKey]struct{}),
+		recordedOrder: make([]recordedKey, maxRecordedKeys),
+		maxBatch:      100,
+		backoff: wait.Backoff{
+			Duration: 100 * time.Millisecond,
+			Factor:   2,
+			Jitter:   0.1,
+			Steps:    10,
+			Cap:      30 * time.Second,
+		},
+	}
+}
+
+// Record queues the entry to be written to the sink. Entries for a decision
+// which has already been recorded for the same CertificateRequest are
+// ignored. If the buffer is full, the entry is dropped and ErrBufferFull is
+// returned.
+func (r *Recorder) Record(_ context.Context, entry Entry) error {
+	key := recordedKey{uid: entry.Request.UID, decision: entry.Decision}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if _, ok := r.recorded[key]; ok {
+		return nil
+	}
+
+	select {
+	case r.entries <- entry:
+	default:
+		metrics.RecordAuditEntryDropped()
+		return ErrBufferFull
+	}
+
+	delete(r.recorded, r.recordedOrder[r.recordedNext])
+	r.recordedOrder[r.recordedNext] = key
+	r.recordedNext = (r.recordedNext + 1) % len(r.recordedOrder)
+	r.recorded[key] = struct{}{}
+	return nil
+}
+
+// Start writes queued entries to the sink until the context is done. Any
+// queued entries are then flushed, and the sink is closed.
+func (r *Recorder) Start(ctx context.Context) error {
+	defer func() {
+		if err := r.sink.Close(); err != nil {
+			r.log.Error(err, "failed to close audit sink")
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			r.flush(nil)
+			return nil
+
+		case entry := <-r.entries:
+			batch := r.batch(entry)
+			if !r.write(ctx, batch) {
+				r.flush(batch)
+				return nil
+			}
+		}
+	}
+}
+
+// NeedLeaderElection returns false so that entries recorded while the
+// controllers are stopping are still written.
+func (r *Recorder) NeedLeaderElection() bool {
+	return false
+}
+
+// batch returns the entry with as many other queued entries as are available
+// without blocking, up to maxBatch.
+func (r *Recorder) batch(entry Entry) []Entry {
+	batch := []Entry{entry}
+	for len(batch) < r.maxBatch {
+		select {
+		case entry := <-r.entries:
+			batch = append(batch, entry)
+		default:
+			return batch
+		}
+	}
+	return batch
+}
+
+// write writes the batch to the sink, retrying with backoff on failure.
+// Returns false if the context was done before the batch was written.
+func (r *Recorder) write(ctx context.Context, batch []Entry) bool {
+	backoff := r.backoff
+	for {
+		err := r.sink.Write(ctx, batch)
+		if err == nil {
+			return true
+		}
+		r.log.Error(err, "failed to write audit entries, retrying", "entries", len(batch))
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(backoff.Step()):
+		}
+	}
+}
+
+// flush makes a final attempt to write the unwritten batch and all queued
+// entries to the sink.
+func (r *Recorder) flush(batch []Entry) {
+	for {
+		select {
+		case entry := <-r.entries:
+			batch = append(batch, entry)
+			continue
+		default:
+		}
+		break
+	}
+
+	if len(batch) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := r.sink.Write(ctx, batch); err != nil {
+		r.log.Error(err, "failed to flush audit entries, entries have been lost", "entries", len(batch))
+	}
+}
@@ -0,0 +1,266 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2/ktesting"
+
+	"github.com/cert-manager/approver-policy/pkg/approver/manager"
+	"github.com/cert-manager/approver-policy/pkg/internal/version"
+)
+
+func Test_NewEntry(t *testing.T) {
+	csr, _, err := gen.CSR(x509.ECDSA, gen.SetCSRCommonName("example.com"), gen.SetCSRDNSNames("example.com", "www.example.com"))
+	require.NoError(t, err)
+
+	cr := gen.CertificateRequest("test",
+		gen.SetCertificateRequestNamespace("test-ns"),
+		gen.SetCertificateRequestCSR(csr),
+		gen.SetCertificateRequestUsername("example"),
+		gen.SetCertificateRequestGroups([]string{"group-a"}),
+		gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Name: "test-issuer", Kind: "Issuer", Group: "cert-manager.io"}),
+	)
+	cr.UID = types.UID("test-uid")
+
+	now := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("an approved entry records the approving policy", func(t *testing.T) {
+		entry := NewEntry(now, cr, DecisionApproved, "approved", manager.ReviewResponse{Result: manager.ResultApproved, Policy: "test-policy"})
+		assert.Equal(t, Entry{
+			Time:     now,
+			Decision: DecisionApproved,
+			Request:  Request{UID: "test-uid", Namespace: "test-ns", Name: "test", IssuerName: "test-issuer", IssuerKind: "Issuer", IssuerGroup: "cert-manager.io"},
+			Requester: Requester{
+				Username: "example",
+				Groups:   []string{"group-a"},
+			},
+			Subject: &Subject{
+				CommonName:   "example.com",
+				DNSNames:     []string{"example.com", "www.example.com"},
+				KeyAlgorithm: "ECDSA",
+				KeySize:      256,
+			},
+			Policy:  "test-policy",
+			Message: "approved",
+			Version: Version{App: version.AppVersion, Commit: version.GitCommit},
+		}, entry)
+	})
+
+	t.Run("a denied entry records the denials", func(t *testing.T) {
+		entry := NewEntry(now, cr, DecisionDenied, "denied", manager.ReviewResponse{
+			Result:  manager.ResultDenied,
+			Denials: []manager.Denial{{Policy: "test-policy", Evaluator: "allowed", Field: "spec.allowed.dnsNames.values"}},
+		})
+		assert.Empty(t, entry.Policy)
+		assert.Equal(t, []Denial{{Policy: "test-policy", Evaluator: "allowed", Field: "spec.allowed.dnsNames.values"}}, entry.Denials)
+	})
+}
+
+func Test_Sinks(t *testing.T) {
+	entries := []Entry{
+		{Decision: DecisionApproved, Policy: "test-policy-a"},
+		{Decision: DecisionDenied, Denials: []Denial{{Policy: "test-policy-b"}}},
+	}
+
+	t.Run("file sink appends JSON lines", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "audit.jsonl")
+		for range 2 {
+			sink, err := NewSink("file:" + path)
+			require.NoError(t, err)
+			require.NoError(t, sink.Write(t.Context(), entries))
+			require.NoError(t, sink.Close())
+		}
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, append(entries, entries...), decodeLines(t, data))
+	})
+
+	t.Run("HTTP sink POSTs JSON lines", func(t *testing.T) {
+		var body []byte
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "application/x-ndjson", r.Header.Get("Content-Type"))
+			body, _ = io.ReadAll(r.Body)
+		}))
+		defer server.Close()
+
+		sink, err := NewSink(server.URL)
+		require.NoError(t, err)
+		require.NoError(t, sink.Write(t.Context(), entries))
+		assert.Equal(t, entries, decodeLines(t, body))
+	})
+
+	t.Run("HTTP sink returns an error on a non-2xx response", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		sink, err := NewSink(server.URL)
+		require.NoError(t, err)
+		assert.Error(t, sink.Write(t.Context(), entries))
+	})
+
+	t.Run("unsupported sinks return an error", func(t *testing.T) {
+		_, err := NewSink("syslog")
+		assert.Error(t, err)
+		_, err = NewSink("file:")
+		assert.Error(t, err)
+	})
+}
+
+func Test_Recorder(t *testing.T) {
+	t.Run("entries are written, retrying failed writes", func(t *testing.T) {
+		sink := &fakeSink{failures: 2}
+		r := NewRecorder(ktesting.NewLogger(t, ktesting.DefaultConfig), sink, 10)
+		r.backoff = wait.Backoff{Duration: time.Millisecond, Factor: 1, Steps: 10}
+
+		ctx, cancel := context.WithCancel(t.Context())
+		done := make(chan struct{})
+		go func() {
+			assert.NoError(t, r.Start(ctx))
+			close(done)
+		}()
+
+		require.NoError(t, r.Record(ctx, Entry{Request: Request{UID: "a"}, Policy: "test-policy-a"}))
+		require.NoError(t, r.Record(ctx, Entry{Request: Request{UID: "b"}, Policy: "test-policy-b"}))
+
+		require.Eventually(t, func() bool { return len(sink.written()) == 2 }, 5*time.Second, time.Millisecond)
+		cancel()
+		<-done
+
+		assert.Equal(t, []string{"test-policy-a", "test-policy-b"}, policies(sink.written()))
+		assert.True(t, sink.isClosed())
+	})
+
+	t.Run("Record drops entries when the buffer is full", func(t *testing.T) {
+		r := NewRecorder(ktesting.NewLogger(t, ktesting.DefaultConfig), &fakeSink{}, 1)
+
+		require.NoError(t, r.Record(t.Context(), Entry{Request: Request{UID: "a"}, Policy: "test-policy-a"}))
+		assert.ErrorIs(t, r.Record(t.Context(), Entry{Request: Request{UID: "b"}, Policy: "test-policy-b"}), ErrBufferFull)
+	})
+
+	t.Run("duplicate decisions for a request are recorded once", func(t *testing.T) {
+		sink := &fakeSink{}
+		r := NewRecorder(ktesting.NewLogger(t, ktesting.DefaultConfig), sink, 10)
+
+		require.NoError(t, r.Record(t.Context(), Entry{Request: Request{UID: "a"}, Decision: DecisionApproved, Policy: "test-policy-a"}))
+		require.NoError(t, r.Record(t.Context(), Entry{Request: Request{UID: "a"}, Decision: DecisionApproved, Policy: "test-policy-a"}))
+		require.NoError(t, r.Record(t.Context(), Entry{Request: Request{UID: "a"}, Decision: DecisionDenied, Policy: "test-policy-b"}))
+		require.NoError(t, r.Record(t.Context(), Entry{Request: Request{UID: "b"}, Decision: DecisionApproved, Policy: "test-policy-c"}))
+
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+		require.NoError(t, r.Start(ctx))
+
+		assert.Equal(t, []string{"test-policy-a", "test-policy-b", "test-policy-c"}, policies(sink.written()))
+	})
+
+	t.Run("queued entries are flushed when stopped", func(t *testing.T) {
+		sink := &fakeSink{}
+		r := NewRecorder(ktesting.NewLogger(t, ktesting.DefaultConfig), sink, 10)
+
+		require.NoError(t, r.Record(t.Context(), Entry{Request: Request{UID: "a"}, Policy: "test-policy-a"}))
+		require.NoError(t, r.Record(t.Context(), Entry{Request: Request{UID: "b"}, Policy: "test-policy-b"}))
+
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+		require.NoError(t, r.Start(ctx))
+
+		assert.Equal(t, []string{"test-policy-a", "test-policy-b"}, policies(sink.written()))
+	})
+}
+
+// fakeSink records written entries, failing the first failures writes.
+type fakeSink struct {
+	lock     sync.Mutex
+	failures int
+	entries  []Entry
+	closed   bool
+}
+
+func (f *fakeSink) Write(_ context.Context, entries []Entry) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.failures > 0 {
+		f.failures--
+		return errors.New("this is an error")
+	}
+	f.entries = append(f.entries, entries...)
+	return nil
+}
+
+func (f *fakeSink) Close() error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.closed = true
+	return nil
+}
+
+func (f *fakeSink) written() []Entry {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return append([]Entry(nil), f.entries...)
+}
+
+func (f *fakeSink) isClosed() bool {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.closed
+}
+
+func policies(entries []Entry) []string {
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Policy)
+	}
+	return names
+}
+
+func decodeLines(t *testing.T, data []byte) []Entry {
+	var entries []Entry
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		var entry Entry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		entries = append(entries, entry)
+	}
+	require.NoError(t, scanner.Err())
+	return entries
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
+
+	"github.com/cert-manager/approver-policy/pkg/approver/manager"
+	"github.com/cert-manager/approver-policy/pkg/internal/version"
+)
+
+// Decision is the decision made on a CertificateRequest.
+type Decision string
+
+const (
+	// DecisionApproved is the decision to approve a CertificateRequest.
+	DecisionApproved Decision = "Approved"
+
+	// DecisionDenied is the decision to deny a CertificateRequest.
+	DecisionDenied Decision = "Denied"
+)
+
+// Entry is a single audit log entry, recording the decision made on a
+// CertificateRequest.
+type Entry struct {
+	// Time is the time the decision was made.
+	Time time.Time `json:"time"`
+
+	// Decision is whether the request was approved or denied.
+	Decision Decision `json:"decision"`
+
+	// Request identifies the CertificateRequest.
+	Request Request `json:"request"`
+
+	// Requester is the user which created the CertificateRequest.
+	Requester Requester `json:"requester"`
+
+	// Subject is what the CertificateRequest requested to be signed. Empty if
+	// the request could not be decoded.
+	Subject *Subject `json:"subject,omitempty"`
+
+	// Policy is the name of the CertificateRequestPolicy which approved the
+	// request.
+	Policy string `json:"policy,omitempty"`
+
+	// Denials are the reasons the request was denied.
+	Denials []Denial `json:"denials,omitempty"`
+
+	// Message is the message set on the Approved or Denied condition.
+	Message string `json:"message"`
+
+	// Version is the version of approver-policy which made the decision.
+	Version Version `json:"version"`
+}
+
+// Request identifies a CertificateRequest.
+type Request struct {
+	UID         string `json:"uid"`
+	Namespace   string `json:"namespace"`
+	Name        string `json:"name"`
+	IssuerName  string `json:"issuerName"`
+	IssuerKind  string `json:"issuerKind"`
+	IssuerGroup string `json:"issuerGroup"`
+}
+
+// Requester is the user which created a CertificateRequest.
+type Requester struct {
+	Username string   `json:"username"`
+	UID      string   `json:"uid,omitempty"`
+	Groups   []string `json:"groups,omitempty"`
+}
+
+// Subject is the subject, SANs and public key of a CertificateRequest.
+type Subject struct {
+	CommonName     string   `json:"commonName,omitempty"`
+	DNSNames       []string `json:"dnsNames,omitempty"`
+	IPAddresses    []string `json:"ipAddresses,omitempty"`
+	URIs           []string `json:"uris,omitempty"`
+	EmailAddresses []string `json:"emailAddresses,omitempty"`
+	IsCA           bool     `json:"isCA,omitempty"`
+	Duration       string   `json:"duration,omitempty"`
+
+	// KeyAlgorithm is the algorithm of the public key, such as RSA.
+	KeyAlgorithm string `json:"keyAlgorithm"`
+
+	// KeySize is the size of the public key in bits.
+	KeySize int `json:"keySize,omitempty"`
+}
+
+// Denial is a reason a CertificateRequestPolicy denied a request.
+type Denial struct {
+	Policy    string `json:"policy"`
+	Evaluator string `json:"evaluator,omitempty"`
+	Field     string `json:"field,omitempty"`
+}
+
+// Version is the version of approver-policy.
+type Version struct {
+	App    string `json:"app"`
+	Commit string `json:"commit,omitempty"`
+}
+
+// NewEntry builds the audit entry for a decision made on a CertificateRequest
+// from its review.
+func NewEntry(now time.Time, cr *cmapi.CertificateRequest, decision Decision, message string, response manager.ReviewResponse) Entry {
+	entry := Entry{
+		Time:     now.UTC(),
+		Decision: decision,
+		Request: Request{
+			UID:         string(cr.UID),
+			Namespace:   cr.Namespace,
+			Name:        cr.Name,
+			IssuerName:  cr.Spec.IssuerRef.Name,
+			IssuerKind:  cr.Spec.IssuerRef.Kind,
+			IssuerGroup: cr.Spec.IssuerRef.Group,
+		},
+		Requester: Requester{
+			Username: cr.Spec.Username,
+			UID:      cr.Spec.UID,
+			Groups:   cr.Spec.Groups,
+		},
+		Message: message,
+		Version: Version{App: version.AppVersion, Commit: version.GitCommit},
+	}
+
+	if decision == DecisionApproved {
+		entry.Policy = response.Policy
+	}
+	for _, denial := range response.Denials {
+		entry.Denials = append(entry.Denials, Denial(denial))
+	}
+
+	if csr, err := utilpki.DecodeX509CertificateRequestBytes(cr.Spec.Request); err == nil {
+		entry.Subject = subjectFor(cr, csr)
+	}
+
+	return entry
+}
+
+// subjectFor returns the subject, SANs and public key of the request.
+func subjectFor(cr *cmapi.CertificateRequest, csr *x509.CertificateRequest) *Subject {
+	subject := &Subject{
+		CommonName:     csr.Subject.CommonName,
+		DNSNames:       csr.DNSNames,
+		EmailAddresses: csr.EmailAddresses,
+		IsCA:           cr.Spec.IsCA,
+		KeyAlgorithm:   csr.PublicKeyAlgorithm.String(),
+	}
+	for _, ip := range csr.IPAddresses {
+		subject.IPAddresses = append(subject.IPAddresses, ip.String())
+	}
+	for _, uri := range csr.URIs {
+		subject.URIs = append(subject.URIs, uri.String())
+	}
+	if cr.Spec.Duration != nil {
+		subject.Duration = cr.Spec.Duration.Duration.String()
+	}
+
+	switch key := csr.PublicKey.(type) {
+	case *rsa.PublicKey:
+		subject.KeySize = key.N.BitLen()
+	case *ecdsa.PublicKey:
+		subject.KeySize = key.Curve.Params().BitSize
+	case ed25519.PublicKey:
+		subject.KeySize = len(key) * 8
+	}
+
+	return subject
+}
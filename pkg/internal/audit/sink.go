@@ -0,0 +1,152 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// Sink writes audit entries to a destination.
+type Sink interface {
+	// Write writes a batch of entries to the destination. Either all entries
+	// are written, or an error is returned and the batch may be written again.
+	Write(ctx context.Context, entries []Entry) error
+
+	// Close releases the resources of the sink. Write is not called after
+	// Close.
+	Close() error
+}
+
+// NewSink returns the Sink described by spec, which is one of:
+//   - "stdout" to write JSON lines to standard output
+//   - "file:<path>" to append JSON lines to the file at path
+//   - an http:// or https:// URL to POST batches of JSON lines to
+func NewSink(spec string) (Sink, error) {
+	switch {
+	case spec == "stdout":
+		return NewWriterSink(os.Stdout), nil
+
+	case strings.HasPrefix(spec, "file:"):
+		path := strings.TrimPrefix(spec, "file:")
+		if len(path) == 0 {
+			return nil, fmt.Errorf("audit sink %q has no file path", spec)
+		}
+		// #nosec G302 G304 -- The audit log is read by log shippers, and the
+		// path is configured by the operator.
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open audit log file: %w", err)
+		}
+		return &writerSink{w: f, closer: f}, nil
+
+	case strings.HasPrefix(spec, "http://"), strings.HasPrefix(spec, "https://"):
+		return NewHTTPSink(spec, &http.Client{Timeout: 10 * time.Second}), nil
+
+	default:
+		return nil, fmt.Errorf(`unsupported audit sink %q, must be "stdout", "file:<path>", or an http(s) URL`, spec)
+	}
+}
+
+// writerSink writes entries as JSON lines to a writer.
+type writerSink struct {
+	w      io.Writer
+	closer io.Closer
+}
+
+// NewWriterSink returns a Sink which writes entries as JSON lines to w.
+func NewWriterSink(w io.Writer) Sink {
+	return &writerSink{w: w}
+}
+
+func (s *writerSink) Write(_ context.Context, entries []Entry) error {
+	body, err := encode(entries)
+	if err != nil {
+		return err
+	}
+	_, err = s.w.Write(body)
+	return err
+}
+
+func (s *writerSink) Close() error {
+	if s.closer == nil {
+		return nil
+	}
+	return s.closer.Close()
+}
+
+// httpSink POSTs batches of entries as JSON lines to an HTTP endpoint.
+type httpSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPSink returns a Sink which POSTs batches of entries as JSON lines to
+// url. Any response other than 2xx is an error.
+func NewHTTPSink(url string, client *http.Client) Sink {
+	return &httpSink{url: url, client: client}
+}
+
+func (s *httpSink) Write(ctx context.Context, entries []Entry) error {
+	body, err := encode(entries)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("audit endpoint responded with status %s", resp.Status)
+	}
+
+	return nil
+}
+
+func (s *httpSink) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
+
+// encode encodes the entries as JSON lines.
+func encode(entries []Entry) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, entry := range entries {
+		if err := enc.Encode(entry); err != nil {
+			return nil, fmt.Errorf("failed to encode audit entry: %w", err)
+		}
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/cert-manager/approver-policy/pkg/internal/approver/manager/predicate"
+	"github.com/cert-manager/approver-policy/pkg/internal/cmd/options"
+)
+
+// newAuthorizer returns the Authorizer deciding whether the user of a
+// CertificateRequest is bound to a CertificateRequestPolicy. Authorizers
+// which hold state derived from RBAC are invalidated by events from the
+// RBAC informers of the manager cache, which run on every replica, so that
+// the webhooks of replicas which are not the leader are also invalidated. The
+// certificaterequests controller also invalidates the authorizer before
+// enqueueing requests for an RBAC change, since event handlers have no order.
+func newAuthorizer(ctx context.Context, opts *options.Options, mgr manager.Manager) (predicate.Authorizer, error) {
+	var (
+		authorizer predicate.Invalidator
+
+		// objects are the RBAC objects whose informers invalidate the
+		// authorizer.
+		objects []client.Object
+	)
+
+	switch opts.RBACBoundAuthorizer {
+	case "subjectaccessreview":
+		sar := predicate.NewSubjectAccessReviewAuthorizer(mgr.GetClient())
+		if opts.SubjectAccessReviewCacheTTL <= 0 {
+			return sar, nil
+		}
+		authorizer = predicate.NewCachedAuthorizer(sar, opts.SubjectAccessReviewCacheTTL, clock.RealClock{})
+
+		// Share the metadata informers of the certificaterequests controller.
+		for _, kind := range []string{"Role", "RoleBinding", "ClusterRole", "ClusterRoleBinding"} {
+			obj := new(metav1.PartialObjectMetadata)
+			obj.SetGroupVersionKind(rbacv1.SchemeGroupVersion.WithKind(kind))
+			objects = append(objects, obj)
+		}
+
+	case "rbac":
+		authorizer = predicate.NewRBACAuthorizer(mgr.GetCache())
+
+		// The snapshot is built from the typed informers, so must be
+		// invalidated by them rather than the metadata informers, which may
+		// observe a change first.
+		objects = []client.Object{new(rbacv1.Role), new(rbacv1.RoleBinding), new(rbacv1.ClusterRole), new(rbacv1.ClusterRoleBinding)}
+
+	default:
+		return nil, fmt.Errorf("unsupported --rbac-bound-authorizer %q, must be one of \"subjectaccessreview\" or \"rbac\"", opts.RBACBoundAuthorizer)
+	}
+
+	// Roles and RoleBindings only affect their own namespace. ClusterRoles and
+	// ClusterRoleBindings have no namespace, which invalidates all namespaces.
+	invalidate := func(obj interface{}) {
+		if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		accessor, err := meta.Accessor(obj)
+		if err != nil {
+			authorizer.Invalidate("")
+			return
+		}
+		authorizer.Invalidate(accessor.GetNamespace())
+	}
+
+	for _, obj := range objects {
+		informer, err := mgr.GetCache().GetInformer(ctx, obj)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build %T informer: %w", obj, err)
+		}
+		if _, err := informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+			AddFunc:    invalidate,
+			UpdateFunc: func(_, obj interface{}) { invalidate(obj) },
+			DeleteFunc: invalidate,
+		}); err != nil {
+			return nil, fmt.Errorf("failed to add %T event handler: %w", obj, err)
+		}
+	}
+
+	return authorizer, nil
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"time"
 
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	servertls "github.com/cert-manager/cert-manager/pkg/server/tls"
@@ -30,9 +31,12 @@ import (
 	ctrlwebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver"
+	"github.com/cert-manager/approver-policy/pkg/internal/audit"
 	"github.com/cert-manager/approver-policy/pkg/internal/cmd/options"
 	"github.com/cert-manager/approver-policy/pkg/internal/controllers"
 	"github.com/cert-manager/approver-policy/pkg/internal/metrics"
+	"github.com/cert-manager/approver-policy/pkg/internal/tracing"
 	"github.com/cert-manager/approver-policy/pkg/internal/webhook"
 	"github.com/cert-manager/approver-policy/pkg/registry"
 )
@@ -60,6 +64,20 @@ func NewCommand(ctx context.Context) *cobra.Command {
 
 			ctrl.SetLogger(mlog)
 
+			shutdownTracing, err := tracing.Setup(ctx, opts.Tracing)
+			if err != nil {
+				return fmt.Errorf("failed to set up tracing: %w", err)
+			}
+			defer func() {
+				// The command context is cancelled by now, so flush any remaining
+				// spans with a fresh context.
+				shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				if err := shutdownTracing(shutdownCtx); err != nil {
+					log.Error(err, "failed to shut down tracing")
+				}
+			}()
+
 			certificateSource := &servertls.DynamicSource{
 				DNSNames: []string{fmt.Sprintf("%s.%s.svc", opts.Webhook.ServiceName, opts.Webhook.CASecretNamespace)},
 				Authority: &authority.DynamicAuthority{
@@ -102,12 +120,21 @@ func NewCommand(ctx context.Context) *cobra.Command {
 				return err
 			}
 
-			metrics.RegisterMetrics(ctx, opts.Logr.WithName("metrics"), mgr.GetCache())
+			metrics.RegisterMetrics(ctx, opts.Logr.WithName("metrics"), mgr.GetCache(), opts.MetricsPendingApprovalThreshold)
+
+			authorizer, err := newAuthorizer(ctx, opts, mgr)
+			if err != nil {
+				return fmt.Errorf("failed to build rbac bound authorizer: %w", err)
+			}
 
 			if err := webhook.Register(ctx, webhook.Options{
-				Log:      opts.Logr,
-				Webhooks: registry.Shared.Webhooks(),
-				Manager:  mgr,
+				Log:                     opts.Logr,
+				Webhooks:                registry.Shared.Webhooks(),
+				Evaluators:              registry.Shared.Evaluators(),
+				PluginSchemas:           approver.PluginSchemas(registry.Shared.Approvers()...),
+				Authorizer:              authorizer,
+				CertManagerFeatureGates: opts.Webhook.CertManagerFeatureGates,
+				Manager:                 mgr,
 			}); err != nil {
 				return fmt.Errorf("failed to register webhook: %w", err)
 			}
@@ -121,11 +148,33 @@ func NewCommand(ctx context.Context) *cobra.Command {
 			}
 			log.Info("all approvers ready...")
 
+			var auditRecorder audit.Interface
+			if len(opts.AuditSink) > 0 {
+				sink, err := audit.NewSink(opts.AuditSink)
+				if err != nil {
+					return fmt.Errorf("failed to create audit sink: %w", err)
+				}
+				recorder := audit.NewRecorder(opts.Logr.WithName("audit"), sink, opts.AuditBufferSize)
+				if err := mgr.Add(recorder); err != nil {
+					return fmt.Errorf("failed to add audit recorder: %w", err)
+				}
+				auditRecorder = recorder
+			}
+
 			if err := controllers.AddControllers(ctx, controllers.Options{
 				Log:         opts.Logr.WithName("controller"),
 				Manager:     mgr,
 				Evaluators:  registry.Shared.Evaluators(),
 				Reconcilers: registry.Shared.Reconcilers(),
+				Authorizer:  authorizer,
+
+				DenyUnprocessedAfter:              opts.DenyUnprocessedAfter,
+				DenyUnprocessedExcludedNamespaces: opts.DenyUnprocessedExcludedNamespaces,
+				ComplianceCheckInterval:           opts.ComplianceCheckInterval,
+				Audit:                             auditRecorder,
+				PolicyReports:                     opts.PolicyReports,
+				PolicyStatisticsInterval:          opts.PolicyStatisticsInterval,
+				PolicyStatisticsSubjects:          opts.PolicyStatisticsSubjects,
 			}); err != nil {
 				return fmt.Errorf("failed to add controllers: %w", err)
 			}
@@ -137,5 +186,10 @@ func NewCommand(ctx context.Context) *cobra.Command {
 
 	opts.Prepare(cmd, registry.Shared.Approvers()...)
 
+	cmd.AddCommand(newPluginsCommand(registry.Shared.Approvers()...))
+	cmd.AddCommand(newLintCommand(registry.Shared.Approvers()...))
+	cmd.AddCommand(newWhoCanUseCommand())
+	cmd.AddCommand(newWhoCanApproveCommand())
+
 	return cmd
 }
@@ -0,0 +1,151 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver"
+	"github.com/cert-manager/approver-policy/pkg/internal/lint"
+)
+
+// newLintCommand returns a command which lints CertificateRequestPolicies in
+// files, or in the cluster, for risky patterns and problems which would cause
+// them to be rejected when applied.
+func newLintCommand(approvers ...approver.Interface) *cobra.Command {
+	var (
+		output  string
+		failOn  string
+		cluster bool
+
+		kubeConfigFlags = genericclioptions.NewConfigFlags(true)
+	)
+
+	cmd := &cobra.Command{
+		Use:   "lint [FILE|DIR|-]...",
+		Short: "Lint CertificateRequestPolicies for risky patterns",
+		Long: `Lint CertificateRequestPolicies for risky patterns, and problems which would
+cause them to be rejected when applied.
+
+Policies are read from YAML or JSON files, directories of them, or stdin
+("-"), or from the cluster with --from-cluster. Plugin flags should be set as
+they are on the approver-policy deployment, since plugins validate policies
+against them.
+
+Rules:
+` + ruleCatalogue(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			switch output {
+			case "text", "json", "sarif":
+			default:
+				return fmt.Errorf("unsupported output format %q, must be one of \"text\", \"json\" or \"sarif\"", output)
+			}
+			switch lint.Severity(failOn) {
+			case lint.SeverityError, lint.SeverityWarning, "none":
+			default:
+				return fmt.Errorf("unsupported --fail-on %q, must be one of \"error\", \"warning\" or \"none\"", failOn)
+			}
+			if cluster == (len(args) > 0) {
+				return errors.New("either files to lint or --from-cluster must be given")
+			}
+
+			// Arguments are valid, so don't print the usage on lint failures.
+			// Errors are printed by the caller.
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+
+			var (
+				policies []lint.Policy
+				err      error
+			)
+			if cluster {
+				restConfig, err := kubeConfigFlags.ToRESTConfig()
+				if err != nil {
+					return fmt.Errorf("failed to build kubernetes rest config: %w", err)
+				}
+				cl, err := client.New(restConfig, client.Options{Scheme: policyapi.GlobalScheme})
+				if err != nil {
+					return fmt.Errorf("failed to build kubernetes client: %w", err)
+				}
+				policies, err = lint.LoadCluster(cmd.Context(), cl)
+				if err != nil {
+					return err
+				}
+			} else {
+				policies, err = lint.LoadFiles(cmd.InOrStdin(), args...)
+				if err != nil {
+					return err
+				}
+			}
+
+			findings, err := lint.New(approvers...).Lint(cmd.Context(), policies)
+			if err != nil {
+				return err
+			}
+
+			switch output {
+			case "json":
+				err = lint.WriteJSON(cmd.OutOrStdout(), findings)
+			case "sarif":
+				err = lint.WriteSARIF(cmd.OutOrStdout(), findings)
+			default:
+				err = lint.WriteText(cmd.OutOrStdout(), findings)
+			}
+			if err != nil {
+				return err
+			}
+
+			errs, warnings := lint.Count(findings, lint.SeverityError), lint.Count(findings, lint.SeverityWarning)
+			if errs > 0 && failOn != "none" || warnings > 0 && failOn == string(lint.SeverityWarning) {
+				return fmt.Errorf("lint found %d errors and %d warnings", errs, warnings)
+			}
+
+			return nil
+		},
+	}
+
+	fs := cmd.Flags()
+	fs.StringVarP(&output, "output", "o", "text", "Output format (text, json or sarif)")
+	fs.StringVar(&failOn, "fail-on", string(lint.SeverityError), "Exit with a non-zero code if any finding of this severity or higher is found (error, warning or none)")
+	fs.BoolVar(&cluster, "from-cluster", false, "Lint the CertificateRequestPolicies in the cluster, instead of files")
+	kubeConfigFlags.AddFlags(fs)
+	for _, a := range approvers {
+		a.RegisterFlags(fs)
+	}
+
+	// Don't inherit the help and usage of the root command, which prints the
+	// flags of the controller.
+	cmd.SetHelpFunc(new(cobra.Command).HelpFunc())
+	cmd.SetUsageFunc(new(cobra.Command).UsageFunc())
+
+	return cmd
+}
+
+// ruleCatalogue returns the lint rules, one per line.
+func ruleCatalogue() string {
+	var s string
+	for _, rule := range lint.Rules() {
+		s += fmt.Sprintf("  %s %-27s %-8s %s\n", rule.ID, rule.Name, rule.Severity, rule.Description)
+	}
+	return s
+}
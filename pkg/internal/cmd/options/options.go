@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -32,6 +33,8 @@ import (
 	"k8s.io/klog/v2"
 
 	"github.com/cert-manager/approver-policy/pkg/approver"
+	"github.com/cert-manager/approver-policy/pkg/internal/tracing"
+	"github.com/cert-manager/approver-policy/pkg/internal/webhook"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -58,6 +61,25 @@ type Options struct {
 	// which will be served on the HTTP path '/readyz'.
 	ReadyzAddress string
 
+	// DenyUnprocessedAfter is the duration after creation at which
+	// CertificateRequests that no CertificateRequestPolicy is applicable to are
+	// denied. Zero disables denying unprocessed requests.
+	DenyUnprocessedAfter time.Duration
+
+	// DenyUnprocessedExcludedNamespaces are namespaces whose
+	// CertificateRequests are never denied for being unprocessed.
+	DenyUnprocessedExcludedNamespaces []string
+
+	// ComplianceCheckInterval is the interval at which approved
+	// CertificateRequests are re-reviewed against the current
+	// CertificateRequestPolicies. Zero disables the compliance check.
+	ComplianceCheckInterval time.Duration
+
+	// MetricsPendingApprovalThreshold is the age after which
+	// CertificateRequests which are neither approved nor denied are reported as
+	// pending approval in metrics.
+	MetricsPendingApprovalThreshold time.Duration
+
 	// RestConfig is the shared base rest config to connect to the Kubernetes
 	// API.
 	RestConfig *rest.Config
@@ -68,6 +90,39 @@ type Options struct {
 	// Webhook are options specific to the Kubernetes Webhook.
 	Webhook
 
+	// Tracing are options for exporting OpenTelemetry traces.
+	Tracing tracing.Options
+
+	// AuditSink is the destination of the audit log of CertificateRequest
+	// approvals and denials. Empty disables the audit log.
+	AuditSink string
+
+	// AuditBufferSize is the number of audit entries buffered before further
+	// entries are dropped.
+	AuditBufferSize int
+
+	// PolicyReports enables writing a PolicyReport to every namespace
+	// summarising the approvals and denials of its CertificateRequests.
+	PolicyReports bool
+
+	// PolicyStatisticsInterval is the interval at which the usage statistics
+	// of CertificateRequestPolicies are written to their status. Zero disables
+	// policy statistics.
+	PolicyStatisticsInterval time.Duration
+
+	// PolicyStatisticsSubjects lists the subjects bound to each
+	// CertificateRequestPolicy via RBAC in its statistics.
+	PolicyStatisticsSubjects bool
+
+	// RBACBoundAuthorizer is the authorizer deciding whether the user of a
+	// CertificateRequest is bound to a CertificateRequestPolicy, either
+	// "subjectaccessreview" or "rbac".
+	RBACBoundAuthorizer string
+
+	// SubjectAccessReviewCacheTTL is the duration for which the results of
+	// SubjectAccessReviews are cached. Zero disables the cache.
+	SubjectAccessReviewCacheTTL time.Duration
+
 	// Logr is the shared base logger.
 	Logr logr.Logger
 }
@@ -136,6 +191,10 @@ type Webhook struct {
 	// LeafDuration for webhook server TLS certificates.
 	// Defaults to 7 days.
 	LeafDuration time.Duration
+
+	// CertManagerFeatureGates are the feature gates of the cert-manager
+	// controller which decide the CSR cert-manager builds for a Certificate.
+	CertManagerFeatureGates map[string]bool
 }
 
 func New() *Options {
@@ -179,6 +238,7 @@ func (o *Options) addFlags(cmd *cobra.Command, approvers ...approver.Interface)
 	o.addAppFlags(nfs.FlagSet("App"))
 	o.addLoggingFlags(nfs.FlagSet("Logging"))
 	o.addWebhookFlags(nfs.FlagSet("Webhook"))
+	o.addTracingFlags(nfs.FlagSet("Tracing"))
 	o.kubeConfigFlags = genericclioptions.NewConfigFlags(true)
 	o.kubeConfigFlags.AddFlags(nfs.FlagSet("Kubernetes"))
 
@@ -190,12 +250,14 @@ func (o *Options) addFlags(cmd *cobra.Command, approvers ...approver.Interface)
 	cmd.SetUsageFunc(func(cmd *cobra.Command) error {
 		fmt.Fprintf(cmd.OutOrStderr(), usageFmt, cmd.UseLine())
 		cliflag.PrintSections(cmd.OutOrStderr(), nfs, 0)
+		PrintPluginSchemas(cmd.OutOrStderr(), approvers...)
 		return nil
 	})
 
 	cmd.SetHelpFunc(func(cmd *cobra.Command, args []string) {
 		fmt.Fprintf(cmd.OutOrStdout(), "%s\n\n"+usageFmt, cmd.Long, cmd.UseLine())
 		cliflag.PrintSections(cmd.OutOrStdout(), nfs, 0)
+		PrintPluginSchemas(cmd.OutOrStdout(), approvers...)
 	})
 
 	fs := cmd.Flags()
@@ -212,8 +274,55 @@ func (o *Options) addAppFlags(fs *pflag.FlagSet) {
 		`TCP address for exposing HTTP Prometheus metrics which will be served on the HTTP path '/metrics'. The value "0" will
 	 disable exposing metrics.`)
 
+	fs.StringVar(&o.AuditSink, "audit-sink", "",
+		`Destination of the JSON lines audit log of CertificateRequest approvals and denials. One of "stdout", `+
+			`"file:<path>", or an http(s) URL that batches of entries are POSTed to. Empty disables the audit log.`)
+
+	fs.IntVar(&o.AuditBufferSize, "audit-buffer-size", 1000,
+		"Number of audit entries buffered to be written to the audit sink. Entries are dropped while the buffer is full.")
+
+	fs.BoolVar(&o.PolicyReports, "policy-reports", false,
+		"Write a wgpolicyk8s.io PolicyReport to every namespace summarising the approvals and denials of its "+
+			"CertificateRequests. Requires the PolicyReport CRD to be installed.")
+
+	fs.DurationVar(&o.PolicyStatisticsInterval, "policy-statistics-interval", 0,
+		"Interval at which the approval and denial counts of CertificateRequestPolicies, and the number of namespaces "+
+			"and subjects bound to them via RBAC, are written to their status. Decisions are batched between writes. "+
+			"Enabling this caches all Roles, ClusterRoles and bindings. The value 0 disables policy statistics.")
+
+	fs.BoolVar(&o.PolicyStatisticsSubjects, "policy-statistics-subjects", false,
+		"List the users, groups and service accounts bound to each CertificateRequestPolicy via RBAC, and the "+
+			"namespaces they are bound in, in its status. Only used with --policy-statistics-interval.")
+
+	fs.StringVar(&o.RBACBoundAuthorizer, "rbac-bound-authorizer", "subjectaccessreview",
+		`Authorizer deciding whether the user of a CertificateRequest is bound to a CertificateRequestPolicy. `+
+			`"subjectaccessreview" creates a SubjectAccessReview against the API server. "rbac" evaluates the cached `+
+			`Roles, ClusterRoles and bindings in process, without considering other authorization modes such as webhooks.`)
+
+	fs.DurationVar(&o.SubjectAccessReviewCacheTTL, "subjectaccessreview-cache-ttl", 0,
+		"Duration for which the results of SubjectAccessReviews are cached, keyed by user, groups, namespace and policy. "+
+			"Cached results are discarded when the RBAC of their namespace changes. Only used with "+
+			`--rbac-bound-authorizer=subjectaccessreview. The value 0 disables the cache.`)
+
+	fs.DurationVar(&o.MetricsPendingApprovalThreshold, "metrics-pending-approval-threshold", 5*time.Minute,
+		"Age after which CertificateRequests that are neither approved nor denied are reported by the "+
+			"approverpolicy_certificaterequest_pending_approval_count metric.")
+
 	fs.StringVar(&o.ReadyzAddress, "readiness-probe-bind-address", ":6060",
 		"TCP address for exposing the HTTP readiness probe which will be served on the HTTP path '/readyz'.")
+
+	fs.DurationVar(&o.DenyUnprocessedAfter, "deny-unprocessed-after", 0,
+		"Duration after creation at which CertificateRequests that no CertificateRequestPolicy is applicable to are denied. "+
+			"The value 0 disables denying unprocessed requests, leaving them neither approved nor denied.")
+
+	fs.StringSliceVar(&o.DenyUnprocessedExcludedNamespaces, "deny-unprocessed-excluded-namespaces", nil,
+		"Namespaces whose CertificateRequests are never denied for being unprocessed. Only used with --deny-unprocessed-after.")
+
+	fs.DurationVar(&o.ComplianceCheckInterval, "compliance-check-interval", 0,
+		"Interval at which approved CertificateRequests are re-reviewed against the current CertificateRequestPolicies. "+
+			"Requests that would no longer be approved are reported on the status of the approving policy and as metrics. "+
+			"Requests whose requester is no longer bound to any applicable policy are reported as drifted. "+
+			"The value 0 disables the compliance check.")
 }
 
 func (o *Options) addLoggingFlags(fs *pflag.FlagSet) {
@@ -226,6 +335,20 @@ func (o *Options) addLoggingFlags(fs *pflag.FlagSet) {
 		"Log level (1-5).")
 }
 
+func (o *Options) addTracingFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.Tracing.Endpoint,
+		"tracing-otlp-endpoint", "",
+		"Host and port of an OTLP gRPC collector to export OpenTelemetry traces to. Tracing is disabled if empty.")
+
+	fs.BoolVar(&o.Tracing.Insecure,
+		"tracing-otlp-insecure", false,
+		"Connect to the OTLP collector without TLS.")
+
+	fs.Float64Var(&o.Tracing.SampleRatio,
+		"tracing-sample-ratio", 1,
+		"Ratio of traces to sample, between 0 and 1. Traces with a sampled parent are always sampled.")
+}
+
 func (o *Options) addWebhookFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.Webhook.Host,
 		"webhook-host", "0.0.0.0",
@@ -255,6 +378,12 @@ func (o *Options) addWebhookFlags(fs *pflag.FlagSet) {
 		"webhook-leaf-cert-duration", time.Hour*24*7,
 		"Duration for webhook server TLS certificates. Defaults to 7 days.")
 
+	fs.Var(cliflag.NewMapStringBool(&o.Webhook.CertManagerFeatureGates),
+		"webhook-cert-manager-feature-gates",
+		"Feature gates of the cert-manager controller, which decide the CSR that is built for a Certificate "+
+			"when Certificates are validated on admission. Must match the --feature-gates of the cert-manager controller. "+
+			"Options are: "+strings.Join(webhook.CertManagerFeatureGates(), ", ")+".")
+
 	var deprecatedCertDir string
 	fs.StringVar(&deprecatedCertDir,
 		"webhook-certificate-dir", "/tmp",
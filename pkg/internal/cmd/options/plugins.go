@@ -0,0 +1,76 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/cert-manager/approver-policy/pkg/approver"
+)
+
+// PrintPluginSchemas prints a human readable description of the plugin values
+// accepted by each of the given Approvers which declare a schema. Nothing is
+// printed if no Approver declares a schema.
+func PrintPluginSchemas(w io.Writer, approvers ...approver.Interface) {
+	var printed bool
+	for _, a := range approvers {
+		provider, ok := a.(approver.PluginSchemaProvider)
+		if !ok {
+			continue
+		}
+		if !printed {
+			fmt.Fprintf(w, "\nPlugin values (spec.plugins.<name>.values):\n")
+			printed = true
+		}
+
+		schema := provider.PluginSchema()
+		fmt.Fprintf(w, "\n  %s:\n", a.Name())
+		if len(schema.Description) > 0 {
+			fmt.Fprintf(w, "      %s\n", schema.Description)
+		}
+		for _, value := range schema.Values {
+			fmt.Fprintf(w, "      %s %s\n", value.Key, pluginValueAttributes(value))
+			if len(value.Description) > 0 {
+				fmt.Fprintf(w, "          %s\n", value.Description)
+			}
+		}
+	}
+}
+
+// pluginValueAttributes returns the type, and when set, the required, enum
+// and default attributes of the value.
+func pluginValueAttributes(value approver.PluginValueSchema) string {
+	typ := value.Type
+	if len(typ) == 0 {
+		typ = approver.PluginValueTypeString
+	}
+
+	attrs := []string{string(typ)}
+	if value.Required {
+		attrs = append(attrs, "required")
+	}
+	if len(value.Enum) > 0 {
+		attrs = append(attrs, fmt.Sprintf("one of: %s", strings.Join(value.Enum, ", ")))
+	}
+	if len(value.Default) > 0 {
+		attrs = append(attrs, fmt.Sprintf("default: %q", value.Default))
+	}
+
+	return "(" + strings.Join(attrs, ", ") + ")"
+}
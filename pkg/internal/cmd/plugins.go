@@ -0,0 +1,92 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cert-manager/approver-policy/pkg/approver"
+	"github.com/cert-manager/approver-policy/pkg/internal/cmd/options"
+)
+
+// pluginInfo is the JSON representation of a registered plugin.
+type pluginInfo struct {
+	Name   string                 `json:"name"`
+	Schema *approver.PluginSchema `json:"schema,omitempty"`
+}
+
+// newPluginsCommand returns a command which lists the plugins registered to
+// this approver-policy build, along with the schema of their values.
+func newPluginsCommand(approvers ...approver.Interface) *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "plugins",
+		Short: "List the plugins registered to approver-policy, and the values they accept",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var plugins []approver.Interface
+			for _, a := range approvers {
+				if name := a.Name(); name != "allowed" && name != "constraints" {
+					plugins = append(plugins, a)
+				}
+			}
+			sort.Slice(plugins, func(i, j int) bool {
+				return plugins[i].Name() < plugins[j].Name()
+			})
+
+			switch output {
+			case "text":
+				for _, plugin := range plugins {
+					fmt.Fprintln(cmd.OutOrStdout(), plugin.Name())
+				}
+				options.PrintPluginSchemas(cmd.OutOrStdout(), plugins...)
+				return nil
+
+			case "json":
+				schemas := approver.PluginSchemas(plugins...)
+				infos := make([]pluginInfo, 0, len(plugins))
+				for _, plugin := range plugins {
+					info := pluginInfo{Name: plugin.Name()}
+					if schema, ok := schemas[plugin.Name()]; ok {
+						info.Schema = &schema
+					}
+					infos = append(infos, info)
+				}
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				return enc.Encode(infos)
+
+			default:
+				return fmt.Errorf("unsupported output format %q, must be one of \"text\" or \"json\"", output)
+			}
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "text", "Output format (text or json)")
+
+	// Don't inherit the help and usage of the root command, which prints the
+	// flags of the controller.
+	cmd.SetHelpFunc(new(cobra.Command).HelpFunc())
+	cmd.SetUsageFunc(new(cobra.Command).UsageFunc())
+
+	return cmd
+}
@@ -0,0 +1,267 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/spf13/cobra"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/internal/approver/manager/predicate"
+	"github.com/cert-manager/approver-policy/pkg/internal/rbac"
+)
+
+// grantInfo is the text and JSON representation of a subject granted
+// permission to use a CertificateRequestPolicy.
+type grantInfo struct {
+	Policy    string         `json:"policy"`
+	Namespace string         `json:"namespace,omitempty"`
+	Subject   rbacv1.Subject `json:"subject"`
+	Binding   string         `json:"binding"`
+	Role      string         `json:"role"`
+}
+
+// newWhoCanUseCommand returns a command which lists the subjects bound to a
+// CertificateRequestPolicy via RBAC.
+func newWhoCanUseCommand() *cobra.Command {
+	var (
+		output string
+
+		kubeConfigFlags = genericclioptions.NewConfigFlags(true)
+	)
+
+	cmd := &cobra.Command{
+		Use:   "who-can-use POLICY",
+		Short: "List the users, groups and service accounts bound to a CertificateRequestPolicy",
+		Long: `List the users, groups and service accounts which are granted the "use" verb
+on a CertificateRequestPolicy via RBAC, directly or through aggregated
+ClusterRoles, along with the namespaces they are bound in and the binding
+which grants it. Members of a listed group are also bound.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateWhoCanOutput(output); err != nil {
+				return err
+			}
+			cmd.SilenceUsage = true
+
+			cl, err := newWhoCanClient(kubeConfigFlags)
+			if err != nil {
+				return err
+			}
+
+			var policy policyapi.CertificateRequestPolicy
+			if err := cl.Get(cmd.Context(), client.ObjectKey{Name: args[0]}, &policy); err != nil {
+				return fmt.Errorf("failed to get CertificateRequestPolicy %q: %w", args[0], err)
+			}
+
+			snapshot, err := rbac.List(cmd.Context(), cl)
+			if err != nil {
+				return err
+			}
+
+			return writeGrants(cmd.OutOrStdout(), output, grantInfos(policy.Name, snapshot.WhoCan(rbac.UsePolicy(policy.Name))))
+		},
+	}
+
+	addWhoCanFlags(cmd, &output, kubeConfigFlags)
+
+	return cmd
+}
+
+// newWhoCanApproveCommand returns a command which lists the subjects whose
+// CertificateRequest would be considered by a CertificateRequestPolicy, for a
+// hypothetical request to an issuer in a namespace.
+func newWhoCanApproveCommand() *cobra.Command {
+	var (
+		output    string
+		issuerRef cmmeta.ObjectReference
+
+		kubeConfigFlags = genericclioptions.NewConfigFlags(true)
+	)
+
+	cmd := &cobra.Command{
+		Use:   "who-can-approve --namespace NAMESPACE --issuer-name NAME",
+		Short: "List who would have a CertificateRequest approved by a CertificateRequestPolicy",
+		Long: `List the CertificateRequestPolicies which are ready and select a hypothetical
+CertificateRequest for an issuer in a namespace, and the users, groups and
+service accounts bound to each of them via RBAC in that namespace.
+
+A CertificateRequest created by one of these subjects is approved if it also
+satisfies the allowed, constraints and plugin rules of one of the policies,
+which are not evaluated by this command.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateWhoCanOutput(output); err != nil {
+				return err
+			}
+			namespace, _, err := kubeConfigFlags.ToRawKubeConfigLoader().Namespace()
+			if err != nil {
+				return fmt.Errorf("failed to determine namespace: %w", err)
+			}
+			if len(issuerRef.Name) == 0 {
+				return errors.New("--issuer-name must be given")
+			}
+			cmd.SilenceUsage = true
+
+			cl, err := newWhoCanClient(kubeConfigFlags)
+			if err != nil {
+				return err
+			}
+
+			snapshot, err := rbac.List(cmd.Context(), cl)
+			if err != nil {
+				return err
+			}
+
+			cr := &cmapi.CertificateRequest{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace},
+				Spec:       cmapi.CertificateRequestSpec{IssuerRef: issuerRef},
+			}
+			grants, err := whoCanApprove(cmd.Context(), cl, snapshot, cr)
+			if err != nil {
+				return err
+			}
+
+			return writeGrants(cmd.OutOrStdout(), output, grants)
+		},
+	}
+
+	fs := cmd.Flags()
+	fs.StringVar(&issuerRef.Name, "issuer-name", "", "Name of the issuer of the request")
+	fs.StringVar(&issuerRef.Kind, "issuer-kind", cmapi.IssuerKind, "Kind of the issuer of the request")
+	fs.StringVar(&issuerRef.Group, "issuer-group", "cert-manager.io", "Group of the issuer of the request")
+	addWhoCanFlags(cmd, &output, kubeConfigFlags)
+
+	return cmd
+}
+
+// whoCanApprove returns the grants to use every CertificateRequestPolicy which
+// is ready and selects the request, which apply in the namespace of the
+// request. Grants are ordered by policy.
+func whoCanApprove(ctx context.Context, reader client.Reader, snapshot *rbac.Snapshot, cr *cmapi.CertificateRequest) ([]grantInfo, error) {
+	var policyList policyapi.CertificateRequestPolicyList
+	if err := reader.List(ctx, &policyList); err != nil {
+		return nil, fmt.Errorf("failed to list CertificateRequestPolicies: %w", err)
+	}
+
+	policies := policyList.Items
+	for _, p := range []predicate.Predicate{predicate.Ready, predicate.SelectorIssuerRef, predicate.SelectorNamespace(reader)} {
+		var err error
+		policies, err = p(ctx, cr, policies)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(policies, func(i, j int) bool {
+		return policies[i].Name < policies[j].Name
+	})
+
+	var grants []grantInfo
+	for _, policy := range policies {
+		grants = append(grants, grantInfos(policy.Name, rbac.GrantsIn(snapshot.WhoCan(rbac.UsePolicy(policy.Name)), cr.Namespace))...)
+	}
+
+	return grants, nil
+}
+
+// grantInfos returns the representation of the grants to use the policy.
+func grantInfos(policy string, grants []rbac.Grant) []grantInfo {
+	infos := make([]grantInfo, 0, len(grants))
+	for _, grant := range grants {
+		infos = append(infos, grantInfo{
+			Policy:    policy,
+			Namespace: grant.Namespace,
+			Subject:   grant.Subject,
+			Binding:   grant.BindingKind + "/" + grant.BindingName,
+			Role:      grant.RoleKind + "/" + grant.RoleName,
+		})
+	}
+	return infos
+}
+
+// writeGrants writes the grants in the output format.
+func writeGrants(w io.Writer, output string, grants []grantInfo) error {
+	if output == "json" {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(grants)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "POLICY\tNAMESPACE\tSUBJECT\tBINDING\tROLE")
+	for _, grant := range grants {
+		namespace := grant.Namespace
+		if len(namespace) == 0 {
+			namespace = "*"
+		}
+		subject := grant.Subject.Kind + "/" + grant.Subject.Name
+		if len(grant.Subject.Namespace) > 0 {
+			subject = grant.Subject.Kind + "/" + grant.Subject.Namespace + "/" + grant.Subject.Name
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", grant.Policy, namespace, subject, grant.Binding, grant.Role)
+	}
+	return tw.Flush()
+}
+
+// validateWhoCanOutput returns an error if the output format is unsupported.
+func validateWhoCanOutput(output string) error {
+	switch output {
+	case "text", "json":
+		return nil
+	default:
+		return fmt.Errorf("unsupported output format %q, must be one of \"text\" or \"json\"", output)
+	}
+}
+
+// newWhoCanClient returns a client for the cluster of the kubeconfig flags.
+func newWhoCanClient(kubeConfigFlags *genericclioptions.ConfigFlags) (client.Client, error) {
+	restConfig, err := kubeConfigFlags.ToRESTConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build kubernetes rest config: %w", err)
+	}
+	cl, err := client.New(restConfig, client.Options{Scheme: policyapi.GlobalScheme})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build kubernetes client: %w", err)
+	}
+	return cl, nil
+}
+
+// addWhoCanFlags adds the flags shared by the who-can commands.
+func addWhoCanFlags(cmd *cobra.Command, output *string, kubeConfigFlags *genericclioptions.ConfigFlags) {
+	fs := cmd.Flags()
+	fs.StringVarP(output, "output", "o", "text", "Output format (text or json)")
+	kubeConfigFlags.AddFlags(fs)
+
+	// Don't inherit the help and usage of the root command, which prints the
+	// flags of the controller.
+	cmd.SetHelpFunc(new(cobra.Command).HelpFunc())
+	cmd.SetUsageFunc(new(cobra.Command).UsageFunc())
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/internal/rbac"
+)
+
+func Test_whoCanApprove(t *testing.T) {
+	ready := policyapi.CertificateRequestPolicyStatus{Conditions: []policyapi.CertificateRequestPolicyCondition{
+		{Type: policyapi.CertificateRequestPolicyConditionReady, Status: corev1.ConditionTrue},
+	}}
+	policy := func(name string, selector policyapi.CertificateRequestPolicySelector, status policyapi.CertificateRequestPolicyStatus) *policyapi.CertificateRequestPolicy {
+		return &policyapi.CertificateRequestPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       policyapi.CertificateRequestPolicySpec{Selector: selector},
+			Status:     status,
+		}
+	}
+	user := func(name string) rbacv1.Subject {
+		return rbacv1.Subject{Kind: rbacv1.UserKind, Name: name}
+	}
+
+	cl := fakeclient.NewClientBuilder().
+		WithScheme(policyapi.GlobalScheme).
+		WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test-ns"}},
+			policy("selects-issuer", policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{Name: ptr.To("test-*")}}, ready),
+			policy("other-issuer", policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{Name: ptr.To("other")}}, ready),
+			policy("other-namespace", policyapi.CertificateRequestPolicySelector{Namespace: &policyapi.CertificateRequestPolicySelectorNamespace{MatchNames: []string{"other-ns"}}}, ready),
+			policy("not-ready", policyapi.CertificateRequestPolicySelector{}, policyapi.CertificateRequestPolicyStatus{}),
+			policy("any", policyapi.CertificateRequestPolicySelector{}, ready),
+		).
+		Build()
+
+	useAll := rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{Name: "use-all"},
+		Rules:      []rbacv1.PolicyRule{{APIGroups: []string{"policy.cert-manager.io"}, Resources: []string{"certificaterequestpolicies"}, Verbs: []string{"use"}}},
+	}
+	snapshot := rbac.NewSnapshot([]rbacv1.ClusterRole{useAll}, nil,
+		[]rbacv1.ClusterRoleBinding{
+			{ObjectMeta: metav1.ObjectMeta{Name: "everyone"}, RoleRef: rbacv1.RoleRef{Kind: "ClusterRole", Name: "use-all"}, Subjects: []rbacv1.Subject{user("admin")}},
+		},
+		[]rbacv1.RoleBinding{
+			{ObjectMeta: metav1.ObjectMeta{Namespace: "test-ns", Name: "test"}, RoleRef: rbacv1.RoleRef{Kind: "ClusterRole", Name: "use-all"}, Subjects: []rbacv1.Subject{user("test-user")}},
+			{ObjectMeta: metav1.ObjectMeta{Namespace: "other-ns", Name: "other"}, RoleRef: rbacv1.RoleRef{Kind: "ClusterRole", Name: "use-all"}, Subjects: []rbacv1.Subject{user("other-user")}},
+		},
+	)
+
+	cr := &cmapi.CertificateRequest{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test-ns"},
+		Spec:       cmapi.CertificateRequestSpec{IssuerRef: cmmeta.ObjectReference{Name: "test-issuer"}},
+	}
+
+	grants, err := whoCanApprove(t.Context(), cl, snapshot, cr)
+	require.NoError(t, err)
+	assert.Equal(t, []grantInfo{
+		{Policy: "any", Subject: user("admin"), Binding: "ClusterRoleBinding/everyone", Role: "ClusterRole/use-all"},
+		{Policy: "any", Namespace: "test-ns", Subject: user("test-user"), Binding: "RoleBinding/test", Role: "ClusterRole/use-all"},
+		{Policy: "selects-issuer", Subject: user("admin"), Binding: "ClusterRoleBinding/everyone", Role: "ClusterRole/use-all"},
+		{Policy: "selects-issuer", Namespace: "test-ns", Subject: user("test-user"), Binding: "RoleBinding/test", Role: "ClusterRole/use-all"},
+	}, grants)
+
+	var buf bytes.Buffer
+	require.NoError(t, writeGrants(&buf, "text", grants[:2]))
+	assert.Equal(t, `POLICY   NAMESPACE   SUBJECT          BINDING                       ROLE
+any      *           User/admin       ClusterRoleBinding/everyone   ClusterRole/use-all
+any      test-ns     User/test-user   RoleBinding/test              ClusterRole/use-all
+`, buf.String())
+}
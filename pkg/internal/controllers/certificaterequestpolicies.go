@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -31,16 +32,19 @@ import (
 	"k8s.io/utils/clock"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
 	"github.com/cert-manager/approver-policy/pkg/approver"
 	"github.com/cert-manager/approver-policy/pkg/internal/controllers/ssa_client"
+	"github.com/cert-manager/approver-policy/pkg/internal/shadow"
 )
 
 // certificaterequestpolicies is a controller-runtime Reconciler which handles
@@ -118,8 +122,28 @@ func addCertificateRequestPolicyController(_ context.Context, opts Options) erro
 		}
 	}
 
+	lister := opts.Manager.GetCache()
+
 	return ctrl.NewControllerManagedBy(opts.Manager).
 		For(new(policyapi.CertificateRequestPolicy)).
+		// Whether a policy overlaps depends on every other policy, so
+		// reconcile all other policies when the spec of a policy changes.
+		Watches(new(policyapi.CertificateRequestPolicy), handler.EnqueueRequestsFromMapFunc(
+			func(ctx context.Context, obj client.Object) []reconcile.Request {
+				var policies policyapi.CertificateRequestPolicyList
+				if err := lister.List(ctx, &policies); err != nil {
+					log.Error(err, "failed to list certificaterequestpolicies to reconcile overlapping policies")
+					return nil
+				}
+				var requests []reconcile.Request
+				for _, policy := range policies.Items {
+					if policy.Name != obj.GetName() {
+						requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: policy.Name}})
+					}
+				}
+				return requests
+			},
+		), builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		WatchesRawSource(source.Channel(genericChan, handler.EnqueueRequestsFromMapFunc(
 			func(_ context.Context, obj client.Object) []reconcile.Request {
 				log.Info("reconciling certificaterequestpolicy after receiving event message", "name", obj.GetName())
@@ -131,7 +155,7 @@ func addCertificateRequestPolicyController(_ context.Context, opts Options) erro
 			clock:       clock.RealClock{},
 			recorder:    opts.Manager.GetEventRecorderFor("policy.cert-manager.io"),
 			client:      opts.Manager.GetClient(),
-			lister:      opts.Manager.GetCache(),
+			lister:      lister,
 			reconcilers: opts.Reconcilers,
 		})
 }
@@ -227,6 +251,10 @@ func (c *certificaterequestpolicies) reconcileStatusPatch(ctx context.Context, r
 			},
 		)
 
+		if err := c.setOverlappingCondition(ctx, policy, policyPatch); err != nil {
+			return reconcile.Result{}, nil, err
+		}
+
 		return result, policyPatch, nil
 	}
 
@@ -247,9 +275,54 @@ func (c *certificaterequestpolicies) reconcileStatusPatch(ctx context.Context, r
 		},
 	)
 
+	if err := c.setOverlappingCondition(ctx, policy, policyPatch); err != nil {
+		return reconcile.Result{}, nil, err
+	}
+
 	return result, policyPatch, nil
 }
 
+// setOverlappingCondition sets the Overlapping condition on the status patch
+// if the CertificateRequestPolicy shadows, or is shadowed by, another
+// CertificateRequestPolicy. Otherwise, the condition is omitted from the patch
+// which removes it from the CertificateRequestPolicy.
+func (c *certificaterequestpolicies) setOverlappingCondition(ctx context.Context, policy *policyapi.CertificateRequestPolicy, policyPatch *policyapi.CertificateRequestPolicyStatus) error {
+	var policies policyapi.CertificateRequestPolicyList
+	if err := c.lister.List(ctx, &policies); err != nil {
+		return fmt.Errorf("failed to list CertificateRequestPolicies to detect overlapping policies: %w", err)
+	}
+
+	findings := shadow.Find(policy, policies.Items)
+	if len(findings) == 0 {
+		return nil
+	}
+
+	// A policy which is shadowed has no effect, which is more important to
+	// surface than the policy shadowing others.
+	reason := "Shadows"
+	var messages []string
+	for _, finding := range findings {
+		if finding.Relation != shadow.RelationShadows {
+			reason = "Shadowed"
+		}
+		messages = append(messages, "this policy "+finding.String())
+	}
+
+	c.setCertificateRequestPolicyCondition(
+		policy.Status.Conditions,
+		&policyPatch.Conditions,
+		policy.Generation,
+		policyapi.CertificateRequestPolicyCondition{
+			Type:    policyapi.CertificateRequestPolicyConditionOverlapping,
+			Status:  corev1.ConditionTrue,
+			Reason:  reason,
+			Message: fmt.Sprintf("CertificateRequestPolicy overlaps with other policies: %s", strings.Join(messages, "; ")),
+		},
+	)
+
+	return nil
+}
+
 // setCertificateRequestPolicyCondition updates the CertificateRequestPolicy
 // object with the given condition.
 // Will overwrite any existing condition of the same type.
@@ -86,6 +86,47 @@ func Test_certificaterequestpolicies_Reconcile(t *testing.T) {
 			},
 			expEvent: "Normal Ready CertificateRequestPolicy is ready for approval evaluation",
 		},
+		"if the policy is shadowed by another policy, set the Overlapping condition": {
+			existingObjects: []runtime.Object{
+				&policyapi.CertificateRequestPolicy{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-policy", Generation: policyGeneration, ResourceVersion: "3"},
+					TypeMeta:   metav1.TypeMeta{Kind: "CertificateRequestPolicy", APIVersion: "policy.cert-manager.io/v1alpha1"},
+					Spec: policyapi.CertificateRequestPolicySpec{
+						Selector: policyapi.CertificateRequestPolicySelector{
+							Namespace: &policyapi.CertificateRequestPolicySelectorNamespace{MatchNames: []string{"team-a"}},
+						},
+					},
+				},
+				&policyapi.CertificateRequestPolicy{
+					ObjectMeta: metav1.ObjectMeta{Name: "other-policy", ResourceVersion: "3"},
+					TypeMeta:   metav1.TypeMeta{Kind: "CertificateRequestPolicy", APIVersion: "policy.cert-manager.io/v1alpha1"},
+					Spec: policyapi.CertificateRequestPolicySpec{
+						Selector: policyapi.CertificateRequestPolicySelector{
+							Namespace: &policyapi.CertificateRequestPolicySelectorNamespace{},
+						},
+					},
+				},
+			},
+			expResult: ctrl.Result{},
+			expError:  false,
+			expStatusPatch: &policyapi.CertificateRequestPolicyStatus{
+				Conditions: []policyapi.CertificateRequestPolicyCondition{
+					{Type: policyapi.CertificateRequestPolicyConditionReady,
+						Status:             corev1.ConditionTrue,
+						LastTransitionTime: fixedmetatime,
+						Reason:             "Ready",
+						Message:            "CertificateRequestPolicy is ready for approval evaluation",
+						ObservedGeneration: policyGeneration},
+					{Type: policyapi.CertificateRequestPolicyConditionOverlapping,
+						Status:             corev1.ConditionTrue,
+						LastTransitionTime: fixedmetatime,
+						Reason:             "Shadowed",
+						Message:            `CertificateRequestPolicy overlaps with other policies: this policy is shadowed by CertificateRequestPolicy "other-policy": "other-policy" selects and permits every request this policy does, so this policy has no effect for requesters bound to both`,
+						ObservedGeneration: policyGeneration},
+				},
+			},
+			expEvent: "Normal Ready CertificateRequestPolicy is ready for approval evaluation",
+		},
 		"if reconciler returns ready response, update to ready": {
 			existingObjects: []runtime.Object{&policyapi.CertificateRequestPolicy{
 				ObjectMeta: metav1.ObjectMeta{Name: "test-policy", Generation: policyGeneration, ResourceVersion: "3"},
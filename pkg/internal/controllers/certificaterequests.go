@@ -20,24 +20,29 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
+	"strings"
 	"time"
 
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/clock"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -45,7 +50,12 @@ import (
 	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
 	"github.com/cert-manager/approver-policy/pkg/approver/manager"
 	internalmanager "github.com/cert-manager/approver-policy/pkg/internal/approver/manager"
+	internalpredicate "github.com/cert-manager/approver-policy/pkg/internal/approver/manager/predicate"
+	"github.com/cert-manager/approver-policy/pkg/internal/audit"
 	"github.com/cert-manager/approver-policy/pkg/internal/controllers/ssa_client"
+	"github.com/cert-manager/approver-policy/pkg/internal/metrics"
+	"github.com/cert-manager/approver-policy/pkg/internal/policyreport"
+	"github.com/cert-manager/approver-policy/pkg/internal/tracing"
 )
 
 // certificaterequests is a controller-runtime Reconciler which evaluates
@@ -74,48 +84,57 @@ type certificaterequests struct {
 	// to manage all approvers which have been registered and active for this
 	// controller.
 	manager manager.Interface
+
+	// invalidator is the authorizer of the manager if it holds state derived
+	// from RBAC, which is invalidated before requests are enqueued for an
+	// RBAC change. Nil if the authorizer holds no such state.
+	invalidator internalpredicate.Invalidator
+
+	// denyUnprocessedAfter is the duration after creation at which unprocessed
+	// requests are denied. Zero disables denying unprocessed requests.
+	denyUnprocessedAfter time.Duration
+
+	// denyUnprocessedExcludedNamespaces are namespaces whose requests are never
+	// denied for being unprocessed.
+	denyUnprocessedExcludedNamespaces sets.Set[string]
+
+	// audit records approvals and denials in the audit log. Nil disables
+	// auditing.
+	audit audit.Interface
+
+	// policyReports holds the results of decisions for PolicyReports. Nil
+	// disables PolicyReports.
+	policyReports *policyreport.Store
+
+	// statistics counts decisions for the status of CertificateRequestPolicies.
+	// Nil disables policy statistics.
+	statistics *statistics
 }
 
 // addCertificateRequestController will register the certificaterequests
 // controller with the controller-runtime Manager.
-func addCertificateRequestController(ctx context.Context, opts Options) error {
+func addCertificateRequestController(ctx context.Context, opts Options, policyReports *policyreport.Store, statistics *statistics) error {
 	c := &certificaterequests{
 		log:      opts.Log.WithName("certificaterequests"),
 		clock:    clock.RealClock{},
 		recorder: opts.Manager.GetEventRecorderFor("policy.cert-manager.io"),
 		client:   opts.Manager.GetClient(),
 		lister:   opts.Manager.GetCache(),
-		manager:  internalmanager.New(opts.Manager.GetCache(), opts.Manager.GetClient(), opts.Evaluators),
-	}
+		manager:  internalmanager.New(metrics.CallerController, opts.Manager.GetCache(), opts.Authorizer, opts.Evaluators),
 
-	enqueueRequestFromMapFunc := func(_ context.Context, _ client.Object) []reconcile.Request {
-		// If an error happens here and we do nothing, we run the risk of not
-		// processing CertificateRequests.
-		// Exiting error is the safest option, as it will force a resync on all
-		// CertificateRequests on start.
-		var crList cmapi.CertificateRequestList
-		if err := c.lister.List(ctx, &crList); err != nil {
-			c.log.Error(err, "failed to list all CertificateRequests, exiting error")
-			os.Exit(-1)
-		}
-
-		var requests []reconcile.Request
-		for _, cr := range crList.Items {
-			// Check for approval status early, rather than relying on the
-			// predicate or doing it in the actual Reconcile func.
-			if apiutil.CertificateRequestIsApproved(&cr) || /* #nosec G601 -- Func drops pointer at end of call. */
-				apiutil.CertificateRequestIsDenied(&cr) /* #nosec G601 -- Func drops pointer at end of call. */ {
-				continue
-			}
-			requests = append(requests, reconcile.Request{
-				NamespacedName: types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}},
-			)
-		}
+		denyUnprocessedAfter:              opts.DenyUnprocessedAfter,
+		denyUnprocessedExcludedNamespaces: sets.New(opts.DenyUnprocessedExcludedNamespaces...),
+		audit:                             opts.Audit,
+		policyReports:                     policyReports,
+		statistics:                        statistics,
+	}
+	c.invalidator, _ = opts.Authorizer.(internalpredicate.Invalidator)
 
-		return requests
+	if err := indexPendingCertificateRequests(ctx, opts.Manager.GetFieldIndexer()); err != nil {
+		return err
 	}
 
-	return ctrl.NewControllerManagedBy(opts.Manager).
+	b := ctrl.NewControllerManagedBy(opts.Manager).
 		For(&cmapi.CertificateRequest{}, builder.WithPredicates(
 			// Only process CertificateRequests which have not yet got an approval
 			// status.
@@ -126,33 +145,209 @@ func addCertificateRequestController(ctx context.Context, opts Options) error {
 		)).
 
 		// Watch CertificateRequestPolicies. If a policy is created or updated,
-		// then we need to process all CertificateRequests that do not yet have an
-		// approved or denied condition since they may be relevant for the policy.
-		Watches(&policyapi.CertificateRequestPolicy{}, handler.EnqueueRequestsFromMapFunc(enqueueRequestFromMapFunc)).
-
-		// Watch Roles, RoleBindings, ClusterRoles, and ClusterRoleBindings. If
-		// RBAC changes in the cluster then CertificateRequestPolicies may become
-		// appropriate for a CertificateRequest. On RBAC events, Reconcile all
-		// CertificateRequests that are neither Approved or Denied.
-		// Only need to cache metadata for RBAC resources since we do not need any
-		// information in the spec.
-		WatchesMetadata(&rbacv1.Role{}, handler.EnqueueRequestsFromMapFunc(enqueueRequestFromMapFunc)).
-		WatchesMetadata(&rbacv1.RoleBinding{}, handler.EnqueueRequestsFromMapFunc(enqueueRequestFromMapFunc)).
-		WatchesMetadata(&rbacv1.ClusterRole{}, handler.EnqueueRequestsFromMapFunc(enqueueRequestFromMapFunc)).
-		WatchesMetadata(&rbacv1.ClusterRoleBinding{}, handler.EnqueueRequestsFromMapFunc(enqueueRequestFromMapFunc)).
-		WatchesMetadata(&corev1.Namespace{}, handler.EnqueueRequestsFromMapFunc(enqueueRequestFromMapFunc)).
+		// then we need to process the CertificateRequests that do not yet have
+		// an approved or denied condition and that the policy may select.
+		// Updates to the status of a policy, such as its statistics and
+		// compliance, are ignored unless its readiness changed.
+		Watches(&policyapi.CertificateRequestPolicy{}, handler.EnqueueRequestsFromMapFunc(c.enqueueRequestsForPolicy),
+			builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, readyConditionChanged)))
+
+	// Watch Roles, RoleBindings, ClusterRoles, and ClusterRoleBindings. If RBAC
+	// changes in the cluster then CertificateRequestPolicies may become
+	// appropriate for a CertificateRequest. On RBAC events, Reconcile the
+	// CertificateRequests that are neither Approved or Denied in the namespace
+	// of the Role or RoleBinding, or in all namespaces for cluster scoped RBAC.
+	// Only need to cache metadata for RBAC resources since we do not need any
+	// information in the spec, unless the RBACAuthorizer reads them from the
+	// cache. Its snapshot must be rebuilt from the same informers that enqueue
+	// the requests, since another informer may not have observed the change
+	// when the requests are reconciled.
+	_, typedRBAC := opts.Authorizer.(*internalpredicate.RBACAuthorizer)
+	for _, obj := range []client.Object{&rbacv1.Role{}, &rbacv1.RoleBinding{}, &rbacv1.ClusterRole{}, &rbacv1.ClusterRoleBinding{}} {
+		if typedRBAC {
+			b = b.Watches(obj, handler.EnqueueRequestsFromMapFunc(c.enqueueRequestsForRBAC))
+		} else {
+			b = b.WatchesMetadata(obj, handler.EnqueueRequestsFromMapFunc(c.enqueueRequestsForRBAC))
+		}
+	}
+
+	return b.
+		// Watch Namespaces. A change to the labels of a Namespace may change
+		// which CertificateRequestPolicies select the requests in it.
+		WatchesMetadata(&corev1.Namespace{}, handler.EnqueueRequestsFromMapFunc(c.enqueueRequestsInNamespace)).
+
+		// Watch CertificateRequestApprovals. A manual approval may cause a
+		// pending CertificateRequest to become approved, so reconcile the
+		// CertificateRequest it references.
+		Watches(&policyapi.CertificateRequestApproval{}, handler.EnqueueRequestsFromMapFunc(func(_ context.Context, obj client.Object) []reconcile.Request {
+			approval := obj.(*policyapi.CertificateRequestApproval)
+			return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: approval.Namespace, Name: approval.Spec.CertificateRequestName}}}
+		})).
 
 		// Complete the controller builder.
 		Complete(c)
 }
 
+const (
+	// pendingField is the name of the field index of CertificateRequests which
+	// are neither approved nor denied. Pending requests have the value "true".
+	// Combined with a namespace, it lists the pending requests of a namespace.
+	pendingField = "approverpolicy.pending"
+
+	// pendingIssuerField is the name of the field index of CertificateRequests
+	// which are neither approved nor denied, by the group, kind and name of
+	// their issuer with cert-manager defaults applied.
+	pendingIssuerField = "approverpolicy.pending.issuer"
+)
+
+// indexPendingCertificateRequests registers the field indexes of
+// CertificateRequests which are neither approved nor denied.
+func indexPendingCertificateRequests(ctx context.Context, indexer client.FieldIndexer) error {
+	if err := indexer.IndexField(ctx, new(cmapi.CertificateRequest), pendingField, pendingValue); err != nil {
+		return fmt.Errorf("failed to index pending CertificateRequests: %w", err)
+	}
+	if err := indexer.IndexField(ctx, new(cmapi.CertificateRequest), pendingIssuerField, pendingIssuerValue); err != nil {
+		return fmt.Errorf("failed to index pending CertificateRequests by issuer: %w", err)
+	}
+	return nil
+}
+
+// pendingValue returns the value of the pendingField index of the request.
+func pendingValue(obj client.Object) []string {
+	cr := obj.(*cmapi.CertificateRequest)
+	if apiutil.CertificateRequestIsApproved(cr) || apiutil.CertificateRequestIsDenied(cr) {
+		return nil
+	}
+	return []string{"true"}
+}
+
+// pendingIssuerValue returns the value of the pendingIssuerField index of the
+// request.
+func pendingIssuerValue(obj client.Object) []string {
+	cr := obj.(*cmapi.CertificateRequest)
+	if apiutil.CertificateRequestIsApproved(cr) || apiutil.CertificateRequestIsDenied(cr) {
+		return nil
+	}
+	// cert-manager does not materialize the defaults of the issuer kind and
+	// group, so apply them in the same way as the SelectorIssuerRef predicate.
+	kind := cr.Spec.IssuerRef.Kind
+	if len(kind) == 0 {
+		kind = cmapi.IssuerKind
+	}
+	group := cr.Spec.IssuerRef.Group
+	if len(group) == 0 {
+		group = "cert-manager.io"
+	}
+	return []string{issuerKey(group, kind, cr.Spec.IssuerRef.Name)}
+}
+
+// issuerKey returns the value of the pendingIssuerField index for an issuer.
+func issuerKey(group, kind, name string) string {
+	return group + "/" + kind + "/" + name
+}
+
+// enqueueRequestsForPolicy returns the pending CertificateRequests which the
+// CertificateRequestPolicy may select. If the issuerRef selector of the policy
+// names a single issuer, only the requests for that issuer are returned.
+func (c *certificaterequests) enqueueRequestsForPolicy(ctx context.Context, obj client.Object) []reconcile.Request {
+	policy := obj.(*policyapi.CertificateRequestPolicy)
+
+	if sel := policy.Spec.Selector.IssuerRef; sel != nil && isLiteral(sel.Group) && isLiteral(sel.Kind) && isLiteral(sel.Name) {
+		return c.pendingRequests(ctx, client.MatchingFields{pendingIssuerField: issuerKey(*sel.Group, *sel.Kind, *sel.Name)})
+	}
+
+	return c.pendingRequests(ctx, client.MatchingFields{pendingField: "true"})
+}
+
+// enqueueRequestsForRBAC returns the pending CertificateRequests in the
+// namespace of the RBAC object, or in all namespaces if the object is cluster
+// scoped. State the authorizer derived from RBAC is invalidated first, so that
+// the requests are not reconciled with state predating the change. Event
+// handlers of the same informer have no order, so the invalidation by the
+// handlers registered with the authorizer can't be relied upon.
+func (c *certificaterequests) enqueueRequestsForRBAC(ctx context.Context, obj client.Object) []reconcile.Request {
+	if c.invalidator != nil {
+		c.invalidator.Invalidate(obj.GetNamespace())
+	}
+	return c.pendingRequests(ctx, client.InNamespace(obj.GetNamespace()), client.MatchingFields{pendingField: "true"})
+}
+
+// enqueueRequestsInNamespace returns the pending CertificateRequests in the
+// Namespace.
+func (c *certificaterequests) enqueueRequestsInNamespace(ctx context.Context, obj client.Object) []reconcile.Request {
+	return c.pendingRequests(ctx, client.InNamespace(obj.GetName()), client.MatchingFields{pendingField: "true"})
+}
+
+// pendingRequests returns the CertificateRequests matching the list options.
+// The lister reads from the informer cache, so only fails if the cache is
+// misconfigured or stopping. The error is logged rather than retried, and the
+// requests are reconciled on the next event or resync.
+func (c *certificaterequests) pendingRequests(ctx context.Context, opts ...client.ListOption) []reconcile.Request {
+	var crList cmapi.CertificateRequestList
+	if err := c.lister.List(ctx, &crList, opts...); err != nil {
+		c.log.Error(err, "failed to list pending CertificateRequests, they will not be reconciled until their next event")
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(crList.Items))
+	for _, cr := range crList.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}},
+		)
+	}
+
+	return requests
+}
+
+// readyConditionChanged passes updates to CertificateRequestPolicies which
+// change the status of their Ready condition, since only ready policies are
+// applicable to requests.
+var readyConditionChanged = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return policyReadyStatus(e.ObjectOld) != policyReadyStatus(e.ObjectNew)
+	},
+}
+
+// policyReadyStatus returns the status of the Ready condition of the
+// CertificateRequestPolicy, or an empty string if it has none.
+func policyReadyStatus(obj client.Object) corev1.ConditionStatus {
+	policy, ok := obj.(*policyapi.CertificateRequestPolicy)
+	if !ok {
+		return ""
+	}
+	for _, condition := range policy.Status.Conditions {
+		if condition.Type == policyapi.CertificateRequestPolicyConditionReady {
+			return condition.Status
+		}
+	}
+	return ""
+}
+
+// isLiteral returns true if the issuerRef selector field matches exactly one
+// value.
+func isLiteral(s *string) bool {
+	return s != nil && !strings.Contains(*s, "*")
+}
+
 // Reconcile is the top level function for reconciling over synced
 // CertificateRequests.
 // Reconcile will be called whenever a CertificateRequest event happens. This
 // function will call the approver manager to evaluate whether a
 // CertificateRequest should be approved, denied, or left alone.
-func (c *certificaterequests) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	result, patch, resultErr := c.reconcileStatusPatch(ctx, req)
+func (c *certificaterequests) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "certificaterequests.Reconcile", trace.WithAttributes(
+		attribute.String("certificaterequest.namespace", req.Namespace),
+		attribute.String("certificaterequest.name", req.Name),
+	))
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	result, patch, decided, resultErr := c.reconcileStatusPatch(ctx, req)
 	if patch != nil {
 		cr, patch, err := ssa_client.GenerateCertificateRequestStatusPatch(req.Name, req.Namespace, patch)
 		if err != nil {
@@ -171,21 +366,46 @@ func (c *certificaterequests) Reconcile(ctx context.Context, req ctrl.Request) (
 		}
 	}
 
+	if decided != nil {
+		c.recordApplied(ctx, *decided)
+	}
+
 	return result, resultErr
 }
 
-func (c *certificaterequests) reconcileStatusPatch(ctx context.Context, req ctrl.Request) (ctrl.Result, *cmapi.CertificateRequestStatus, error) {
+// decision is an approval or denial of a CertificateRequest.
+type decision struct {
+	cr       *cmapi.CertificateRequest
+	result   manager.ReviewResult
+	message  string
+	response manager.ReviewResponse
+}
+
+// recordApplied records a decision which has been written to the status of
+// the CertificateRequest. Decisions are only recorded once they have been
+// applied, so that a decision is not recorded again when a failed patch is
+// retried.
+func (c *certificaterequests) recordApplied(ctx context.Context, d decision) {
+	c.recordAudit(ctx, d)
+	metrics.RecordDecision(d.cr, d.result, d.response)
+	c.recordDecision(d)
+}
+
+// reconcileStatusPatch reviews the CertificateRequest, returning the status
+// patch to apply, and the decision made on the request if it was approved or
+// denied. The decision must only be recorded once the patch has been applied.
+func (c *certificaterequests) reconcileStatusPatch(ctx context.Context, req ctrl.Request) (ctrl.Result, *cmapi.CertificateRequestStatus, *decision, error) {
 	log := c.log.WithValues("namespace", req.NamespacedName.Namespace, "name", req.NamespacedName.Name)
 	log.V(2).Info("syncing certificaterequest")
 
 	cr := new(cmapi.CertificateRequest)
 	if err := c.lister.Get(ctx, req.NamespacedName, cr); err != nil {
-		return ctrl.Result{}, nil, client.IgnoreNotFound(err)
+		return ctrl.Result{}, nil, nil, client.IgnoreNotFound(err)
 	}
 
 	if apiutil.CertificateRequestIsApproved(cr) || apiutil.CertificateRequestIsDenied(cr) {
 		// Return early if already approved/denied as this is decision is final for requests.
-		return ctrl.Result{}, nil, nil
+		return ctrl.Result{}, nil, nil, nil
 	}
 
 	// Query review on the approver manager.
@@ -197,8 +417,9 @@ func (c *certificaterequests) reconcileStatusPatch(ctx context.Context, req ctrl
 		// information about the approver configuration being exposed to the
 		// client.
 		c.recorder.Eventf(cr, corev1.EventTypeWarning, "EvaluationError", "approver-policy failed to review the request and will retry")
-		return ctrl.Result{}, nil, err
+		return ctrl.Result{}, nil, nil, err
 	}
+	metrics.RecordEliminations(response)
 
 	crPatch := &cmapi.CertificateRequestStatus{}
 
@@ -206,6 +427,7 @@ func (c *certificaterequests) reconcileStatusPatch(ctx context.Context, req ctrl
 	case manager.ResultApproved:
 		log.V(2).Info("approving request")
 		c.recorder.Event(cr, corev1.EventTypeNormal, "Approved", response.Message)
+		decided := &decision{cr: cr, result: manager.ResultApproved, message: response.Message, response: response}
 
 		setCertificateRequestStatusCondition(
 			c.clock,
@@ -217,11 +439,12 @@ func (c *certificaterequests) reconcileStatusPatch(ctx context.Context, req ctrl
 			response.Message,
 		)
 
-		return ctrl.Result{}, crPatch, nil
+		return ctrl.Result{}, crPatch, decided, nil
 
 	case manager.ResultDenied:
 		log.V(2).Info("denying request")
 		c.recorder.Event(cr, corev1.EventTypeWarning, "Denied", response.Message)
+		decided := &decision{cr: cr, result: manager.ResultDenied, message: response.Message, response: response}
 
 		setCertificateRequestStatusCondition(
 			c.clock,
@@ -233,21 +456,88 @@ func (c *certificaterequests) reconcileStatusPatch(ctx context.Context, req ctrl
 			response.Message,
 		)
 
-		return ctrl.Result{}, crPatch, nil
+		return ctrl.Result{}, crPatch, decided, nil
 
 	case manager.ResultUnprocessed:
-		log.V(2).Info("request was unprocessed")
-		c.recorder.Event(cr, corev1.EventTypeNormal, "Unprocessed", "Request is not applicable for any policy so ignoring")
+		if c.denyUnprocessedAfter <= 0 || c.denyUnprocessedExcludedNamespaces.Has(cr.Namespace) {
+			log.V(2).Info("request was unprocessed")
+			c.recorder.Event(cr, corev1.EventTypeNormal, "Unprocessed", "Request is not applicable for any policy so ignoring")
+
+			return ctrl.Result{}, nil, nil, nil
+		}
+
+		// Deny requests which have been unprocessed for too long, otherwise check
+		// again once the request becomes stale.
+		if remaining := cr.CreationTimestamp.Add(c.denyUnprocessedAfter).Sub(c.clock.Now()); remaining > 0 {
+			log.V(2).Info("request was unprocessed", "deny_after", remaining)
+			c.recorder.Eventf(cr, corev1.EventTypeNormal, "Unprocessed", "Request is not applicable for any policy so ignoring, request will be denied in %s", remaining.Round(time.Second))
+
+			return ctrl.Result{RequeueAfter: remaining}, nil, nil, nil
+		}
+
+		message := fmt.Sprintf("No policy was applicable to this request within %s: %s", c.denyUnprocessedAfter, response.Message)
+		log.V(2).Info("denying unprocessed request")
+		c.recorder.Event(cr, corev1.EventTypeWarning, "Denied", message)
+		decided := &decision{cr: cr, result: manager.ResultDenied, message: message, response: response}
+
+		setCertificateRequestStatusCondition(
+			c.clock,
+			cr.Status.Conditions,
+			&crPatch.Conditions,
+			cmapi.CertificateRequestConditionDenied,
+			cmmeta.ConditionTrue,
+			"policy.cert-manager.io",
+			message,
+		)
+
+		return ctrl.Result{}, crPatch, decided, nil
 
-		return ctrl.Result{}, nil, nil
+	case manager.ResultPending:
+		log.V(2).Info("request is pending approval", "requeue_after", response.RequeueAfter)
+		c.recorder.Event(cr, corev1.EventTypeNormal, "PendingManualApproval", response.Message)
+
+		// The request will also be reconciled again when it is manually
+		// approved.
+		return ctrl.Result{RequeueAfter: response.RequeueAfter}, nil, nil, nil
 
 	default:
 		log.Error(errors.New(response.Message), "manager responded with an unknown result", "result", response.Result)
 		c.recorder.Event(cr, corev1.EventTypeWarning, "UnknownResponse", "Policy returned an unknown result. This is a bug. Please check the approver-policy logs and file an issue")
 
 		// We can do nothing but keep retrying the review here.
-		return ctrl.Result{Requeue: true, RequeueAfter: time.Second * 5}, nil, nil
+		return ctrl.Result{Requeue: true, RequeueAfter: time.Second * 5}, nil, nil, nil
+
+	}
+}
+
+// recordAudit records the decision in the audit log, if enabled. Since the
+// decision has already been applied, an entry is recorded at most once per
+// decision. Auditing is best effort: an entry which cannot be queued because
+// the audit buffer is full, or which is not written before approver-policy
+// stops or crashes, is lost rather than blocking the decision.
+func (c *certificaterequests) recordAudit(ctx context.Context, d decision) {
+	if c.audit == nil {
+		return
+	}
 
+	auditDecision := audit.DecisionApproved
+	if d.result == manager.ResultDenied {
+		auditDecision = audit.DecisionDenied
+	}
+
+	if err := c.audit.Record(ctx, audit.NewEntry(c.clock.Now(), d.cr, auditDecision, d.message, d.response)); err != nil {
+		c.log.Error(err, "failed to record audit entry, entry has been lost", "namespace", d.cr.Namespace, "name", d.cr.Name, "decision", auditDecision)
+	}
+}
+
+// recordDecision records the decision for the PolicyReport of the request's
+// namespace and the statistics of the deciding policies, if enabled.
+func (c *certificaterequests) recordDecision(d decision) {
+	if c.policyReports != nil {
+		c.policyReports.Set(d.cr.Namespace, d.cr.UID, policyreport.ResultsForReview(c.clock.Now(), d.cr, d.result, d.message, d.response))
+	}
+	if c.statistics != nil {
+		c.statistics.Record(d.result, d.response)
 	}
 }
 
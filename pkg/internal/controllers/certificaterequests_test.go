@@ -25,19 +25,26 @@ import (
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2/ktesting"
 	fakeclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 
 	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
 	"github.com/cert-manager/approver-policy/pkg/approver/manager"
 	fakemanager "github.com/cert-manager/approver-policy/pkg/approver/manager/fake"
+	"github.com/cert-manager/approver-policy/pkg/internal/audit"
 )
 
 func Test_certificaterequests_Reconcile(t *testing.T) {
@@ -71,8 +78,10 @@ func Test_certificaterequests_Reconcile(t *testing.T) {
 	)
 
 	tests := map[string]struct {
-		existingObjects []runtime.Object
-		manager         manager.Interface
+		existingObjects                   []runtime.Object
+		manager                           manager.Interface
+		denyUnprocessedAfter              time.Duration
+		denyUnprocessedExcludedNamespaces []string
 
 		expResult      ctrl.Result
 		expError       bool
@@ -134,6 +143,66 @@ func Test_certificaterequests_Reconcile(t *testing.T) {
 			expStatusPatch: nil,
 			expEvent:       "Normal Unprocessed Request is not applicable for any policy so ignoring",
 		},
+		"if manager review returns an unprocessed response and the request is not yet stale, fire event and re-queue until stale": {
+			existingObjects: []runtime.Object{gen.CertificateRequestFrom(baseRequest, func(cr *cmapi.CertificateRequest) {
+				cr.CreationTimestamp = metav1.NewTime(fixedTime.Add(-time.Minute))
+			})},
+			manager: fakemanager.NewFakeManager().WithReview(func(context.Context, *cmapi.CertificateRequest) (manager.ReviewResponse, error) {
+				return manager.ReviewResponse{Result: manager.ResultUnprocessed, Message: "unprocessed result"}, nil
+			}),
+			denyUnprocessedAfter: time.Hour,
+			expResult:            ctrl.Result{RequeueAfter: time.Hour - time.Minute},
+			expError:             false,
+			expStatusPatch:       nil,
+			expEvent:             "Normal Unprocessed Request is not applicable for any policy so ignoring, request will be denied in 59m0s",
+		},
+		"if manager review returns an unprocessed response and the request is stale, fire event and update request with denied": {
+			existingObjects: []runtime.Object{gen.CertificateRequestFrom(baseRequest, func(cr *cmapi.CertificateRequest) {
+				cr.CreationTimestamp = metav1.NewTime(fixedTime.Add(-time.Hour))
+			})},
+			manager: fakemanager.NewFakeManager().WithReview(func(context.Context, *cmapi.CertificateRequest) (manager.ReviewResponse, error) {
+				return manager.ReviewResponse{Result: manager.ResultUnprocessed, Message: "unprocessed result"}, nil
+			}),
+			denyUnprocessedAfter: time.Hour,
+			expResult:            ctrl.Result{},
+			expError:             false,
+			expStatusPatch: &cmapi.CertificateRequestStatus{
+				Conditions: []cmapi.CertificateRequestCondition{
+					{
+						Type:               cmapi.CertificateRequestConditionDenied,
+						Status:             cmmeta.ConditionTrue,
+						LastTransitionTime: fixedmetatime,
+						Reason:             "policy.cert-manager.io",
+						Message:            "No policy was applicable to this request within 1h0m0s: unprocessed result",
+					},
+				},
+			},
+			expEvent: "Warning Denied No policy was applicable to this request within 1h0m0s: unprocessed result",
+		},
+		"if manager review returns an unprocessed response and the request namespace is excluded, fire event and do nothing": {
+			existingObjects: []runtime.Object{gen.CertificateRequestFrom(baseRequest, func(cr *cmapi.CertificateRequest) {
+				cr.CreationTimestamp = metav1.NewTime(fixedTime.Add(-time.Hour))
+			})},
+			manager: fakemanager.NewFakeManager().WithReview(func(context.Context, *cmapi.CertificateRequest) (manager.ReviewResponse, error) {
+				return manager.ReviewResponse{Result: manager.ResultUnprocessed, Message: "unprocessed result"}, nil
+			}),
+			denyUnprocessedAfter:              time.Hour,
+			denyUnprocessedExcludedNamespaces: []string{gen.DefaultTestNamespace},
+			expResult:                         ctrl.Result{},
+			expError:                          false,
+			expStatusPatch:                    nil,
+			expEvent:                          "Normal Unprocessed Request is not applicable for any policy so ignoring",
+		},
+		"if manager review returns a pending response, fire event and re-queue after the requested duration": {
+			existingObjects: []runtime.Object{gen.CertificateRequestFrom(baseRequest)},
+			manager: fakemanager.NewFakeManager().WithReview(func(context.Context, *cmapi.CertificateRequest) (manager.ReviewResponse, error) {
+				return manager.ReviewResponse{Result: manager.ResultPending, Message: "waiting for approval", RequeueAfter: time.Hour}, nil
+			}),
+			expResult:      ctrl.Result{RequeueAfter: time.Hour},
+			expError:       false,
+			expStatusPatch: nil,
+			expEvent:       "Normal PendingManualApproval waiting for approval",
+		},
 		"if manager review returns denied, fire event and update request with denied": {
 			existingObjects: []runtime.Object{gen.CertificateRequestFrom(baseRequest)},
 			manager: fakemanager.NewFakeManager().WithReview(func(context.Context, *cmapi.CertificateRequest) (manager.ReviewResponse, error) {
@@ -192,9 +261,12 @@ func Test_certificaterequests_Reconcile(t *testing.T) {
 				manager:  test.manager,
 				log:      ktesting.NewLogger(t, ktesting.DefaultConfig),
 				clock:    fixedclock,
+
+				denyUnprocessedAfter:              test.denyUnprocessedAfter,
+				denyUnprocessedExcludedNamespaces: sets.New(test.denyUnprocessedExcludedNamespaces...),
 			}
 
-			resp, statusPatch, err := c.reconcileStatusPatch(t.Context(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: gen.DefaultTestNamespace, Name: requestName}})
+			resp, statusPatch, decided, err := c.reconcileStatusPatch(t.Context(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: gen.DefaultTestNamespace, Name: requestName}})
 			if (err != nil) != test.expError {
 				t.Errorf("unexpected error, exp=%t got=%v", test.expError, err)
 			}
@@ -215,6 +287,258 @@ func Test_certificaterequests_Reconcile(t *testing.T) {
 			if !apiequality.Semantic.DeepEqual(statusPatch, test.expStatusPatch) {
 				t.Errorf("unexpected Reconcile response, exp=%v got=%v", test.expStatusPatch, statusPatch)
 			}
+
+			// A decision is only returned with the patch which applies it.
+			if (decided != nil) != (statusPatch != nil) {
+				t.Errorf("unexpected decision, exp_decision=%t got=%v", statusPatch != nil, decided)
+			}
+		})
+	}
+}
+
+// auditFunc implements an audit.Interface with a function.
+type auditFunc func(context.Context, audit.Entry) error
+
+func (f auditFunc) Record(ctx context.Context, entry audit.Entry) error {
+	return f(ctx, entry)
+}
+
+func Test_certificaterequests_Audit(t *testing.T) {
+	const requestName = "test-request"
+
+	fixedTime := time.Date(2021, 01, 01, 01, 0, 0, 0, time.UTC)
+	request := gen.CertificateRequest(requestName,
+		gen.SetCertificateRequestNamespace(gen.DefaultTestNamespace),
+		gen.SetCertificateRequestUsername("example"),
+	)
+
+	tests := map[string]struct {
+		response manager.ReviewResponse
+		auditErr error
+
+		expEntry       *audit.Entry
+		expStatusPatch bool
+	}{
+		"if the request is approved, record the approving policy": {
+			response:       manager.ReviewResponse{Result: manager.ResultApproved, Message: "approved", Policy: "test-policy"},
+			expEntry:       &audit.Entry{Decision: audit.DecisionApproved, Policy: "test-policy", Message: "approved"},
+			expStatusPatch: true,
+		},
+		"if the request is denied, record the denials": {
+			response: manager.ReviewResponse{Result: manager.ResultDenied, Message: "denied", Denials: []manager.Denial{
+				{Policy: "test-policy", Evaluator: "allowed", Field: "spec.allowed.dnsNames.values"},
+			}},
+			expEntry: &audit.Entry{Decision: audit.DecisionDenied, Message: "denied", Denials: []audit.Denial{
+				{Policy: "test-policy", Evaluator: "allowed", Field: "spec.allowed.dnsNames.values"},
+			}},
+			expStatusPatch: true,
+		},
+		"if the request is pending, record nothing": {
+			response: manager.ReviewResponse{Result: manager.ResultPending, Message: "pending"},
+		},
+		"if the entry cannot be recorded, still apply the decision": {
+			response:       manager.ReviewResponse{Result: manager.ResultApproved, Message: "approved", Policy: "test-policy"},
+			auditErr:       errors.New("this is an error"),
+			expEntry:       &audit.Entry{Decision: audit.DecisionApproved, Policy: "test-policy", Message: "approved"},
+			expStatusPatch: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fakeclient := fakeclient.NewClientBuilder().
+				WithScheme(policyapi.GlobalScheme).
+				WithRuntimeObjects(request).
+				Build()
+
+			var entry *audit.Entry
+			c := &certificaterequests{
+				client:   fakeclient,
+				lister:   fakeclient,
+				recorder: record.NewFakeRecorder(1),
+				manager: fakemanager.NewFakeManager().WithReview(func(context.Context, *cmapi.CertificateRequest) (manager.ReviewResponse, error) {
+					return test.response, nil
+				}),
+				log:   ktesting.NewLogger(t, ktesting.DefaultConfig),
+				clock: fakeclock.NewFakeClock(fixedTime),
+				audit: auditFunc(func(_ context.Context, e audit.Entry) error {
+					entry = &e
+					return test.auditErr
+				}),
+			}
+
+			_, statusPatch, decided, err := c.reconcileStatusPatch(t.Context(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: gen.DefaultTestNamespace, Name: requestName}})
+			assert.NoError(t, err)
+			assert.Equal(t, test.expStatusPatch, statusPatch != nil, "unexpected status patch")
+
+			// Nothing is recorded until the status patch has been applied.
+			assert.Nil(t, entry)
+			if decided != nil {
+				c.recordApplied(t.Context(), *decided)
+			}
+
+			if test.expEntry == nil {
+				assert.Nil(t, entry)
+				return
+			}
+			require.NotNil(t, entry)
+			assert.Equal(t, fixedTime, entry.Time)
+			assert.Equal(t, test.expEntry.Decision, entry.Decision)
+			assert.Equal(t, test.expEntry.Policy, entry.Policy)
+			assert.Equal(t, test.expEntry.Message, entry.Message)
+			assert.Equal(t, test.expEntry.Denials, entry.Denials)
+			assert.Equal(t, audit.Request{Namespace: gen.DefaultTestNamespace, Name: requestName}, entry.Request)
+			assert.Equal(t, "example", entry.Requester.Username)
+		})
+	}
+}
+
+func Test_certificaterequests_statistics(t *testing.T) {
+	const requestName = "test-request"
+
+	fakeclient := fakeclient.NewClientBuilder().
+		WithScheme(policyapi.GlobalScheme).
+		WithRuntimeObjects(gen.CertificateRequest(requestName, gen.SetCertificateRequestNamespace(gen.DefaultTestNamespace))).
+		Build()
+
+	fixedclock := fakeclock.NewFakeClock(time.Date(2021, 01, 01, 01, 0, 0, 0, time.UTC))
+	stats := &statistics{
+		clock:  fixedclock,
+		deltas: make(map[string]*statisticsDelta),
+	}
+	c := &certificaterequests{
+		client:   fakeclient,
+		lister:   fakeclient,
+		recorder: record.NewFakeRecorder(1),
+		manager: fakemanager.NewFakeManager().WithReview(func(context.Context, *cmapi.CertificateRequest) (manager.ReviewResponse, error) {
+			return manager.ReviewResponse{Result: manager.ResultApproved, Message: "approved", Policy: "test-policy"}, nil
+		}),
+		log:        ktesting.NewLogger(t, ktesting.DefaultConfig),
+		clock:      fixedclock,
+		statistics: stats,
+	}
+
+	_, statusPatch, decided, err := c.reconcileStatusPatch(t.Context(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: gen.DefaultTestNamespace, Name: requestName}})
+	require.NoError(t, err)
+	require.NotNil(t, statusPatch)
+	require.NotNil(t, decided)
+
+	// Nothing is counted until the status patch has been applied.
+	assert.Empty(t, stats.deltas)
+
+	c.recordApplied(t.Context(), *decided)
+	require.Contains(t, stats.deltas, "test-policy")
+	assert.Equal(t, int64(1), stats.deltas["test-policy"].approved)
+}
+
+// recordingInvalidator records the namespaces it is invalidated for.
+type recordingInvalidator struct {
+	namespaces []string
+}
+
+func (r *recordingInvalidator) Authorize(context.Context, *cmapi.CertificateRequest, string) (bool, error) {
+	return false, nil
+}
+
+func (r *recordingInvalidator) Invalidate(namespace string) {
+	r.namespaces = append(r.namespaces, namespace)
+}
+
+func Test_certificaterequests_enqueue(t *testing.T) {
+	request := func(namespace, name, issuerName, issuerKind string, conditions ...cmapi.CertificateRequestCondition) *cmapi.CertificateRequest {
+		return &cmapi.CertificateRequest{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Spec:       cmapi.CertificateRequestSpec{IssuerRef: cmmeta.ObjectReference{Name: issuerName, Kind: issuerKind}},
+			Status:     cmapi.CertificateRequestStatus{Conditions: conditions},
+		}
+	}
+	key := func(namespace, name string) ctrl.Request {
+		return ctrl.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: name}}
+	}
+
+	invalidator := new(recordingInvalidator)
+	c := &certificaterequests{
+		log:         ktesting.NewLogger(t, ktesting.DefaultConfig),
+		invalidator: invalidator,
+		lister: fakeclient.NewClientBuilder().
+			WithScheme(policyapi.GlobalScheme).
+			WithIndex(new(cmapi.CertificateRequest), pendingField, pendingValue).
+			WithIndex(new(cmapi.CertificateRequest), pendingIssuerField, pendingIssuerValue).
+			WithObjects(
+				request("ns-a", "pending-a", "issuer-a", ""),
+				request("ns-a", "pending-b", "issuer-b", cmapi.IssuerKind),
+				request("ns-b", "pending-c", "issuer-a", cmapi.ClusterIssuerKind),
+				request("ns-a", "approved", "issuer-a", "", cmapi.CertificateRequestCondition{Type: cmapi.CertificateRequestConditionApproved, Status: cmmeta.ConditionTrue}),
+				request("ns-b", "denied", "issuer-a", "", cmapi.CertificateRequestCondition{Type: cmapi.CertificateRequestConditionDenied, Status: cmmeta.ConditionTrue}),
+			).
+			Build(),
+	}
+
+	ctx := t.Context()
+	policy := func(issuerRef *policyapi.CertificateRequestPolicySelectorIssuerRef) *policyapi.CertificateRequestPolicy {
+		return &policyapi.CertificateRequestPolicy{Spec: policyapi.CertificateRequestPolicySpec{
+			Selector: policyapi.CertificateRequestPolicySelector{IssuerRef: issuerRef},
+		}}
+	}
+
+	assert.ElementsMatch(t, []ctrl.Request{key("ns-a", "pending-a"), key("ns-a", "pending-b"), key("ns-b", "pending-c")},
+		c.enqueueRequestsForPolicy(ctx, policy(nil)), "expected a policy without an issuerRef selector to enqueue all pending requests")
+	assert.ElementsMatch(t, []ctrl.Request{key("ns-a", "pending-a"), key("ns-a", "pending-b"), key("ns-b", "pending-c")},
+		c.enqueueRequestsForPolicy(ctx, policy(&policyapi.CertificateRequestPolicySelectorIssuerRef{Name: ptr.To("issuer-a"), Kind: ptr.To("*"), Group: ptr.To("cert-manager.io")})),
+		"expected a policy with a wildcard kind to enqueue all pending requests")
+	assert.ElementsMatch(t, []ctrl.Request{key("ns-a", "pending-a")},
+		c.enqueueRequestsForPolicy(ctx, policy(&policyapi.CertificateRequestPolicySelectorIssuerRef{Name: ptr.To("issuer-a"), Kind: ptr.To(cmapi.IssuerKind), Group: ptr.To("cert-manager.io")})),
+		"expected a policy naming an issuer to only enqueue pending requests for that issuer, with defaults applied")
+
+	assert.ElementsMatch(t, []ctrl.Request{key("ns-a", "pending-a"), key("ns-a", "pending-b")},
+		c.enqueueRequestsForRBAC(ctx, &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-a", Name: "role-binding"}}))
+	assert.ElementsMatch(t, []ctrl.Request{key("ns-a", "pending-a"), key("ns-a", "pending-b"), key("ns-b", "pending-c")},
+		c.enqueueRequestsForRBAC(ctx, &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: "cluster-role-binding"}}))
+	assert.Equal(t, []string{"ns-a", ""}, invalidator.namespaces, "expected the authorizer to be invalidated for RBAC events")
+	assert.ElementsMatch(t, []ctrl.Request{key("ns-b", "pending-c")},
+		c.enqueueRequestsInNamespace(ctx, &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: "ns-b"}}))
+}
+
+func Test_readyConditionChanged(t *testing.T) {
+	policy := func(generation int64, ready corev1.ConditionStatus) *policyapi.CertificateRequestPolicy {
+		policy := &policyapi.CertificateRequestPolicy{ObjectMeta: metav1.ObjectMeta{Name: "test-policy", Generation: generation}}
+		if len(ready) > 0 {
+			policy.Status.Conditions = []policyapi.CertificateRequestPolicyCondition{
+				{Type: policyapi.CertificateRequestPolicyConditionReady, Status: ready},
+			}
+		}
+		return policy
+	}
+
+	tests := map[string]struct {
+		oldPolicy, newPolicy *policyapi.CertificateRequestPolicy
+		expChanged           bool
+	}{
+		"becoming ready is a change": {
+			oldPolicy:  policy(1, ""),
+			newPolicy:  policy(1, corev1.ConditionTrue),
+			expChanged: true,
+		},
+		"becoming not ready is a change": {
+			oldPolicy:  policy(1, corev1.ConditionTrue),
+			newPolicy:  policy(1, corev1.ConditionFalse),
+			expChanged: true,
+		},
+		"a status update which keeps the policy ready is not a change": {
+			oldPolicy:  policy(1, corev1.ConditionTrue),
+			newPolicy:  policy(1, corev1.ConditionTrue),
+			expChanged: false,
+		},
+		"a spec update is not a change of readiness": {
+			oldPolicy:  policy(1, corev1.ConditionTrue),
+			newPolicy:  policy(2, corev1.ConditionTrue),
+			expChanged: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expChanged, readyConditionChanged.Update(event.UpdateEvent{ObjectOld: test.oldPolicy, ObjectNew: test.newPolicy}))
 		})
 	}
 }
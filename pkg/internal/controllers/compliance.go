@@ -0,0 +1,254 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/go-logr/logr"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver/manager"
+	internalmanager "github.com/cert-manager/approver-policy/pkg/internal/approver/manager"
+	"github.com/cert-manager/approver-policy/pkg/internal/controllers/ssa_client"
+	"github.com/cert-manager/approver-policy/pkg/internal/metrics"
+)
+
+const (
+	// complianceFieldManager is the field manager used to apply the compliance
+	// status of CertificateRequestPolicies. It is distinct from the field
+	// manager of the policy controller so that neither removes the status
+	// fields of the other.
+	complianceFieldManager = "approver-policy-compliance"
+
+	// complianceMaxDrifted is the maximum number of drifted requests listed in
+	// the status of a CertificateRequestPolicy.
+	complianceMaxDrifted = 50
+)
+
+// approvedByPolicyRegex matches the message of the Approved condition set by
+// approver-policy, capturing the name of the approving policy.
+var approvedByPolicyRegex = regexp.MustCompile(`^Approved by CertificateRequestPolicy: "([^"]+)"`)
+
+// compliance periodically re-reviews approved CertificateRequests against the
+// current CertificateRequestPolicies, and reports the requests that would no
+// longer be approved on the status of the policy which approved them, and as
+// metrics. Requests are only reviewed; their conditions are never changed.
+type compliance struct {
+	// log is logger for the compliance checker.
+	log logr.Logger
+
+	// clock returns time which can be overwritten for testing.
+	clock clock.Clock
+
+	// client is a Kubernetes REST client to interact with objects in the API
+	// server.
+	client client.Client
+
+	// lister makes requests to the informer cache for getting and listing
+	// objects.
+	lister client.Reader
+
+	// manager is used to re-review approved CertificateRequests in evaluate
+	// only mode.
+	manager manager.Interface
+
+	// interval is the duration between compliance checks.
+	interval time.Duration
+}
+
+// addComplianceChecker will register the compliance checker with the
+// controller-runtime Manager, if enabled.
+func addComplianceChecker(_ context.Context, opts Options) error {
+	if opts.ComplianceCheckInterval <= 0 {
+		return nil
+	}
+
+	return opts.Manager.Add(&compliance{
+		log:      opts.Log.WithName("compliance"),
+		clock:    clock.RealClock{},
+		client:   opts.Manager.GetClient(),
+		lister:   opts.Manager.GetCache(),
+		manager:  internalmanager.NewEvaluateOnly(opts.Manager.GetCache(), opts.Authorizer, opts.Evaluators),
+		interval: opts.ComplianceCheckInterval,
+	})
+}
+
+// Start runs a compliance check every interval until the context is
+// cancelled.
+func (c *compliance) Start(ctx context.Context) error {
+	c.log.Info("starting compliance checker", "interval", c.interval)
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := c.check(ctx); err != nil {
+			c.log.Error(err, "failed to check compliance of approved CertificateRequests")
+		}
+	}, c.interval)
+	return nil
+}
+
+// check re-reviews all approved CertificateRequests, and writes the result to
+// the metrics and the status of every CertificateRequestPolicy whose
+// compliance has changed.
+func (c *compliance) check(ctx context.Context) error {
+	var policies policyapi.CertificateRequestPolicyList
+	if err := c.lister.List(ctx, &policies); err != nil {
+		return fmt.Errorf("failed to list CertificateRequestPolicies: %w", err)
+	}
+
+	var requests cmapi.CertificateRequestList
+	if err := c.lister.List(ctx, &requests); err != nil {
+		return fmt.Errorf("failed to list CertificateRequests: %w", err)
+	}
+
+	reports := c.report(ctx, requests.Items)
+
+	approved, drifted := make(map[string]int), make(map[string]int)
+	for name, report := range reports {
+		approved[name] = int(report.ApprovedRequests)
+		drifted[name] = int(report.DriftedRequests)
+	}
+	metrics.RecordCompliance(approved, drifted)
+
+	var errs []error
+	for _, policy := range policies.Items {
+		report, ok := reports[policy.Name]
+		if !ok {
+			report = &policyapi.CertificateRequestPolicyCompliance{LastCheckTime: &metav1.Time{Time: c.clock.Now()}}
+		}
+		if complianceEqual(policy.Status.Compliance, report) {
+			continue
+		}
+
+		crp, patch, err := ssa_client.GenerateCertificateRequestPolicyStatusPatch(policy.Name, &policyapi.CertificateRequestPolicyStatus{Compliance: report})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to generate CertificateRequestPolicy.Status patch for %q: %w", policy.Name, err))
+			continue
+		}
+
+		if err := c.client.Status().Patch(ctx, crp, patch, &client.SubResourcePatchOptions{
+			PatchOptions: client.PatchOptions{
+				FieldManager: complianceFieldManager,
+				Force:        ptr.To(true),
+			},
+		}); client.IgnoreNotFound(err) != nil {
+			errs = append(errs, fmt.Errorf("failed to apply CertificateRequestPolicy.Status patch for %q: %w", policy.Name, err))
+		}
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// report re-reviews the given CertificateRequests that have been approved by
+// a CertificateRequestPolicy, and returns the compliance of each approving
+// policy by name. A request has drifted if it would now be denied, or no
+// policy would apply to it, including because the requester is no longer
+// bound to it. Requests which are pending, such as waiting for manual
+// approval, or fail to be reviewed are counted as approved, but not as
+// drifted.
+func (c *compliance) report(ctx context.Context, requests []cmapi.CertificateRequest) map[string]*policyapi.CertificateRequestPolicyCompliance {
+	now := &metav1.Time{Time: c.clock.Now()}
+	reports := make(map[string]*policyapi.CertificateRequestPolicyCompliance)
+
+	sort.SliceStable(requests, func(i, j int) bool {
+		if requests[i].Namespace != requests[j].Namespace {
+			return requests[i].Namespace < requests[j].Namespace
+		}
+		return requests[i].Name < requests[j].Name
+	})
+
+	for i := range requests {
+		cr := &requests[i]
+
+		policyName, ok := approvingPolicy(cr)
+		if !ok {
+			continue
+		}
+
+		report, ok := reports[policyName]
+		if !ok {
+			report = &policyapi.CertificateRequestPolicyCompliance{LastCheckTime: now}
+			reports[policyName] = report
+		}
+		report.ApprovedRequests++
+
+		response, err := c.manager.Review(ctx, cr)
+		if err != nil {
+			c.log.Error(err, "failed to review approved CertificateRequest", "namespace", cr.Namespace, "name", cr.Name)
+			continue
+		}
+		if response.Result == manager.ResultApproved || response.Result == manager.ResultPending {
+			continue
+		}
+
+		report.DriftedRequests++
+		if len(report.Drifted) < complianceMaxDrifted {
+			report.Drifted = append(report.Drifted, policyapi.CertificateRequestPolicyComplianceDrift{
+				Namespace: cr.Namespace,
+				Name:      cr.Name,
+				Message:   response.Message,
+			})
+		}
+	}
+
+	return reports
+}
+
+// complianceEqual returns true if the compliance reports are equal, ignoring
+// the time they were checked.
+func complianceEqual(a, b *policyapi.CertificateRequestPolicyCompliance) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	a, b = a.DeepCopy(), b.DeepCopy()
+	a.LastCheckTime, b.LastCheckTime = nil, nil
+	return apiequality.Semantic.DeepEqual(a, b)
+}
+
+// approvingPolicy returns the name of the CertificateRequestPolicy which
+// approved the CertificateRequest. Returns false if the request is not
+// approved, or was approved by something other than approver-policy.
+func approvingPolicy(cr *cmapi.CertificateRequest) (string, bool) {
+	if !apiutil.CertificateRequestIsApproved(cr) || apiutil.CertificateRequestIsDenied(cr) {
+		return "", false
+	}
+
+	condition := apiutil.GetCertificateRequestCondition(cr, cmapi.CertificateRequestConditionApproved)
+	if condition == nil || condition.Status != cmmeta.ConditionTrue || condition.Reason != "policy.cert-manager.io" {
+		return "", false
+	}
+
+	match := approvedByPolicyRegex.FindStringSubmatch(condition.Message)
+	if match == nil {
+		return "", false
+	}
+
+	return match[1], true
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2/ktesting"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver/manager"
+	fakemanager "github.com/cert-manager/approver-policy/pkg/approver/manager/fake"
+)
+
+func Test_compliance_report(t *testing.T) {
+	var (
+		fixedTime     = time.Date(2021, 01, 01, 01, 0, 0, 0, time.UTC)
+		fixedmetatime = &metav1.Time{Time: fixedTime}
+	)
+
+	approvedBy := func(message string) gen.CertificateRequestModifier {
+		return gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+			Type:    cmapi.CertificateRequestConditionApproved,
+			Status:  cmmeta.ConditionTrue,
+			Reason:  "policy.cert-manager.io",
+			Message: message,
+		})
+	}
+
+	request := func(namespace, name string, mods ...gen.CertificateRequestModifier) cmapi.CertificateRequest {
+		return *gen.CertificateRequest(name, append([]gen.CertificateRequestModifier{gen.SetCertificateRequestNamespace(namespace)}, mods...)...)
+	}
+
+	tests := map[string]struct {
+		requests []cmapi.CertificateRequest
+		review   func(context.Context, *cmapi.CertificateRequest) (manager.ReviewResponse, error)
+
+		expReports map[string]*policyapi.CertificateRequestPolicyCompliance
+	}{
+		"if there are no requests, return no reports": {
+			requests:   nil,
+			expReports: map[string]*policyapi.CertificateRequestPolicyCompliance{},
+		},
+		"requests which are not approved by a policy should be ignored": {
+			requests: []cmapi.CertificateRequest{
+				request("ns", "unapproved"),
+				request("ns", "other-approver", gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+					Type:    cmapi.CertificateRequestConditionApproved,
+					Status:  cmmeta.ConditionTrue,
+					Reason:  "cert-manager.io",
+					Message: "Certificate request has been approved by cert-manager.io",
+				})),
+				request("ns", "denied", gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+					Type:   cmapi.CertificateRequestConditionDenied,
+					Status: cmmeta.ConditionTrue,
+					Reason: "policy.cert-manager.io",
+				})),
+			},
+			review: func(context.Context, *cmapi.CertificateRequest) (manager.ReviewResponse, error) {
+				t.Fatal("unexpected review")
+				return manager.ReviewResponse{}, nil
+			},
+			expReports: map[string]*policyapi.CertificateRequestPolicyCompliance{},
+		},
+		"requests which are still approved, pending, or fail review, should not be drifted": {
+			requests: []cmapi.CertificateRequest{
+				request("ns", "still-approved", approvedBy(`Approved by CertificateRequestPolicy: "policy-a"`)),
+				request("ns", "pending", approvedBy(`Approved by CertificateRequestPolicy: "policy-a": manually approved by alice`)),
+				request("ns", "error", approvedBy(`Approved by CertificateRequestPolicy: "policy-a": manually approved by alice`)),
+			},
+			review: func(_ context.Context, cr *cmapi.CertificateRequest) (manager.ReviewResponse, error) {
+				switch cr.Name {
+				case "error":
+					return manager.ReviewResponse{}, errors.New("this is an error")
+				case "pending":
+					return manager.ReviewResponse{Result: manager.ResultPending, Message: "Request is pending approval"}, nil
+				default:
+					return manager.ReviewResponse{Result: manager.ResultApproved}, nil
+				}
+			},
+			expReports: map[string]*policyapi.CertificateRequestPolicyCompliance{
+				"policy-a": {LastCheckTime: fixedmetatime, ApprovedRequests: 3},
+			},
+		},
+		"requests which would no longer be approved should be drifted on the approving policy, sorted by namespace and name": {
+			requests: []cmapi.CertificateRequest{
+				request("ns-b", "denied", approvedBy(`Approved by CertificateRequestPolicy: "policy-a"`)),
+				request("ns-a", "unprocessed", approvedBy(`Approved by CertificateRequestPolicy: "policy-a"`)),
+				request("ns-a", "approved", approvedBy(`Approved by CertificateRequestPolicy: "policy-b"`)),
+			},
+			review: func(_ context.Context, cr *cmapi.CertificateRequest) (manager.ReviewResponse, error) {
+				switch cr.Name {
+				case "denied":
+					return manager.ReviewResponse{Result: manager.ResultDenied, Message: "No policy approved this request"}, nil
+				case "unprocessed":
+					return manager.ReviewResponse{Result: manager.ResultUnprocessed, Message: "No CertificateRequestPolicies bound or applicable"}, nil
+				default:
+					return manager.ReviewResponse{Result: manager.ResultApproved}, nil
+				}
+			},
+			expReports: map[string]*policyapi.CertificateRequestPolicyCompliance{
+				"policy-a": {
+					LastCheckTime:    fixedmetatime,
+					ApprovedRequests: 2,
+					DriftedRequests:  2,
+					Drifted: []policyapi.CertificateRequestPolicyComplianceDrift{
+						{Namespace: "ns-a", Name: "unprocessed", Message: "No CertificateRequestPolicies bound or applicable"},
+						{Namespace: "ns-b", Name: "denied", Message: "No policy approved this request"},
+					},
+				},
+				"policy-b": {LastCheckTime: fixedmetatime, ApprovedRequests: 1},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &compliance{
+				log:     ktesting.NewLogger(t, ktesting.DefaultConfig),
+				clock:   fakeclock.NewFakeClock(fixedTime),
+				manager: fakemanager.NewFakeManager().WithReview(test.review),
+			}
+
+			reports := c.report(t.Context(), test.requests)
+			if !apiequality.Semantic.DeepEqual(reports, test.expReports) {
+				t.Errorf("unexpected reports, exp=%#+v got=%#+v", test.expReports, reports)
+			}
+		})
+	}
+}
+
+func Test_complianceEqual(t *testing.T) {
+	var (
+		earlier = &metav1.Time{Time: time.Date(2021, 01, 01, 01, 0, 0, 0, time.UTC)}
+		later   = &metav1.Time{Time: earlier.Add(time.Hour)}
+		drifted = []policyapi.CertificateRequestPolicyComplianceDrift{{Namespace: "ns", Name: "cr", Message: "denied"}}
+	)
+
+	if !complianceEqual(
+		&policyapi.CertificateRequestPolicyCompliance{LastCheckTime: earlier, ApprovedRequests: 1, DriftedRequests: 1, Drifted: drifted},
+		&policyapi.CertificateRequestPolicyCompliance{LastCheckTime: later, ApprovedRequests: 1, DriftedRequests: 1, Drifted: drifted},
+	) {
+		t.Error("expected reports which only differ by check time to be equal, so the status is not rewritten")
+	}
+	if complianceEqual(
+		&policyapi.CertificateRequestPolicyCompliance{LastCheckTime: earlier, ApprovedRequests: 1},
+		&policyapi.CertificateRequestPolicyCompliance{LastCheckTime: earlier, ApprovedRequests: 2},
+	) {
+		t.Error("expected reports with different counts to differ")
+	}
+	if complianceEqual(nil, &policyapi.CertificateRequestPolicyCompliance{LastCheckTime: earlier}) {
+		t.Error("expected a policy without a report to be written")
+	}
+}
@@ -19,11 +19,15 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	"github.com/cert-manager/approver-policy/pkg/approver"
+	"github.com/cert-manager/approver-policy/pkg/internal/approver/manager/predicate"
+	"github.com/cert-manager/approver-policy/pkg/internal/audit"
+	"github.com/cert-manager/approver-policy/pkg/internal/policyreport"
 )
 
 // Options hold options for the internal approver-policy controllers.
@@ -39,14 +43,61 @@ type Options struct {
 	// used to build the approver manager.
 	Evaluators []approver.Evaluator
 
+	// Authorizer decides whether the user of a CertificateRequest is bound to
+	// a CertificateRequestPolicy. Defaults to creating a SubjectAccessReview
+	// for every decision.
+	Authorizer predicate.Authorizer
+
 	// Reconcilers is the list of registered Approver Reconcilers that  will be
 	// used to manager CertificateRequestPolicy Ready conditions.
 	Reconcilers []approver.Reconciler
+
+	// DenyUnprocessedAfter is the duration after creation at which
+	// CertificateRequests that no CertificateRequestPolicy is applicable to are
+	// denied. Zero disables denying unprocessed requests.
+	DenyUnprocessedAfter time.Duration
+
+	// DenyUnprocessedExcludedNamespaces are namespaces whose
+	// CertificateRequests are never denied for being unprocessed.
+	DenyUnprocessedExcludedNamespaces []string
+
+	// ComplianceCheckInterval is the interval at which approved
+	// CertificateRequests are re-reviewed against the current
+	// CertificateRequestPolicies. Zero disables the compliance check.
+	ComplianceCheckInterval time.Duration
+
+	// Audit records approvals and denials of CertificateRequests in the audit
+	// log, once they have been applied. Nil disables auditing.
+	Audit audit.Interface
+
+	// PolicyReports enables writing a PolicyReport to every namespace
+	// summarising the approvals and denials of its CertificateRequests.
+	PolicyReports bool
+
+	// PolicyStatisticsInterval is the interval at which the usage statistics
+	// of CertificateRequestPolicies are written to their status. Zero disables
+	// policy statistics.
+	PolicyStatisticsInterval time.Duration
+
+	// PolicyStatisticsSubjects lists the subjects bound to each
+	// CertificateRequestPolicy via RBAC in its statistics.
+	PolicyStatisticsSubjects bool
 }
 
 // AddControllers adds all internal controllers.
 func AddControllers(ctx context.Context, opts Options) error {
-	if err := addCertificateRequestController(ctx, opts); err != nil {
+	if opts.Authorizer == nil {
+		opts.Authorizer = predicate.NewSubjectAccessReviewAuthorizer(opts.Manager.GetClient())
+	}
+
+	var policyReports *policyreport.Store
+	if opts.PolicyReports {
+		policyReports = policyreport.NewStore()
+	}
+
+	statistics := newStatistics(opts)
+
+	if err := addCertificateRequestController(ctx, opts, policyReports, statistics); err != nil {
 		return fmt.Errorf("failed to add certificaterequest controller: %w", err)
 	}
 
@@ -54,5 +105,17 @@ func AddControllers(ctx context.Context, opts Options) error {
 		return fmt.Errorf("failed to add certificaterequestpolicy controller: %w", err)
 	}
 
+	if err := addComplianceChecker(ctx, opts); err != nil {
+		return fmt.Errorf("failed to add compliance checker: %w", err)
+	}
+
+	if err := addPolicyReportController(ctx, opts, policyReports); err != nil {
+		return fmt.Errorf("failed to add policyreport controller: %w", err)
+	}
+
+	if err := addStatisticsReporter(ctx, opts, statistics); err != nil {
+		return fmt.Errorf("failed to add policy statistics reporter: %w", err)
+	}
+
 	return nil
 }
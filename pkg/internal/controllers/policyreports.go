@@ -0,0 +1,198 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/cert-manager/approver-policy/pkg/internal/controllers/ssa_client"
+	"github.com/cert-manager/approver-policy/pkg/internal/policyreport"
+)
+
+// policyReportFieldManager is the field manager used to apply PolicyReports.
+const policyReportFieldManager = "approver-policy"
+
+// policyreports is a controller-runtime Reconciler which writes a PolicyReport
+// to every namespace summarising the approvals and denials of the
+// CertificateRequests in that namespace. Reconcile requests are keyed by
+// namespace name only.
+type policyreports struct {
+	// log is logger for the policyreports controller.
+	log logr.Logger
+
+	// client is a Kubernetes REST client to interact with objects in the API
+	// server.
+	client client.Client
+
+	// lister makes requests to the informer cache for getting and listing
+	// objects.
+	lister client.Reader
+
+	// store holds the results of the decisions made by the certificaterequests
+	// controller.
+	store *policyreport.Store
+}
+
+// addPolicyReportController will register the policyreports controller with
+// the controller-runtime Manager, if a store is given.
+func addPolicyReportController(_ context.Context, opts Options, store *policyreport.Store) error {
+	if store == nil {
+		return nil
+	}
+
+	c := &policyreports{
+		log:    opts.Log.WithName("policyreports"),
+		client: opts.Manager.GetClient(),
+		lister: opts.Manager.GetCache(),
+		store:  store,
+	}
+
+	return ctrl.NewControllerManagedBy(opts.Manager).
+		Named("policyreports").
+
+		// Watch CertificateRequests which have been approved or denied,
+		// reconciling the namespace they are in. Deleting a decided request
+		// also reconciles its namespace so that its results are removed.
+		Watches(&cmapi.CertificateRequest{}, handler.EnqueueRequestsFromMapFunc(func(_ context.Context, obj client.Object) []reconcile.Request {
+			return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: obj.GetNamespace()}}}
+		}), builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			cr := obj.(*cmapi.CertificateRequest)
+			return apiutil.CertificateRequestIsApproved(cr) || apiutil.CertificateRequestIsDenied(cr)
+		}))).
+
+		// Complete the controller builder.
+		Complete(c)
+}
+
+// Reconcile rebuilds the PolicyReport of the namespace from the decided
+// CertificateRequests in it. The PolicyReport is deleted when there are no
+// results.
+func (c *policyreports) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	namespace := req.Name
+	log := c.log.WithValues("namespace", namespace)
+	log.V(2).Info("syncing policyreport")
+
+	var crList cmapi.CertificateRequestList
+	if err := c.lister.List(ctx, &crList, client.InNamespace(namespace)); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to list CertificateRequests: %w", err)
+	}
+
+	results, uids := c.results(crList.Items)
+	c.store.Prune(namespace, uids)
+
+	if len(results) == 0 {
+		report := &unstructured.Unstructured{}
+		report.SetGroupVersionKind(policyreport.GroupVersionKind)
+		report.SetName(policyreport.Name)
+		report.SetNamespace(namespace)
+		if err := c.client.Delete(ctx, report); client.IgnoreNotFound(err) != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to delete PolicyReport: %w", err)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	report, patch, err := ssa_client.GeneratePolicyReportPatch(policyreport.Build(namespace, results))
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to generate PolicyReport patch: %w", err)
+	}
+
+	if err := c.client.Patch(ctx, report, patch, &client.PatchOptions{
+		FieldManager: policyReportFieldManager,
+		Force:        ptr.To(true),
+	}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to apply PolicyReport: %w", err)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// results returns the results of the given CertificateRequests which have
+// been decided by approver-policy, and the UIDs of all the requests. Results
+// recorded by the certificaterequests controller are used where available,
+// otherwise they are rebuilt from the request's conditions.
+func (c *policyreports) results(requests []cmapi.CertificateRequest) ([]policyreport.PolicyReportResult, sets.Set[types.UID]) {
+	var results []policyreport.PolicyReportResult
+	uids := sets.New[types.UID]()
+
+	for i := range requests {
+		cr := &requests[i]
+		uids.Insert(cr.UID)
+
+		decided := resultsFromConditions(cr)
+		if decided == nil {
+			continue
+		}
+
+		if stored, ok := c.store.Get(cr.UID); ok && stored[0].Result == decided[0].Result {
+			results = append(results, stored...)
+			continue
+		}
+
+		results = append(results, decided...)
+	}
+
+	return results, uids
+}
+
+// resultsFromConditions returns the result of the decision made on the
+// CertificateRequest by approver-policy from its conditions. The denied
+// fields, and the policy which denied the request, are not recorded in the
+// conditions, so denials are reported against policyreport.UnknownPolicy.
+// Returns nil if the request has not been decided by approver-policy.
+func resultsFromConditions(cr *cmapi.CertificateRequest) []policyreport.PolicyReportResult {
+	if policyName, ok := approvingPolicy(cr); ok {
+		condition := apiutil.GetCertificateRequestCondition(cr, cmapi.CertificateRequestConditionApproved)
+		return []policyreport.PolicyReportResult{
+			policyreport.NewResult(conditionTime(cr, condition), cr, policyreport.ResultPass, policyName, "", condition.Message),
+		}
+	}
+
+	condition := apiutil.GetCertificateRequestCondition(cr, cmapi.CertificateRequestConditionDenied)
+	if condition == nil || condition.Status != cmmeta.ConditionTrue || condition.Reason != "policy.cert-manager.io" {
+		return nil
+	}
+
+	return []policyreport.PolicyReportResult{
+		policyreport.NewResult(conditionTime(cr, condition), cr, policyreport.ResultFail, policyreport.UnknownPolicy, "", condition.Message),
+	}
+}
+
+// conditionTime returns the last transition time of the condition, or the
+// creation time of the request if it is not set.
+func conditionTime(cr *cmapi.CertificateRequest, condition *cmapi.CertificateRequestCondition) time.Time {
+	if condition.LastTransitionTime != nil {
+		return condition.LastTransitionTime.Time
+	}
+	return cr.CreationTimestamp.Time
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2/ktesting"
+
+	"github.com/cert-manager/approver-policy/pkg/approver/manager"
+	"github.com/cert-manager/approver-policy/pkg/internal/policyreport"
+)
+
+func Test_policyreports_results(t *testing.T) {
+	var (
+		fixedTime     = time.Date(2021, 01, 01, 01, 0, 0, 0, time.UTC)
+		fixedmetatime = &metav1.Time{Time: fixedTime}
+	)
+
+	request := func(name string, conditions ...cmapi.CertificateRequestCondition) cmapi.CertificateRequest {
+		mods := []gen.CertificateRequestModifier{gen.SetCertificateRequestNamespace("test-ns")}
+		for _, condition := range conditions {
+			condition.LastTransitionTime = fixedmetatime
+			mods = append(mods, gen.SetCertificateRequestStatusCondition(condition))
+		}
+		cr := gen.CertificateRequest(name, mods...)
+		cr.UID = types.UID(name + "-uid")
+		return *cr
+	}
+
+	approved := cmapi.CertificateRequestCondition{
+		Type:    cmapi.CertificateRequestConditionApproved,
+		Status:  cmmeta.ConditionTrue,
+		Reason:  "policy.cert-manager.io",
+		Message: `Approved by CertificateRequestPolicy: "test-policy"`,
+	}
+	denied := cmapi.CertificateRequestCondition{
+		Type:    cmapi.CertificateRequestConditionDenied,
+		Status:  cmmeta.ConditionTrue,
+		Reason:  "policy.cert-manager.io",
+		Message: "No policy approved this request",
+	}
+
+	var (
+		pending       = request("pending")
+		otherApprover = request("other-approver", cmapi.CertificateRequestCondition{
+			Type:    cmapi.CertificateRequestConditionApproved,
+			Status:  cmmeta.ConditionTrue,
+			Reason:  "cert-manager.io",
+			Message: "Certificate request has been approved by cert-manager.io",
+		})
+		approvedBefore = request("approved-before-start", approved)
+		deniedBefore   = request("denied-before-start", denied)
+		deniedReviewed = request("denied-reviewed", denied)
+	)
+
+	store := policyreport.NewStore()
+	reviewed := policyreport.ResultsForReview(fixedTime, &deniedReviewed, manager.ResultDenied, denied.Message, manager.ReviewResponse{
+		Result:  manager.ResultDenied,
+		Denials: []manager.Denial{{Policy: "test-policy", Evaluator: "allowed", Field: "spec.allowed.dnsNames.values"}},
+	})
+	store.Set("test-ns", deniedReviewed.UID, reviewed)
+	store.Set("test-ns", "deleted-uid", reviewed)
+
+	c := &policyreports{
+		log:   ktesting.NewLogger(t, ktesting.DefaultConfig),
+		store: store,
+	}
+
+	requests := []cmapi.CertificateRequest{pending, otherApprover, approvedBefore, deniedBefore, deniedReviewed}
+	results, uids := c.results(requests)
+	store.Prune("test-ns", uids)
+
+	assert.Equal(t, []policyreport.PolicyReportResult{
+		policyreport.NewResult(fixedTime, &approvedBefore, policyreport.ResultPass, "test-policy", "", approved.Message),
+		policyreport.NewResult(fixedTime, &deniedBefore, policyreport.ResultFail, policyreport.UnknownPolicy, "", denied.Message),
+		reviewed[0],
+	}, results)
+	assert.Equal(t, sets.New[types.UID]("pending-uid", "other-approver-uid", "approved-before-start-uid", "denied-before-start-uid", "denied-reviewed-uid"), uids)
+
+	_, ok := store.Get("deleted-uid")
+	assert.False(t, ok, "expected the results of deleted requests to be pruned")
+}
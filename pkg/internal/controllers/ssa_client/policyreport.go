@@ -0,0 +1,41 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ssa_client
+
+import (
+	"encoding/json"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/cert-manager/approver-policy/pkg/internal/policyreport"
+)
+
+func GeneratePolicyReportPatch(report *policyreport.PolicyReport) (*unstructured.Unstructured, client.Patch, error) {
+	// This object is used to deduce the name & namespace + unmarshall the return value in
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(policyreport.GroupVersionKind)
+	obj.SetName(report.Name)
+	obj.SetNamespace(report.Namespace)
+
+	encodedPatch, err := json.Marshal(report)
+	if err != nil {
+		return obj, nil, err
+	}
+
+	return obj, applyPatch{encodedPatch}, nil
+}
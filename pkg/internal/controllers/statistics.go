@@ -0,0 +1,357 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver/manager"
+	"github.com/cert-manager/approver-policy/pkg/internal/controllers/ssa_client"
+	"github.com/cert-manager/approver-policy/pkg/internal/rbac"
+)
+
+// statisticsFieldManager is the field manager used to apply the statistics
+// status of CertificateRequestPolicies. It is distinct from the field managers
+// of the policy controller and compliance checker so that none removes the
+// status fields of the others.
+const statisticsFieldManager = "approver-policy-statistics"
+
+// statisticsMaxSubjects is the maximum number of bound subjects listed in the
+// status of a CertificateRequestPolicy.
+const statisticsMaxSubjects = 50
+
+// statistics counts the approvals and denials made by each
+// CertificateRequestPolicy, and periodically writes them, along with how
+// widely each policy is bound via RBAC, to the status of the policies.
+// Decisions are accumulated in memory between writes so that busy policies do
+// not cause a status write for every CertificateRequest.
+type statistics struct {
+	// log is logger for the statistics reporter.
+	log logr.Logger
+
+	// clock returns time which can be overwritten for testing.
+	clock clock.Clock
+
+	// client is a Kubernetes REST client to interact with objects in the API
+	// server.
+	client client.Client
+
+	// lister makes requests to the informer cache for getting and listing
+	// objects.
+	lister client.Reader
+
+	// interval is the duration between writes of the statistics.
+	interval time.Duration
+
+	// subjects lists the subjects bound to each policy in its status.
+	subjects bool
+
+	lock sync.Mutex
+
+	// deltas are the decisions recorded for each policy since the last write.
+	deltas map[string]*statisticsDelta
+
+	// totals are the statistics of each policy, seeded from the status of the
+	// policy the first time it is written.
+	totals map[string]*policyapi.CertificateRequestPolicyStatistics
+
+	// written are the statistics last successfully written to each policy.
+	written map[string]*policyapi.CertificateRequestPolicyStatistics
+}
+
+// statisticsDelta are the decisions recorded for a policy since the last
+// write.
+type statisticsDelta struct {
+	approved, denied int64
+	lastApproved     *metav1.Time
+	lastDenied       *metav1.Time
+	lastDenialReason string
+}
+
+// newStatistics returns the statistics reporter, or nil if disabled.
+func newStatistics(opts Options) *statistics {
+	if opts.PolicyStatisticsInterval <= 0 {
+		return nil
+	}
+
+	return &statistics{
+		log:      opts.Log.WithName("statistics"),
+		clock:    clock.RealClock{},
+		client:   opts.Manager.GetClient(),
+		lister:   opts.Manager.GetCache(),
+		interval: opts.PolicyStatisticsInterval,
+		subjects: opts.PolicyStatisticsSubjects,
+		deltas:   make(map[string]*statisticsDelta),
+		totals:   make(map[string]*policyapi.CertificateRequestPolicyStatistics),
+		written:  make(map[string]*policyapi.CertificateRequestPolicyStatistics),
+	}
+}
+
+// addStatisticsReporter will register the statistics reporter with the
+// controller-runtime Manager, if enabled.
+func addStatisticsReporter(_ context.Context, opts Options, s *statistics) error {
+	if s == nil {
+		return nil
+	}
+	return opts.Manager.Add(s)
+}
+
+// Start writes the statistics every interval until the context is cancelled.
+func (s *statistics) Start(ctx context.Context) error {
+	s.log.Info("starting policy statistics reporter", "interval", s.interval)
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := s.write(ctx); err != nil {
+			s.log.Error(err, "failed to write CertificateRequestPolicy statistics")
+		}
+	}, s.interval)
+	return nil
+}
+
+// Record counts the decision made on a CertificateRequest against the
+// policies which made it. An approval is counted against the approving
+// policy, and a denial against every policy which denied the request.
+func (s *statistics) Record(result manager.ReviewResult, response manager.ReviewResponse) {
+	now := &metav1.Time{Time: s.clock.Now()}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	switch result {
+	case manager.ResultApproved:
+		if len(response.Policy) == 0 {
+			return
+		}
+		delta := s.delta(response.Policy)
+		delta.approved++
+		delta.lastApproved = now
+
+	case manager.ResultDenied:
+		var policies []string
+		reasons := make(map[string][]string)
+		for _, denial := range response.Denials {
+			if _, ok := reasons[denial.Policy]; !ok {
+				policies = append(policies, denial.Policy)
+				reasons[denial.Policy] = nil
+			}
+			reason := denial.Field
+			if len(reason) == 0 {
+				reason = denial.Evaluator
+			}
+			if len(reason) > 0 && !slices.Contains(reasons[denial.Policy], reason) {
+				reasons[denial.Policy] = append(reasons[denial.Policy], reason)
+			}
+		}
+
+		for _, policy := range policies {
+			delta := s.delta(policy)
+			delta.denied++
+			delta.lastDenied = now
+			delta.lastDenialReason = strings.Join(reasons[policy], ", ")
+		}
+	}
+}
+
+// delta returns the delta of the policy, creating it if needed. Must be
+// called with the lock held.
+func (s *statistics) delta(policy string) *statisticsDelta {
+	delta, ok := s.deltas[policy]
+	if !ok {
+		delta = new(statisticsDelta)
+		s.deltas[policy] = delta
+	}
+	return delta
+}
+
+// write applies the recorded decisions and RBAC bindings to the statistics of
+// every CertificateRequestPolicy, and writes the statistics of the policies
+// which have changed since they were last written.
+func (s *statistics) write(ctx context.Context) error {
+	var policies policyapi.CertificateRequestPolicyList
+	if err := s.lister.List(ctx, &policies); err != nil {
+		return fmt.Errorf("failed to list CertificateRequestPolicies: %w", err)
+	}
+
+	snapshot, err := rbac.List(ctx, s.lister)
+	if err != nil {
+		return err
+	}
+
+	var namespaces corev1.NamespaceList
+	if err := s.lister.List(ctx, &namespaces); err != nil {
+		return fmt.Errorf("failed to list Namespaces: %w", err)
+	}
+
+	updates := s.update(policies.Items, snapshot, len(namespaces.Items))
+
+	var errs []error
+	for name, stats := range updates {
+		crp, patch, err := ssa_client.GenerateCertificateRequestPolicyStatusPatch(name, &policyapi.CertificateRequestPolicyStatus{Statistics: stats})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to generate CertificateRequestPolicy.Status patch for %q: %w", name, err))
+			continue
+		}
+
+		if err := s.client.Status().Patch(ctx, crp, patch, &client.SubResourcePatchOptions{
+			PatchOptions: client.PatchOptions{
+				FieldManager: statisticsFieldManager,
+				Force:        ptr.To(true),
+			},
+		}); client.IgnoreNotFound(err) != nil {
+			errs = append(errs, fmt.Errorf("failed to apply CertificateRequestPolicy.Status patch for %q: %w", name, err))
+			continue
+		}
+
+		s.lock.Lock()
+		s.written[name] = stats
+		s.lock.Unlock()
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// update applies the recorded decisions and RBAC bindings to the totals of
+// the given policies, and returns the statistics of the policies which differ
+// from those last written. The totals of policies which no longer exist are
+// forgotten.
+func (s *statistics) update(policies []policyapi.CertificateRequestPolicy, snapshot *rbac.Snapshot, allNamespaces int) map[string]*policyapi.CertificateRequestPolicyStatistics {
+	now := &metav1.Time{Time: s.clock.Now()}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	exists := sets.New[string]()
+	updates := make(map[string]*policyapi.CertificateRequestPolicyStatistics)
+
+	for _, policy := range policies {
+		exists.Insert(policy.Name)
+
+		total, ok := s.totals[policy.Name]
+		if !ok {
+			total = new(policyapi.CertificateRequestPolicyStatistics)
+			if policy.Status.Statistics != nil {
+				total = policy.Status.Statistics.DeepCopy()
+			}
+			s.totals[policy.Name] = total
+		}
+
+		if delta, ok := s.deltas[policy.Name]; ok {
+			total.ApprovedCount += delta.approved
+			total.DeniedCount += delta.denied
+			if delta.lastApproved != nil {
+				total.LastApprovedTime = delta.lastApproved
+			}
+			if delta.lastDenied != nil {
+				total.LastDeniedTime = delta.lastDenied
+				total.LastDenialReason = delta.lastDenialReason
+			}
+		}
+
+		grants := snapshot.WhoCan(rbac.UsePolicy(policy.Name))
+		total.BoundNamespaces, total.BoundSubjects = countBindings(grants, allNamespaces)
+		total.Subjects = nil
+		if s.subjects {
+			total.Subjects = boundSubjects(grants)
+		}
+
+		if written, ok := s.written[policy.Name]; ok && statisticsEqual(written, total) {
+			continue
+		}
+
+		total.LastUpdateTime = now
+		updates[policy.Name] = total.DeepCopy()
+	}
+
+	// Decisions of policies which do not exist can never be written.
+	clear(s.deltas)
+
+	for name := range s.totals {
+		if !exists.Has(name) {
+			delete(s.totals, name)
+			delete(s.written, name)
+		}
+	}
+
+	return updates
+}
+
+// countBindings returns the number of namespaces in which the grants apply,
+// and the number of distinct subjects granted. A grant in all namespaces
+// counts every namespace.
+func countBindings(grants []rbac.Grant, allNamespaces int) (int32, int32) {
+	namespaces := sets.New[string]()
+	subjects := sets.New[rbacv1.Subject]()
+	clusterWide := false
+
+	for _, grant := range grants {
+		if len(grant.Namespace) == 0 {
+			clusterWide = true
+		}
+		namespaces.Insert(grant.Namespace)
+		subjects.Insert(rbacv1.Subject{Kind: grant.Subject.Kind, Namespace: grant.Subject.Namespace, Name: grant.Subject.Name})
+	}
+
+	boundNamespaces := namespaces.Len()
+	if clusterWide {
+		boundNamespaces = allNamespaces
+	}
+
+	return int32(boundNamespaces), int32(subjects.Len()) // #nosec G115 -- The number of namespaces and subjects cannot overflow int32.
+}
+
+// boundSubjects returns the subjects of the grants for the status of a
+// policy, truncated to statisticsMaxSubjects.
+func boundSubjects(grants []rbac.Grant) []policyapi.CertificateRequestPolicyBoundSubject {
+	var subjects []policyapi.CertificateRequestPolicyBoundSubject
+	for _, bound := range rbac.BoundSubjects(grants) {
+		if len(subjects) == statisticsMaxSubjects {
+			break
+		}
+		subjects = append(subjects, policyapi.CertificateRequestPolicyBoundSubject{
+			Kind:       bound.Subject.Kind,
+			Name:       bound.Subject.Name,
+			Namespace:  bound.Subject.Namespace,
+			Namespaces: bound.Namespaces,
+		})
+	}
+	return subjects
+}
+
+// statisticsEqual returns true if the statistics are equal, ignoring when they
+// were last updated.
+func statisticsEqual(a, b *policyapi.CertificateRequestPolicyStatistics) bool {
+	a, b = a.DeepCopy(), b.DeepCopy()
+	a.LastUpdateTime, b.LastUpdateTime = nil, nil
+	return apiequality.Semantic.DeepEqual(a, b)
+}
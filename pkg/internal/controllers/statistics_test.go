@@ -0,0 +1,124 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2/ktesting"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver/manager"
+	"github.com/cert-manager/approver-policy/pkg/internal/rbac"
+)
+
+func Test_statistics(t *testing.T) {
+	var (
+		fixedTime     = time.Date(2021, 01, 01, 01, 0, 0, 0, time.UTC)
+		fixedmetatime = &metav1.Time{Time: fixedTime}
+	)
+
+	s := &statistics{
+		log:     ktesting.NewLogger(t, ktesting.DefaultConfig),
+		clock:   fakeclock.NewFakeClock(fixedTime),
+		deltas:  make(map[string]*statisticsDelta),
+		totals:  make(map[string]*policyapi.CertificateRequestPolicyStatistics),
+		written: make(map[string]*policyapi.CertificateRequestPolicyStatistics),
+	}
+
+	policies := []policyapi.CertificateRequestPolicy{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "policy-a"},
+			Status: policyapi.CertificateRequestPolicyStatus{Statistics: &policyapi.CertificateRequestPolicyStatistics{
+				ApprovedCount: 10,
+				DeniedCount:   5,
+			}},
+		},
+		{ObjectMeta: metav1.ObjectMeta{Name: "policy-b"}},
+	}
+
+	useRole := rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{Name: "use-policy-a"},
+		Rules:      []rbacv1.PolicyRule{{APIGroups: []string{"policy.cert-manager.io"}, Resources: []string{"certificaterequestpolicies"}, Verbs: []string{"use"}, ResourceNames: []string{"policy-a"}}},
+	}
+	snapshot := rbac.NewSnapshot([]rbacv1.ClusterRole{useRole}, nil, nil, []rbacv1.RoleBinding{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-a", Name: "a"}, RoleRef: rbacv1.RoleRef{Kind: "ClusterRole", Name: "use-policy-a"}, Subjects: []rbacv1.Subject{
+			{Kind: rbacv1.UserKind, Name: "user-a"},
+			{Kind: rbacv1.GroupKind, Name: "group-a"},
+		}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-b", Name: "b"}, RoleRef: rbacv1.RoleRef{Kind: "ClusterRole", Name: "use-policy-a"}, Subjects: []rbacv1.Subject{
+			{Kind: rbacv1.UserKind, Name: "user-a"},
+		}},
+	})
+
+	s.Record(manager.ResultApproved, manager.ReviewResponse{Result: manager.ResultApproved, Policy: "policy-a"})
+	s.Record(manager.ResultApproved, manager.ReviewResponse{Result: manager.ResultApproved, Policy: "policy-a"})
+	s.Record(manager.ResultDenied, manager.ReviewResponse{Result: manager.ResultDenied, Denials: []manager.Denial{
+		{Policy: "policy-a", Evaluator: "allowed", Field: "spec.allowed.dnsNames.values"},
+		{Policy: "policy-a", Evaluator: "allowed", Field: "spec.allowed.dnsNames.values"},
+		{Policy: "policy-a", Evaluator: "plugin"},
+		{Policy: "policy-b", Field: "spec.defaultAction"},
+	}})
+	s.Record(manager.ResultApproved, manager.ReviewResponse{Result: manager.ResultApproved, Policy: "deleted-policy"})
+
+	updates := s.update(policies, snapshot, 3)
+	assert.Equal(t, map[string]*policyapi.CertificateRequestPolicyStatistics{
+		"policy-a": {
+			LastUpdateTime:   fixedmetatime,
+			ApprovedCount:    12,
+			LastApprovedTime: fixedmetatime,
+			DeniedCount:      6,
+			LastDeniedTime:   fixedmetatime,
+			LastDenialReason: "spec.allowed.dnsNames.values, plugin",
+			BoundNamespaces:  2,
+			BoundSubjects:    2,
+		},
+		"policy-b": {
+			LastUpdateTime:   fixedmetatime,
+			DeniedCount:      1,
+			LastDeniedTime:   fixedmetatime,
+			LastDenialReason: "spec.defaultAction",
+		},
+	}, updates)
+
+	// Only policy-a is written, so policy-b should be updated again.
+	s.written["policy-a"] = updates["policy-a"]
+	updates = s.update(policies, snapshot, 3)
+	assert.Contains(t, updates, "policy-b")
+	assert.NotContains(t, updates, "policy-a", "expected unchanged statistics not to be written again")
+	assert.Equal(t, int64(1), updates["policy-b"].DeniedCount, "expected decisions to only be counted once")
+
+	// A ClusterRoleBinding binds the policy in all namespaces.
+	snapshot = rbac.NewSnapshot([]rbacv1.ClusterRole{useRole}, nil, []rbacv1.ClusterRoleBinding{
+		{ObjectMeta: metav1.ObjectMeta{Name: "all"}, RoleRef: rbacv1.RoleRef{Kind: "ClusterRole", Name: "use-policy-a"}, Subjects: []rbacv1.Subject{
+			{Kind: rbacv1.GroupKind, Name: "system:authenticated"},
+		}},
+	}, nil)
+	s.subjects = true
+	updates = s.update(policies[:1], snapshot, 3)
+	assert.Equal(t, int32(3), updates["policy-a"].BoundNamespaces)
+	assert.Equal(t, int32(1), updates["policy-a"].BoundSubjects)
+	assert.Equal(t, []policyapi.CertificateRequestPolicyBoundSubject{
+		{Kind: rbacv1.GroupKind, Name: "system:authenticated"},
+	}, updates["policy-a"].Subjects)
+	assert.NotContains(t, s.totals, "policy-b", "expected the statistics of deleted policies to be forgotten")
+}
@@ -0,0 +1,322 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package lint reports risky patterns in CertificateRequestPolicies, along
+// with problems that would cause them to be rejected when applied.
+package lint
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver"
+	"github.com/cert-manager/approver-policy/pkg/internal/approver/validation"
+	"github.com/cert-manager/approver-policy/pkg/internal/shadow"
+	"github.com/cert-manager/approver-policy/pkg/internal/util"
+)
+
+// Policy is a CertificateRequestPolicy to be linted.
+type Policy struct {
+	// Source is where the policy was loaded from, e.g. a file path. Empty for
+	// policies loaded from a cluster.
+	Source string
+
+	// Policy is the CertificateRequestPolicy.
+	*policyapi.CertificateRequestPolicy
+}
+
+// Finding is a problem found in a CertificateRequestPolicy.
+type Finding struct {
+	// RuleID is the ID of the Rule which found the problem.
+	RuleID string `json:"ruleID"`
+
+	// Severity is the severity of the Rule.
+	Severity Severity `json:"severity"`
+
+	// Source is where the policy was loaded from.
+	Source string `json:"source,omitempty"`
+
+	// Policy is the name of the CertificateRequestPolicy.
+	Policy string `json:"policy"`
+
+	// Field is the path of the field of the policy the problem was found in.
+	Field string `json:"field,omitempty"`
+
+	// Message describes the problem.
+	Message string `json:"message"`
+}
+
+// Linter lints CertificateRequestPolicies against the rule catalogue, and the
+// validations of the registered approvers.
+type Linter struct {
+	approvers []approver.Interface
+}
+
+// New returns a Linter which validates policies with the given registered
+// approvers.
+func New(approvers ...approver.Interface) *Linter {
+	return &Linter{approvers: approvers}
+}
+
+// Lint lints the given policies, returning the findings ordered by source,
+// policy name, rule ID and field. Policies are also linted against each
+// other to find shadowed policies.
+func (l *Linter) Lint(ctx context.Context, policies []Policy) ([]Finding, error) {
+	all := make([]policyapi.CertificateRequestPolicy, 0, len(policies))
+	for _, policy := range policies {
+		all = append(all, *policy.CertificateRequestPolicy)
+	}
+
+	var findings []Finding
+	for _, policy := range policies {
+		report := func(rule Rule, fldPath *field.Path, message string) {
+			finding := Finding{
+				RuleID:   rule.ID,
+				Severity: rule.Severity,
+				Source:   policy.Source,
+				Policy:   policy.Name,
+				Message:  message,
+			}
+			if fldPath != nil {
+				finding.Field = fldPath.String()
+			}
+			findings = append(findings, finding)
+		}
+
+		if err := l.validate(ctx, policy.CertificateRequestPolicy, report); err != nil {
+			return nil, fmt.Errorf("failed to validate CertificateRequestPolicy %q: %w", policy.Name, err)
+		}
+		lintPolicy(policy.CertificateRequestPolicy, report)
+
+		for _, finding := range shadow.Find(policy.CertificateRequestPolicy, all) {
+			if finding.Relation != shadow.RelationShadows {
+				report(RuleShadowedPolicy, nil, "policy "+finding.String())
+			}
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.Source != b.Source {
+			return a.Source < b.Source
+		}
+		if a.Policy != b.Policy {
+			return a.Policy < b.Policy
+		}
+		if a.RuleID != b.RuleID {
+			return a.RuleID < b.RuleID
+		}
+		return a.Field < b.Field
+	})
+
+	return findings, nil
+}
+
+// validate reports the problems which would cause the policy to be rejected
+// when applied, using the validations of the registered approvers.
+func (l *Linter) validate(ctx context.Context, policy *policyapi.CertificateRequestPolicy, report func(Rule, *field.Path, string)) error {
+	fldPath := field.NewPath("spec")
+
+	if policy.Spec.Selector.IssuerRef == nil && policy.Spec.Selector.Namespace == nil {
+		report(RuleInvalidPolicy, fldPath.Child("selector"), "one of issuerRef or namespace must be defined, hint: `{}` on either matches everything")
+	}
+
+	registered := make(map[string]approver.Interface)
+	for _, a := range l.approvers {
+		registered[a.Name()] = a
+	}
+
+	var names []string
+	for name := range policy.Spec.Plugins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if _, ok := registered[name]; !ok || name == "allowed" || name == "constraints" {
+			report(RuleUnregisteredPlugin, fldPath.Child("plugins").Key(name), fmt.Sprintf("plugin %q is not registered to this approver-policy build", name))
+		}
+	}
+
+	// Plugin values are defaulted and validated the same way the webhook
+	// does, and the approvers then validate the defaulted policy.
+	policy, schemaErrs := approver.DefaultAndValidatePlugins(approver.PluginSchemas(l.approvers...), policy)
+	for _, err := range schemaErrs {
+		report(RuleInvalidPolicy, nil, err.Error())
+	}
+
+	for _, a := range l.approvers {
+		response, err := a.Validate(ctx, policy)
+		if err != nil {
+			return fmt.Errorf("approver %q: %w", a.Name(), err)
+		}
+		for _, err := range response.Errors {
+			report(RuleInvalidPolicy, nil, err.Error())
+		}
+		if !response.Allowed && len(response.Errors) == 0 {
+			report(RuleInvalidPolicy, nil, fmt.Sprintf("approver %q did not allow the policy for unknown reasons", a.Name()))
+		}
+		for _, warning := range response.Warnings {
+			report(RulePluginWarning, nil, warning)
+		}
+	}
+
+	return nil
+}
+
+// lintPolicy reports the risky patterns of the rule catalogue found in the
+// policy.
+func lintPolicy(policy *policyapi.CertificateRequestPolicy, report func(Rule, *field.Path, string)) {
+	fldPath := field.NewPath("spec")
+	allowed := policy.Spec.Allowed
+	if allowed == nil {
+		allowed = new(policyapi.CertificateRequestPolicyAllowed)
+	}
+	constraints := policy.Spec.Constraints
+	if constraints == nil {
+		constraints = new(policyapi.CertificateRequestPolicyConstraints)
+	}
+
+	allowedPath := fldPath.Child("allowed")
+	for _, san := range []struct {
+		name  string
+		slice *policyapi.CertificateRequestPolicyAllowedStringSlice
+	}{
+		{"dnsNames", allowed.DNSNames},
+		{"ipAddresses", allowed.IPAddresses},
+		{"uris", allowed.URIs},
+		{"emailAddresses", allowed.EmailAddresses},
+	} {
+		if san.slice == nil || san.slice.Values == nil {
+			continue
+		}
+		for i, value := range *san.slice.Values {
+			if util.WildcardMatches(value, "*") {
+				report(RuleAnySAN, allowedPath.Child(san.name, "values").Index(i), fmt.Sprintf("%q allows any %s", value, san.name))
+			}
+		}
+	}
+
+	if allowed.IsCA != nil && *allowed.IsCA && constraints.PrivateKey == nil {
+		report(RuleCAWithoutKeyConstraints, allowedPath.Child("isCA"), "isCA is allowed without constraints.privateKey")
+	}
+
+	if constraints.MaxDuration == nil {
+		report(RuleNoMaxDuration, fldPath.Child("constraints", "maxDuration"), "maxDuration is not set")
+	}
+
+	if selectsAllIssuers(policy.Spec.Selector.IssuerRef) && selectsAllNamespaces(policy.Spec.Selector.Namespace) &&
+		(policy.Spec.Selector.IssuerRef != nil || policy.Spec.Selector.Namespace != nil) {
+		report(RuleSelectsEverything, fldPath.Child("selector"), "the selector matches requests for every issuer in every namespace")
+	}
+
+	for _, v := range allowedValidations(allowedPath, allowed) {
+		alwaysTrue, err := validation.AlwaysTrue(v.rule)
+		if err != nil {
+			// Invalid rules are reported by the allowed approver's validation.
+			continue
+		}
+		if alwaysTrue {
+			report(RuleCELAlwaysTrue, v.fldPath, fmt.Sprintf("rule %q always evaluates to true", v.rule))
+		}
+	}
+}
+
+// selectsAllIssuers returns true if the issuerRef selector matches every
+// issuer.
+func selectsAllIssuers(sel *policyapi.CertificateRequestPolicySelectorIssuerRef) bool {
+	if sel == nil {
+		return true
+	}
+	for _, pattern := range []*string{sel.Name, sel.Kind, sel.Group} {
+		if pattern != nil && !util.WildcardMatches(*pattern, "*") {
+			return false
+		}
+	}
+	return true
+}
+
+// selectsAllNamespaces returns true if the namespace selector matches every
+// namespace.
+func selectsAllNamespaces(sel *policyapi.CertificateRequestPolicySelectorNamespace) bool {
+	if sel == nil {
+		return true
+	}
+	if len(sel.MatchLabels) > 0 {
+		return false
+	}
+	return len(sel.MatchNames) == 0 || util.WildcardContains(sel.MatchNames, "*")
+}
+
+// celValidation is a CEL validation rule, and the path of the field it is
+// defined on.
+type celValidation struct {
+	fldPath *field.Path
+	rule    string
+}
+
+// allowedValidations returns every CEL validation rule defined in allowed.
+func allowedValidations(fldPath *field.Path, allowed *policyapi.CertificateRequestPolicyAllowed) []celValidation {
+	var validations []celValidation
+	addString := func(fldPath *field.Path, s *policyapi.CertificateRequestPolicyAllowedString) {
+		if s == nil {
+			return
+		}
+		for i, v := range s.Validations {
+			validations = append(validations, celValidation{fldPath: fldPath.Child("validations").Index(i).Child("rule"), rule: v.Rule})
+		}
+	}
+	addSlice := func(fldPath *field.Path, s *policyapi.CertificateRequestPolicyAllowedStringSlice) {
+		if s == nil {
+			return
+		}
+		for i, v := range s.Validations {
+			validations = append(validations, celValidation{fldPath: fldPath.Child("validations").Index(i).Child("rule"), rule: v.Rule})
+		}
+	}
+
+	addString(fldPath.Child("commonName"), allowed.CommonName)
+	addSlice(fldPath.Child("dnsNames"), allowed.DNSNames)
+	addSlice(fldPath.Child("ipAddresses"), allowed.IPAddresses)
+	addSlice(fldPath.Child("uris"), allowed.URIs)
+	addSlice(fldPath.Child("emailAddresses"), allowed.EmailAddresses)
+	addSlice(fldPath.Child("registeredIDs"), allowed.RegisteredIDs)
+	for i := range allowed.OtherNames {
+		addSlice(fldPath.Child("otherNames").Index(i), &allowed.OtherNames[i].CertificateRequestPolicyAllowedStringSlice)
+	}
+
+	if subject := allowed.Subject; subject != nil {
+		subjectPath := fldPath.Child("subject")
+		addSlice(subjectPath.Child("organizations"), subject.Organizations)
+		addSlice(subjectPath.Child("countries"), subject.Countries)
+		addSlice(subjectPath.Child("organizationalUnits"), subject.OrganizationalUnits)
+		addSlice(subjectPath.Child("localities"), subject.Localities)
+		addSlice(subjectPath.Child("provinces"), subject.Provinces)
+		addSlice(subjectPath.Child("streetAddresses"), subject.StreetAddresses)
+		addSlice(subjectPath.Child("postalCodes"), subject.PostalCodes)
+		addString(subjectPath.Child("serialNumber"), subject.SerialNumber)
+		addString(subjectPath.Child("dn"), subject.DN)
+		for i := range subject.ExtraAttributes {
+			addSlice(subjectPath.Child("extraAttributes").Index(i), &subject.ExtraAttributes[i].CertificateRequestPolicyAllowedStringSlice)
+		}
+	}
+
+	return validations
+}
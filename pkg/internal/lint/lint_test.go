@@ -0,0 +1,197 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver"
+	fakeapprover "github.com/cert-manager/approver-policy/pkg/approver/fake"
+)
+
+func Test_Lint(t *testing.T) {
+	fakePlugin := fakeapprover.NewFakeApprover().WithReconciler(fakeapprover.NewFakeReconciler().WithName("fake"))
+	fakePlugin.FakeWebhook = fakeapprover.NewFakeWebhook().WithValidate(func(_ context.Context, policy *policyapi.CertificateRequestPolicy) (approver.WebhookValidationResponse, error) {
+		if _, ok := policy.Spec.Plugins["fake"]; !ok {
+			return approver.WebhookValidationResponse{Allowed: true}, nil
+		}
+		return approver.WebhookValidationResponse{
+			Allowed:  false,
+			Errors:   field.ErrorList{field.Required(field.NewPath("spec", "plugins", "fake", "values", "foo"), "foo is required")},
+			Warnings: admission.Warnings{"fake plugin is deprecated"},
+		}, nil
+	})
+
+	safe := policyapi.CertificateRequestPolicySpec{
+		Allowed: &policyapi.CertificateRequestPolicyAllowed{
+			DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"*.example.com"}},
+		},
+		Constraints: &policyapi.CertificateRequestPolicyConstraints{MaxDuration: &metav1.Duration{Duration: time.Hour}},
+		Selector: policyapi.CertificateRequestPolicySelector{
+			IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{Name: ptr.To("my-issuer")},
+		},
+	}
+
+	tests := map[string]struct {
+		policies    []policyapi.CertificateRequestPolicy
+		expFindings []Finding
+	}{
+		"a policy without risky patterns has no findings": {
+			policies:    []policyapi.CertificateRequestPolicy{{ObjectMeta: metav1.ObjectMeta{Name: "safe"}, Spec: safe}},
+			expFindings: nil,
+		},
+		"a policy with risky patterns reports each of them": {
+			policies: []policyapi.CertificateRequestPolicy{{
+				ObjectMeta: metav1.ObjectMeta{Name: "risky"},
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Allowed: &policyapi.CertificateRequestPolicyAllowed{
+						DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{
+							Values:      &[]string{"*"},
+							Validations: []policyapi.ValidationRule{{Rule: "true || self.endsWith('.com')"}},
+						},
+						IsCA: ptr.To(true),
+					},
+					Selector: policyapi.CertificateRequestPolicySelector{
+						IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{},
+						Namespace: &policyapi.CertificateRequestPolicySelectorNamespace{},
+					},
+				},
+			}},
+			expFindings: []Finding{
+				{RuleID: "AP003", Severity: SeverityError, Policy: "risky", Field: "spec.allowed.dnsNames.values[0]", Message: `"*" allows any dnsNames`},
+				{RuleID: "AP004", Severity: SeverityError, Policy: "risky", Field: "spec.allowed.isCA", Message: "isCA is allowed without constraints.privateKey"},
+				{RuleID: "AP005", Severity: SeverityWarning, Policy: "risky", Field: "spec.constraints.maxDuration", Message: "maxDuration is not set"},
+				{RuleID: "AP006", Severity: SeverityWarning, Policy: "risky", Field: "spec.selector", Message: "the selector matches requests for every issuer in every namespace"},
+				{RuleID: "AP007", Severity: SeverityWarning, Policy: "risky", Field: "spec.allowed.dnsNames.validations[0].rule", Message: `rule "true || self.endsWith('.com')" always evaluates to true`},
+			},
+		},
+		"a policy with invalid and unregistered plugins reports them with the approver warnings": {
+			policies: []policyapi.CertificateRequestPolicy{{
+				ObjectMeta: metav1.ObjectMeta{Name: "plugins"},
+				Spec: func() policyapi.CertificateRequestPolicySpec {
+					spec := *safe.DeepCopy()
+					spec.Plugins = map[string]policyapi.CertificateRequestPolicyPluginData{"fake": {}, "rego": {}}
+					return spec
+				}(),
+			}},
+			expFindings: []Finding{
+				{RuleID: "AP001", Severity: SeverityError, Policy: "plugins", Message: "spec.plugins.fake.values.foo: Required value: foo is required"},
+				{RuleID: "AP002", Severity: SeverityError, Policy: "plugins", Field: "spec.plugins[rego]", Message: `plugin "rego" is not registered to this approver-policy build`},
+				{RuleID: "AP009", Severity: SeverityWarning, Policy: "plugins", Message: "fake plugin is deprecated"},
+			},
+		},
+		"a policy shadowed by another policy is reported": {
+			policies: []policyapi.CertificateRequestPolicy{
+				{ObjectMeta: metav1.ObjectMeta{Name: "safe"}, Spec: safe},
+				{ObjectMeta: metav1.ObjectMeta{Name: "stricter"}, Spec: func() policyapi.CertificateRequestPolicySpec {
+					spec := *safe.DeepCopy()
+					spec.Allowed.DNSNames.Values = &[]string{"foo.example.com"}
+					return spec
+				}()},
+			},
+			expFindings: []Finding{
+				{RuleID: "AP008", Severity: SeverityWarning, Policy: "stricter", Message: `policy is shadowed by CertificateRequestPolicy "safe": "safe" selects and permits every request this policy does, so this policy has no effect for requesters bound to both`},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var policies []Policy
+			for i := range test.policies {
+				policies = append(policies, Policy{CertificateRequestPolicy: &test.policies[i]})
+			}
+
+			findings, err := New(fakePlugin).Lint(t.Context(), policies)
+			require.NoError(t, err)
+			assert.Equal(t, test.expFindings, findings)
+		})
+	}
+}
+
+func Test_LoadFiles(t *testing.T) {
+	const input = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: ignored
+---
+apiVersion: policy.cert-manager.io/v1alpha1
+kind: CertificateRequestPolicy
+metadata:
+  name: a
+spec:
+  selector:
+    issuerRef: {}
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: policy.cert-manager.io/v1alpha1
+  kind: CertificateRequestPolicy
+  metadata:
+    name: b
+  spec:
+    selector:
+      namespace: {}
+`
+
+	policies, err := LoadFiles(strings.NewReader(input), "-")
+	require.NoError(t, err)
+	require.Len(t, policies, 2)
+	assert.Equal(t, "<stdin>", policies[0].Source)
+	assert.Equal(t, "a", policies[0].Name)
+	assert.Equal(t, "b", policies[1].Name)
+
+	_, err = LoadFiles(strings.NewReader("apiVersion: policy.cert-manager.io/v1alpha1\nkind: CertificateRequestPolicy\nspec:\n  unknown: {}\n"), "-")
+	assert.ErrorContains(t, err, `unknown field "unknown"`)
+}
+
+func Test_WriteSARIF(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteSARIF(&buf, []Finding{
+		{RuleID: "AP005", Severity: SeverityWarning, Source: "policies.yaml", Policy: "a", Field: "spec.constraints.maxDuration", Message: "maxDuration is not set"},
+	}))
+
+	var log sarifLog
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &log))
+	assert.Equal(t, "2.1.0", log.Version)
+	require.Len(t, log.Runs, 1)
+	assert.Len(t, log.Runs[0].Tool.Driver.Rules, len(Rules()))
+	assert.Equal(t, []sarifResult{{
+		RuleID:    "AP005",
+		RuleIndex: 4,
+		Level:     SeverityWarning,
+		Message:   sarifMessage{Text: "maxDuration is not set"},
+		Locations: []sarifLocation{{
+			PhysicalLocation: &sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: "policies.yaml"}},
+			LogicalLocations: []sarifLogicalLocation{{Name: "a", FullyQualifiedName: "CertificateRequestPolicy/a/spec.constraints.maxDuration", Kind: "resource"}},
+		}},
+	}}, log.Runs[0].Results)
+}
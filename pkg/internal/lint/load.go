@@ -0,0 +1,171 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+)
+
+// LoadFiles loads the CertificateRequestPolicies from the given YAML or JSON
+// files. Directories are walked for files with a .yaml, .yml or .json
+// extension. A path of "-" reads from stdin. Documents which are not
+// CertificateRequestPolicies are ignored, and List documents are expanded.
+func LoadFiles(stdin io.Reader, paths ...string) ([]Policy, error) {
+	var policies []Policy
+	for _, path := range paths {
+		if path == "-" {
+			loaded, err := decode("<stdin>", stdin)
+			if err != nil {
+				return nil, err
+			}
+			policies = append(policies, loaded...)
+			continue
+		}
+
+		err := filepath.WalkDir(path, func(file string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+			// Only filter by extension when walking directories, so that
+			// explicitly given files are always loaded.
+			if file != path {
+				switch strings.ToLower(filepath.Ext(file)) {
+				case ".yaml", ".yml", ".json":
+				default:
+					return nil
+				}
+			}
+
+			f, err := os.Open(file)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			loaded, err := decode(file, f)
+			if err != nil {
+				return err
+			}
+			policies = append(policies, loaded...)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return policies, nil
+}
+
+// LoadCluster loads every CertificateRequestPolicy from the cluster.
+func LoadCluster(ctx context.Context, lister client.Reader) ([]Policy, error) {
+	var list policyapi.CertificateRequestPolicyList
+	if err := lister.List(ctx, &list); err != nil {
+		return nil, fmt.Errorf("failed to list CertificateRequestPolicies: %w", err)
+	}
+
+	policies := make([]Policy, 0, len(list.Items))
+	for i := range list.Items {
+		policies = append(policies, Policy{CertificateRequestPolicy: &list.Items[i]})
+	}
+	return policies, nil
+}
+
+// decode decodes the CertificateRequestPolicies of every YAML or JSON
+// document read from r.
+func decode(source string, r io.Reader) ([]Policy, error) {
+	var policies []Policy
+
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(r))
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return policies, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to read document: %w", source, err)
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+
+		loaded, err := decodeDocument(source, doc)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, loaded...)
+	}
+}
+
+// decodeDocument decodes a single document, which may be a
+// CertificateRequestPolicy or a List of objects.
+func decodeDocument(source string, doc []byte) ([]Policy, error) {
+	var typeMeta metav1.TypeMeta
+	if err := yaml.Unmarshal(doc, &typeMeta); err != nil {
+		return nil, fmt.Errorf("%s: failed to decode document: %w", source, err)
+	}
+
+	gv := policyapi.SchemeGroupVersion.String()
+	switch {
+	case typeMeta.Kind == "CertificateRequestPolicy" && typeMeta.APIVersion == gv:
+		policy := new(policyapi.CertificateRequestPolicy)
+		if err := yaml.UnmarshalStrict(doc, policy); err != nil {
+			return nil, fmt.Errorf("%s: failed to decode CertificateRequestPolicy: %w", source, err)
+		}
+		return []Policy{{Source: source, CertificateRequestPolicy: policy}}, nil
+
+	case strings.HasSuffix(typeMeta.Kind, "List"):
+		var list struct {
+			Items []json.RawMessage `json:"items"`
+		}
+		if err := yaml.Unmarshal(doc, &list); err != nil {
+			return nil, fmt.Errorf("%s: failed to decode %s: %w", source, typeMeta.Kind, err)
+		}
+		var policies []Policy
+		for _, item := range list.Items {
+			loaded, err := decodeDocument(source, item)
+			if err != nil {
+				return nil, err
+			}
+			policies = append(policies, loaded...)
+		}
+		return policies, nil
+
+	default:
+		return nil, nil
+	}
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Count returns the number of findings with the given severity.
+func Count(findings []Finding, severity Severity) int {
+	var n int
+	for _, finding := range findings {
+		if finding.Severity == severity {
+			n++
+		}
+	}
+	return n
+}
+
+// WriteText writes the findings one per line, followed by a summary.
+func WriteText(w io.Writer, findings []Finding) error {
+	names := make(map[string]string)
+	for _, rule := range Rules() {
+		names[rule.ID] = rule.Name
+	}
+
+	for _, finding := range findings {
+		var location []string
+		if len(finding.Source) > 0 {
+			location = append(location, finding.Source)
+		}
+		location = append(location, fmt.Sprintf("CertificateRequestPolicy %q", finding.Policy))
+		if len(finding.Field) > 0 {
+			location = append(location, finding.Field)
+		}
+		if _, err := fmt.Fprintf(w, "%s: %s %s %s: %s\n", strings.Join(location, ": "), finding.Severity, finding.RuleID, names[finding.RuleID], finding.Message); err != nil {
+			return err
+		}
+	}
+
+	if len(findings) == 0 {
+		_, err := fmt.Fprintln(w, "no problems found")
+		return err
+	}
+	_, err := fmt.Fprintf(w, "%d problems (%d errors, %d warnings)\n", len(findings), Count(findings, SeverityError), Count(findings, SeverityWarning))
+	return err
+}
+
+// jsonOutput is the JSON representation of the findings.
+type jsonOutput struct {
+	Findings []Finding `json:"findings"`
+	Errors   int       `json:"errors"`
+	Warnings int       `json:"warnings"`
+}
+
+// WriteJSON writes the findings as a JSON object, along with the number of
+// findings of each severity.
+func WriteJSON(w io.Writer, findings []Finding) error {
+	if findings == nil {
+		findings = []Finding{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(jsonOutput{
+		Findings: findings,
+		Errors:   Count(findings, SeverityError),
+		Warnings: Count(findings, SeverityWarning),
+	})
+}
+
+// The subset of SARIF 2.1.0 used to report findings.
+// https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+type (
+	sarifLog struct {
+		Version string     `json:"version"`
+		Schema  string     `json:"$schema"`
+		Runs    []sarifRun `json:"runs"`
+	}
+
+	sarifRun struct {
+		Tool    sarifTool     `json:"tool"`
+		Results []sarifResult `json:"results"`
+	}
+
+	sarifTool struct {
+		Driver sarifDriver `json:"driver"`
+	}
+
+	sarifDriver struct {
+		Name           string      `json:"name"`
+		InformationURI string      `json:"informationUri"`
+		Rules          []sarifRule `json:"rules"`
+	}
+
+	sarifRule struct {
+		ID                   string             `json:"id"`
+		Name                 string             `json:"name"`
+		ShortDescription     sarifMessage       `json:"shortDescription"`
+		DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+	}
+
+	sarifConfiguration struct {
+		Level Severity `json:"level"`
+	}
+
+	sarifMessage struct {
+		Text string `json:"text"`
+	}
+
+	sarifResult struct {
+		RuleID    string          `json:"ruleId"`
+		RuleIndex int             `json:"ruleIndex"`
+		Level     Severity        `json:"level"`
+		Message   sarifMessage    `json:"message"`
+		Locations []sarifLocation `json:"locations"`
+	}
+
+	sarifLocation struct {
+		PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+		LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+	}
+
+	sarifPhysicalLocation struct {
+		ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	}
+
+	sarifArtifactLocation struct {
+		URI string `json:"uri"`
+	}
+
+	sarifLogicalLocation struct {
+		Name               string `json:"name"`
+		FullyQualifiedName string `json:"fullyQualifiedName"`
+		Kind               string `json:"kind"`
+	}
+)
+
+// WriteSARIF writes the findings as a SARIF 2.1.0 log, for consumption by
+// code scanning tools in CI.
+func WriteSARIF(w io.Writer, findings []Finding) error {
+	rules := Rules()
+	ruleIndex := make(map[string]int, len(rules))
+	driver := sarifDriver{
+		Name:           "approver-policy",
+		InformationURI: "https://github.com/cert-manager/approver-policy",
+		Rules:          make([]sarifRule, 0, len(rules)),
+	}
+	for i, rule := range rules {
+		ruleIndex[rule.ID] = i
+		driver.Rules = append(driver.Rules, sarifRule{
+			ID:                   rule.ID,
+			Name:                 rule.Name,
+			ShortDescription:     sarifMessage{Text: rule.Description},
+			DefaultConfiguration: sarifConfiguration{Level: rule.Severity},
+		})
+	}
+
+	results := make([]sarifResult, 0, len(findings))
+	for _, finding := range findings {
+		fqn := "CertificateRequestPolicy/" + finding.Policy
+		if len(finding.Field) > 0 {
+			fqn += "/" + finding.Field
+		}
+		location := sarifLocation{
+			LogicalLocations: []sarifLogicalLocation{{Name: finding.Policy, FullyQualifiedName: fqn, Kind: "resource"}},
+		}
+		if len(finding.Source) > 0 {
+			location.PhysicalLocation = &sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: finding.Source}}
+		}
+
+		results = append(results, sarifResult{
+			RuleID:    finding.RuleID,
+			RuleIndex: ruleIndex[finding.RuleID],
+			Level:     finding.Severity,
+			Message:   sarifMessage{Text: finding.Message},
+			Locations: []sarifLocation{location},
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{
+		Version: "2.1.0",
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Runs:    []sarifRun{{Tool: sarifTool{Driver: driver}, Results: results}},
+	})
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+// Severity is the severity of a Rule. Severities are the levels of a SARIF
+// result.
+type Severity string
+
+const (
+	// SeverityError is a problem that should block the policy from being
+	// applied.
+	SeverityError Severity = "error"
+
+	// SeverityWarning is a risky pattern that should be reviewed.
+	SeverityWarning Severity = "warning"
+)
+
+// Rule is a check which the linter runs on every CertificateRequestPolicy.
+type Rule struct {
+	// ID is the stable identifier of the rule.
+	ID string `json:"id"`
+
+	// Name is a short human readable name of the rule.
+	Name string `json:"name"`
+
+	// Severity of the findings of the rule.
+	Severity Severity `json:"severity"`
+
+	// Description explains what the rule detects and why it is risky.
+	Description string `json:"description"`
+}
+
+var (
+	// RuleInvalidPolicy reports policies rejected by the validation of a
+	// registered approver, so would be rejected when applied.
+	RuleInvalidPolicy = Rule{
+		ID:          "AP001",
+		Name:        "invalid-policy",
+		Severity:    SeverityError,
+		Description: "The policy is rejected by the validation of a registered approver, so cannot be applied.",
+	}
+
+	// RuleUnregisteredPlugin reports plugins which are not registered to this
+	// build of approver-policy.
+	RuleUnregisteredPlugin = Rule{
+		ID:          "AP002",
+		Name:        "unregistered-plugin",
+		Severity:    SeverityError,
+		Description: "The policy references a plugin which is not registered to this approver-policy build, so cannot be applied.",
+	}
+
+	// RuleAnySAN reports SANs allowing any value.
+	RuleAnySAN = Rule{
+		ID:          "AP003",
+		Name:        "any-san-allowed",
+		Severity:    SeverityError,
+		Description: "The policy allows any value of a SAN type, such as `dnsNames: [\"*\"]`, so requesters can impersonate any identity of that type.",
+	}
+
+	// RuleCAWithoutKeyConstraints reports policies allowing CA certificates
+	// without constraining their private keys.
+	RuleCAWithoutKeyConstraints = Rule{
+		ID:          "AP004",
+		Name:        "ca-without-key-constraints",
+		Severity:    SeverityError,
+		Description: "The policy allows isCA without `constraints.privateKey`, so CA certificates may be issued for weak keys.",
+	}
+
+	// RuleNoMaxDuration reports policies which don't limit the duration of
+	// certificates.
+	RuleNoMaxDuration = Rule{
+		ID:          "AP005",
+		Name:        "no-max-duration",
+		Severity:    SeverityWarning,
+		Description: "The policy does not set `constraints.maxDuration`, so certificates are issued for as long as the issuer permits.",
+	}
+
+	// RuleSelectsEverything reports policies selecting every request.
+	RuleSelectsEverything = Rule{
+		ID:          "AP006",
+		Name:        "selects-everything",
+		Severity:    SeverityWarning,
+		Description: "The policy selects requests for every issuer in every namespace, e.g. `{}` on both selector fields.",
+	}
+
+	// RuleCELAlwaysTrue reports CEL validations which never reject a value.
+	RuleCELAlwaysTrue = Rule{
+		ID:          "AP007",
+		Name:        "cel-always-true",
+		Severity:    SeverityWarning,
+		Description: "A CEL validation always evaluates to true, so has no effect.",
+	}
+
+	// RuleShadowedPolicy reports policies which are made pointless by another
+	// linted policy.
+	RuleShadowedPolicy = Rule{
+		ID:          "AP008",
+		Name:        "shadowed-policy",
+		Severity:    SeverityWarning,
+		Description: "Another policy selects and permits every request this policy does, so this policy has no effect for requesters bound to both.",
+	}
+
+	// RulePluginWarning reports warnings returned by the validation of a
+	// registered approver.
+	RulePluginWarning = Rule{
+		ID:          "AP009",
+		Name:        "approver-warning",
+		Severity:    SeverityWarning,
+		Description: "The validation of a registered approver returned a warning for the policy.",
+	}
+)
+
+// Rules returns the catalogue of rules run by the linter, ordered by ID.
+func Rules() []Rule {
+	return []Rule{
+		RuleInvalidPolicy,
+		RuleUnregisteredPlugin,
+		RuleAnySAN,
+		RuleCAWithoutKeyConstraints,
+		RuleNoMaxDuration,
+		RuleSelectsEverything,
+		RuleCELAlwaysTrue,
+		RuleShadowedPolicy,
+		RulePluginWarning,
+	}
+}
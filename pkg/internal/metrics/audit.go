@@ -0,0 +1,38 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// auditEntriesDroppedTotal counts the audit entries which were dropped
+	// because the audit buffer was full.
+	auditEntriesDroppedTotal = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "approverpolicy_audit_entries_dropped_total",
+			Help: "Number of audit entries dropped because the audit buffer was full.",
+		},
+	)
+)
+
+// RecordAuditEntryDropped records an audit entry which was dropped because the
+// audit buffer was full.
+func RecordAuditEntryDropped() {
+	auditEntriesDroppedTotal.Inc()
+}
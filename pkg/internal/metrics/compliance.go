@@ -0,0 +1,62 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// complianceApprovedCount is the number of existing CertificateRequests
+	// approved by each CertificateRequestPolicy, as seen by the most recent
+	// compliance check.
+	complianceApprovedCount = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "approverpolicy_compliance_approved_count",
+			Help: "Number of approved CertificateRequests re-reviewed by the most recent compliance check, by approving CertificateRequestPolicy.",
+		},
+		[]string{
+			"policy",
+		},
+	)
+
+	// complianceDriftedCount is the number of CertificateRequests approved by
+	// each CertificateRequestPolicy that would no longer be approved, as seen by
+	// the most recent compliance check.
+	complianceDriftedCount = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "approverpolicy_compliance_drifted_count",
+			Help: "Number of approved CertificateRequests that would no longer be approved by the current policies, by approving CertificateRequestPolicy.",
+		},
+		[]string{
+			"policy",
+		},
+	)
+)
+
+// RecordCompliance replaces the compliance metrics with the result of a
+// compliance check. Both maps are keyed by the name of the approving
+// CertificateRequestPolicy.
+func RecordCompliance(approved, drifted map[string]int) {
+	complianceApprovedCount.Reset()
+	complianceDriftedCount.Reset()
+
+	for policy, count := range approved {
+		complianceApprovedCount.WithLabelValues(policy).Set(float64(count))
+		complianceDriftedCount.WithLabelValues(policy).Set(float64(drifted[policy]))
+	}
+}
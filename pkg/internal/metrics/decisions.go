@@ -0,0 +1,113 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/cert-manager/approver-policy/pkg/approver/manager"
+)
+
+var (
+	// decisionsTotal counts the CertificateRequests which have been approved or
+	// denied, once the decision has been written to the request. Requests
+	// which are unprocessed or pending are not counted, since they are
+	// reviewed again on every reconcile. The policy label is the approving
+	// CertificateRequestPolicy, and is empty for denials.
+	decisionsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "approverpolicy_certificaterequest_decisions_total",
+			Help: "Number of CertificateRequests approved or denied, by result, approving CertificateRequestPolicy, issuer and namespace.",
+		},
+		[]string{
+			"result",
+			"policy",
+			"issuer_name",
+			"issuer_kind",
+			"issuer_group",
+			"namespace",
+		},
+	)
+
+	// denialsTotal counts the denials of CertificateRequests by each
+	// CertificateRequestPolicy, evaluator and policy field. A single denied
+	// review may increment several series, such as when a request violates
+	// many fields of a policy.
+	denialsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "approverpolicy_certificaterequest_denials_total",
+			Help: "Number of CertificateRequest denials, by denying CertificateRequestPolicy, evaluator and policy field.",
+		},
+		[]string{
+			"policy",
+			"evaluator",
+			"field",
+		},
+	)
+
+	// predicateEliminationsTotal counts the CertificateRequestPolicies removed
+	// from consideration by each predicate when reviewing CertificateRequests.
+	predicateEliminationsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "approverpolicy_predicate_eliminations_total",
+			Help: "Number of CertificateRequestPolicies removed from consideration when reviewing CertificateRequests, by predicate.",
+		},
+		[]string{
+			"predicate",
+		},
+	)
+)
+
+// RecordDecision records the decision made on a CertificateRequest from its
+// review. The result may differ from the result of the review, such as when an
+// unprocessed request is denied. Only approvals and denials are recorded, and
+// must only be recorded once they have been written to the request.
+func RecordDecision(cr *cmapi.CertificateRequest, result manager.ReviewResult, response manager.ReviewResponse) {
+	if result != manager.ResultApproved && result != manager.ResultDenied {
+		return
+	}
+
+	var policy string
+	if result == manager.ResultApproved {
+		policy = response.Policy
+	}
+
+	decisionsTotal.WithLabelValues(
+		result.String(),
+		policy,
+		cr.Spec.IssuerRef.Name,
+		cr.Spec.IssuerRef.Kind,
+		cr.Spec.IssuerRef.Group,
+		cr.Namespace,
+	).Inc()
+
+	if result == manager.ResultDenied {
+		for _, denial := range response.Denials {
+			denialsTotal.WithLabelValues(denial.Policy, denial.Evaluator, denial.Field).Inc()
+		}
+	}
+}
+
+// RecordEliminations records the CertificateRequestPolicies removed from
+// consideration by each predicate during a review. It must be recorded once
+// for every review, whatever its result.
+func RecordEliminations(response manager.ReviewResponse) {
+	for predicate, count := range response.Eliminations {
+		predicateEliminationsTotal.WithLabelValues(predicate).Add(float64(count))
+	}
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/cert-manager/approver-policy/pkg/approver/manager"
+)
+
+func Test_RecordDecision(t *testing.T) {
+	decisionsTotal.Reset()
+	denialsTotal.Reset()
+
+	cr := &cmapi.CertificateRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "bar"},
+		Spec: cmapi.CertificateRequestSpec{
+			IssuerRef: cmmeta.ObjectReference{Name: "issuer", Kind: "Issuer", Group: "cert-manager.io"},
+		},
+	}
+
+	RecordDecision(cr, manager.ResultApproved, manager.ReviewResponse{
+		Result: manager.ResultApproved,
+		Policy: "policy-a",
+	})
+	RecordDecision(cr, manager.ResultDenied, manager.ReviewResponse{
+		Result: manager.ResultDenied,
+		Denials: []manager.Denial{
+			{Policy: "policy-a", Evaluator: "allowed", Field: "spec.allowed.dnsNames.values"},
+			{Policy: "policy-a", Evaluator: "allowed", Field: "spec.allowed.commonName.value"},
+			{Policy: "policy-b", Evaluator: "constraints", Field: "spec.constraints.maxDuration"},
+		},
+	})
+	// An unprocessed request denied by the controller has no policy denials.
+	RecordDecision(cr, manager.ResultDenied, manager.ReviewResponse{Result: manager.ResultUnprocessed})
+	// Requests which are not approved or denied are not recorded.
+	RecordDecision(cr, manager.ResultPending, manager.ReviewResponse{Result: manager.ResultPending, Policy: "ignored"})
+	RecordDecision(cr, manager.ResultUnprocessed, manager.ReviewResponse{Result: manager.ResultUnprocessed})
+
+	const expected = `
+		# HELP approverpolicy_certificaterequest_decisions_total Number of CertificateRequests approved or denied, by result, approving CertificateRequestPolicy, issuer and namespace.
+		# TYPE approverpolicy_certificaterequest_decisions_total counter
+		approverpolicy_certificaterequest_decisions_total{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="issuer",namespace="bar",policy="",result="denied"} 2
+		approverpolicy_certificaterequest_decisions_total{issuer_group="cert-manager.io",issuer_kind="Issuer",issuer_name="issuer",namespace="bar",policy="policy-a",result="approved"} 1
+		# HELP approverpolicy_certificaterequest_denials_total Number of CertificateRequest denials, by denying CertificateRequestPolicy, evaluator and policy field.
+		# TYPE approverpolicy_certificaterequest_denials_total counter
+		approverpolicy_certificaterequest_denials_total{evaluator="allowed",field="spec.allowed.commonName.value",policy="policy-a"} 1
+		approverpolicy_certificaterequest_denials_total{evaluator="allowed",field="spec.allowed.dnsNames.values",policy="policy-a"} 1
+		approverpolicy_certificaterequest_denials_total{evaluator="constraints",field="spec.constraints.maxDuration",policy="policy-b"} 1
+	`
+
+	require.NoError(t, testutil.CollectAndCompare(decisionsTotal, strings.NewReader(expected), "approverpolicy_certificaterequest_decisions_total"))
+	require.NoError(t, testutil.CollectAndCompare(denialsTotal, strings.NewReader(expected), "approverpolicy_certificaterequest_denials_total"))
+}
+
+func Test_RecordEliminations(t *testing.T) {
+	predicateEliminationsTotal.Reset()
+
+	RecordEliminations(manager.ReviewResponse{
+		Result:       manager.ResultApproved,
+		Eliminations: map[string]int{"SelectorIssuerRef": 2},
+	})
+	RecordEliminations(manager.ReviewResponse{
+		Result:       manager.ResultDenied,
+		Eliminations: map[string]int{"SelectorIssuerRef": 1, "RBACBound": 1},
+	})
+	// Eliminations are recorded whatever the result of the review.
+	RecordEliminations(manager.ReviewResponse{Result: manager.ResultPending, Eliminations: map[string]int{"RBACBound": 1}})
+	RecordEliminations(manager.ReviewResponse{Result: manager.ResultUnprocessed, Eliminations: map[string]int{"SelectorIssuerRef": 3}})
+
+	const expected = `
+		# HELP approverpolicy_predicate_eliminations_total Number of CertificateRequestPolicies removed from consideration when reviewing CertificateRequests, by predicate.
+		# TYPE approverpolicy_predicate_eliminations_total counter
+		approverpolicy_predicate_eliminations_total{predicate="RBACBound"} 2
+		approverpolicy_predicate_eliminations_total{predicate="SelectorIssuerRef"} 6
+	`
+
+	require.NoError(t, testutil.CollectAndCompare(predicateEliminationsTotal, strings.NewReader(expected), "approverpolicy_predicate_eliminations_total"))
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Callers of reviews, recorded as the caller label of the review, predicate
+// and evaluator latencies, since reviews made while admitting requests are
+// latency sensitive in a way that reconciles are not.
+const (
+	// CallerController is the caller of reviews made by the
+	// CertificateRequest controller.
+	CallerController = "controller"
+
+	// CallerWebhook is the caller of reviews made by the admission webhook.
+	CallerWebhook = "webhook"
+)
+
+// latencyBuckets are the histogram buckets used for all latencies, ranging
+// from 1ms to ~8s.
+var latencyBuckets = prometheus.ExponentialBuckets(0.001, 2, 14)
+
+var (
+	// reviewDuration is the time taken to review a CertificateRequest, from
+	// listing CertificateRequestPolicies to returning the result. The result
+	// label is "error" if the review failed.
+	reviewDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "approverpolicy_review_duration_seconds",
+			Help:    "Time taken to review a CertificateRequest, by caller and result.",
+			Buckets: latencyBuckets,
+		},
+		[]string{
+			"caller",
+			"result",
+		},
+	)
+
+	// predicateDuration is the time taken by each predicate to filter the
+	// CertificateRequestPolicies for a CertificateRequest.
+	predicateDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "approverpolicy_predicate_duration_seconds",
+			Help:    "Time taken by a predicate to filter CertificateRequestPolicies for a CertificateRequest, by caller and predicate.",
+			Buckets: latencyBuckets,
+		},
+		[]string{
+			"caller",
+			"predicate",
+		},
+	)
+
+	// evaluatorDuration is the time taken by each evaluator to evaluate a
+	// CertificateRequest against a single CertificateRequestPolicy.
+	evaluatorDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "approverpolicy_evaluator_duration_seconds",
+			Help:    "Time taken by an evaluator to evaluate a CertificateRequest against a CertificateRequestPolicy, by caller and evaluator.",
+			Buckets: latencyBuckets,
+		},
+		[]string{
+			"caller",
+			"evaluator",
+		},
+	)
+
+	// subjectAccessReviewDuration is the round-trip time of the
+	// SubjectAccessReviews created to check whether a requester is bound to a
+	// CertificateRequestPolicy, including those which failed.
+	subjectAccessReviewDuration = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "approverpolicy_subjectaccessreview_duration_seconds",
+			Help:    "Round-trip time of SubjectAccessReviews checking whether a requester may use a CertificateRequestPolicy.",
+			Buckets: latencyBuckets,
+		},
+	)
+
+	// subjectAccessReviewErrorsTotal counts the SubjectAccessReviews which
+	// could not be created.
+	subjectAccessReviewErrorsTotal = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "approverpolicy_subjectaccessreview_errors_total",
+			Help: "Number of SubjectAccessReviews checking whether a requester may use a CertificateRequestPolicy which failed.",
+		},
+	)
+
+	// subjectAccessReviewCacheTotal counts the lookups of cached
+	// SubjectAccessReview results, by whether the result was cached.
+	subjectAccessReviewCacheTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "approverpolicy_subjectaccessreview_cache_total",
+			Help: "Number of lookups of cached SubjectAccessReview results, by result (hit or miss).",
+		},
+		[]string{
+			"result",
+		},
+	)
+)
+
+// ObserveReview records the time taken to review a CertificateRequest.
+func ObserveReview(caller, result string, duration time.Duration) {
+	reviewDuration.WithLabelValues(caller, result).Observe(duration.Seconds())
+}
+
+// ObservePredicate records the time taken by a predicate to filter
+// CertificateRequestPolicies.
+func ObservePredicate(caller, predicate string, duration time.Duration) {
+	predicateDuration.WithLabelValues(caller, predicate).Observe(duration.Seconds())
+}
+
+// ObserveEvaluator records the time taken by an evaluator to evaluate a
+// CertificateRequest against a CertificateRequestPolicy.
+func ObserveEvaluator(caller, evaluator string, duration time.Duration) {
+	evaluatorDuration.WithLabelValues(caller, evaluator).Observe(duration.Seconds())
+}
+
+// ObserveSubjectAccessReview records the round-trip time of a
+// SubjectAccessReview, and counts it as an error if err is not nil.
+func ObserveSubjectAccessReview(duration time.Duration, err error) {
+	subjectAccessReviewDuration.Observe(duration.Seconds())
+	if err != nil {
+		subjectAccessReviewErrorsTotal.Inc()
+	}
+}
+
+// ObserveSubjectAccessReviewCache counts a lookup of a cached
+// SubjectAccessReview result.
+func ObserveSubjectAccessReviewCache(hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	subjectAccessReviewCacheTotal.WithLabelValues(result).Inc()
+}
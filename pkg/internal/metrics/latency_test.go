@@ -0,0 +1,89 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Latency(t *testing.T) {
+	reviewDuration.Reset()
+	predicateDuration.Reset()
+	evaluatorDuration.Reset()
+
+	ObserveReview(CallerController, "approved", 3*time.Millisecond)
+	ObserveReview(CallerWebhook, "error", time.Second)
+	ObservePredicate(CallerController, "Ready", time.Millisecond)
+	ObservePredicate(CallerWebhook, "RBACBound", 10*time.Millisecond)
+	ObserveEvaluator(CallerController, "allowed", 2*time.Millisecond)
+
+	assert.Equal(t, 2, testutil.CollectAndCount(reviewDuration, "approverpolicy_review_duration_seconds"))
+	assert.Equal(t, 2, testutil.CollectAndCount(predicateDuration, "approverpolicy_predicate_duration_seconds"))
+	assert.Equal(t, 1, testutil.CollectAndCount(evaluatorDuration, "approverpolicy_evaluator_duration_seconds"))
+
+	errorsBefore := testutil.ToFloat64(subjectAccessReviewErrorsTotal)
+	ObserveSubjectAccessReview(5*time.Millisecond, nil)
+	ObserveSubjectAccessReview(5*time.Millisecond, errors.New("this is an error"))
+	assert.Equal(t, errorsBefore+1, testutil.ToFloat64(subjectAccessReviewErrorsTotal))
+
+	const expected = `
+		# HELP approverpolicy_review_duration_seconds Time taken to review a CertificateRequest, by caller and result.
+		# TYPE approverpolicy_review_duration_seconds histogram
+		approverpolicy_review_duration_seconds_bucket{caller="controller",result="approved",le="0.001"} 0
+		approverpolicy_review_duration_seconds_bucket{caller="controller",result="approved",le="0.002"} 0
+		approverpolicy_review_duration_seconds_bucket{caller="controller",result="approved",le="0.004"} 1
+		approverpolicy_review_duration_seconds_bucket{caller="controller",result="approved",le="0.008"} 1
+		approverpolicy_review_duration_seconds_bucket{caller="controller",result="approved",le="0.016"} 1
+		approverpolicy_review_duration_seconds_bucket{caller="controller",result="approved",le="0.032"} 1
+		approverpolicy_review_duration_seconds_bucket{caller="controller",result="approved",le="0.064"} 1
+		approverpolicy_review_duration_seconds_bucket{caller="controller",result="approved",le="0.128"} 1
+		approverpolicy_review_duration_seconds_bucket{caller="controller",result="approved",le="0.256"} 1
+		approverpolicy_review_duration_seconds_bucket{caller="controller",result="approved",le="0.512"} 1
+		approverpolicy_review_duration_seconds_bucket{caller="controller",result="approved",le="1.024"} 1
+		approverpolicy_review_duration_seconds_bucket{caller="controller",result="approved",le="2.048"} 1
+		approverpolicy_review_duration_seconds_bucket{caller="controller",result="approved",le="4.096"} 1
+		approverpolicy_review_duration_seconds_bucket{caller="controller",result="approved",le="8.192"} 1
+		approverpolicy_review_duration_seconds_bucket{caller="controller",result="approved",le="+Inf"} 1
+		approverpolicy_review_duration_seconds_sum{caller="controller",result="approved"} 0.003
+		approverpolicy_review_duration_seconds_count{caller="controller",result="approved"} 1
+		approverpolicy_review_duration_seconds_bucket{caller="webhook",result="error",le="0.001"} 0
+		approverpolicy_review_duration_seconds_bucket{caller="webhook",result="error",le="0.002"} 0
+		approverpolicy_review_duration_seconds_bucket{caller="webhook",result="error",le="0.004"} 0
+		approverpolicy_review_duration_seconds_bucket{caller="webhook",result="error",le="0.008"} 0
+		approverpolicy_review_duration_seconds_bucket{caller="webhook",result="error",le="0.016"} 0
+		approverpolicy_review_duration_seconds_bucket{caller="webhook",result="error",le="0.032"} 0
+		approverpolicy_review_duration_seconds_bucket{caller="webhook",result="error",le="0.064"} 0
+		approverpolicy_review_duration_seconds_bucket{caller="webhook",result="error",le="0.128"} 0
+		approverpolicy_review_duration_seconds_bucket{caller="webhook",result="error",le="0.256"} 0
+		approverpolicy_review_duration_seconds_bucket{caller="webhook",result="error",le="0.512"} 0
+		approverpolicy_review_duration_seconds_bucket{caller="webhook",result="error",le="1.024"} 1
+		approverpolicy_review_duration_seconds_bucket{caller="webhook",result="error",le="2.048"} 1
+		approverpolicy_review_duration_seconds_bucket{caller="webhook",result="error",le="4.096"} 1
+		approverpolicy_review_duration_seconds_bucket{caller="webhook",result="error",le="8.192"} 1
+		approverpolicy_review_duration_seconds_bucket{caller="webhook",result="error",le="+Inf"} 1
+		approverpolicy_review_duration_seconds_sum{caller="webhook",result="error"} 1
+		approverpolicy_review_duration_seconds_count{caller="webhook",result="error"} 1
+	`
+	require.NoError(t, testutil.CollectAndCompare(reviewDuration, strings.NewReader(expected), "approverpolicy_review_duration_seconds"))
+}
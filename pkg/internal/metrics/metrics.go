@@ -24,6 +24,7 @@ import (
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	"github.com/go-logr/logr"
 	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/utils/clock"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 )
@@ -79,21 +80,75 @@ var (
 		},
 		nil,
 	)
+
+	// approvedNotIssuedCount counts the current number of certificate requests
+	// that have been approved, but have not yet been issued nor failed. A large
+	// or growing number may mean the issuer is not processing approved
+	// requests.
+	approvedNotIssuedCount = prometheus.NewDesc(
+		"approverpolicy_certificaterequest_approved_not_issued_count",
+		"Number of CertificateRequests that have been approved (Approved=True), but have not been issued or failed yet.",
+		[]string{
+			"namespace",
+		},
+		nil,
+	)
+
+	// pendingApprovalCount counts the current number of certificate requests
+	// that are unmatched and were created longer ago than the pending approval
+	// threshold. Unlike unmatchedCount, it does not count requests that are
+	// simply waiting to be reviewed.
+	pendingApprovalCount = prometheus.NewDesc(
+		"approverpolicy_certificaterequest_pending_approval_count",
+		"Number of CertificateRequests that don't have an Approved or Denied condition set, and were created longer ago than the pending approval threshold.",
+		[]string{
+			"namespace",
+		},
+		nil,
+	)
 )
 
+// RegisterMetrics registers the approver-policy metrics with the
+// controller-runtime metrics registry. CertificateRequests without an Approved
+// or Denied condition which were created longer than pendingApprovalAfter ago
+// are reported as pending approval.
 // You don't need to wait for the cache to be synced before calling this. This
 // function is non-blocking.
-func RegisterMetrics(ctx context.Context, log logr.Logger, c cache.Cache) {
-	metrics.Registry.MustRegister(collector{ctx, log, c})
+func RegisterMetrics(ctx context.Context, log logr.Logger, c cache.Cache, pendingApprovalAfter time.Duration) {
+	metrics.Registry.MustRegister(
+		collector{ctx: ctx, log: log, cache: c, clock: clock.RealClock{}, pendingApprovalAfter: pendingApprovalAfter},
+		complianceApprovedCount,
+		complianceDriftedCount,
+		decisionsTotal,
+		denialsTotal,
+		predicateEliminationsTotal,
+		reviewDuration,
+		predicateDuration,
+		evaluatorDuration,
+		subjectAccessReviewDuration,
+		subjectAccessReviewErrorsTotal,
+		subjectAccessReviewCacheTotal,
+		auditEntriesDroppedTotal,
+	)
 }
 
 // We use a custom collector instead of prometheus.NewGaugeVec because it is
 // much easier to list all of the certificate requests when the `/metrics`
-// endpoint is hit rather than using a controller-runtime reconciler.
+// endpoint is hit rather than using a controller-runtime reconciler. The
+// certificate requests are listed once per scrape, and all gauges are computed
+// in that single pass.
 type collector struct {
 	ctx   context.Context
 	log   logr.Logger
 	cache cache.Cache
+
+	// clock returns the time used to determine whether a certificate request
+	// has been pending approval for longer than pendingApprovalAfter.
+	clock clock.PassiveClock
+
+	// pendingApprovalAfter is the age after which a certificate request without
+	// an Approved or Denied condition is counted as pending approval.
+	pendingApprovalAfter time.Duration
 }
 
 func (cc collector) Describe(ch chan<- *prometheus.Desc) {
@@ -109,9 +164,46 @@ func (cc collector) Collect(ch chan<- prometheus.Metric) {
 		return
 	}
 
-	collectCRsApproved(cc.ctx, cc.log, cc.cache, ch)
-	collectCRsDenied(cc.ctx, cc.log, cc.cache, ch)
-	collectCRsUnmatched(cc.log, cc.cache, ch)
+	list := &cmapi.CertificateRequestList{}
+	if err := cc.cache.List(cc.ctx, list); err != nil {
+		cc.log.Error(err, "unable to list CertificateRequests")
+		return
+	}
+
+	var approved, denied, unmatched, approvedNotIssued, pendingApproval namespaceCounts
+	for _, cr := range list.Items {
+		approvedStatus := getStatus(cmapi.CertificateRequestConditionApproved, cr.Status.Conditions)
+		deniedStatus := getStatus(cmapi.CertificateRequestConditionDenied, cr.Status.Conditions)
+
+		switch {
+		// A certificate request is said to be approved if it has the condition
+		// Approved=True.
+		case approvedStatus == cmmeta.ConditionTrue:
+			approved.inc(cr.Namespace)
+			if len(cr.Status.Certificate) == 0 && cr.Status.FailureTime == nil {
+				approvedNotIssued.inc(cr.Namespace)
+			}
+
+		// A certificate request is said to be denied if it has the condition
+		// Denied=True.
+		case deniedStatus == cmmeta.ConditionTrue:
+			denied.inc(cr.Namespace)
+
+		// A certificate request is said to be unmatched if it doesn't have the
+		// Approved and Denied conditions.
+		default:
+			unmatched.inc(cr.Namespace)
+			if cc.clock.Since(cr.CreationTimestamp.Time) > cc.pendingApprovalAfter {
+				pendingApproval.inc(cr.Namespace)
+			}
+		}
+	}
+
+	approved.collect(ch, approvedCount)
+	denied.collect(ch, deniedCount)
+	unmatched.collect(ch, unmatchedCount)
+	approvedNotIssued.collect(ch, approvedNotIssuedCount)
+	pendingApproval.collect(ch, pendingApprovalCount)
 }
 
 // hasSynced returns true if the cache has synced. Otherwise, it returns false.
@@ -127,124 +219,31 @@ func hasSynced(cache cache.Cache) bool {
 	return cache.WaitForCacheSync(tempCtx)
 }
 
-func collectCRsApproved(ctx context.Context, log logr.Logger, c cache.Cache, ch chan<- prometheus.Metric) {
-	list := &cmapi.CertificateRequestList{}
-	err := c.List(ctx, list)
-	if err != nil {
-		log.Error(err, "unable to list CertificateRequests")
-		return
-	}
-
-	type label struct{ namespace string }
-
-	// Let's remember the order of the labels so that we can send the metrics
-	// deterministically. Undeterministic outputs are a pain to test and debug.
-	var labels []label
-	count := make(map[label]int)
-
-	for _, cr := range list.Items {
-		// A certificate request is said to be approved if it has the condition
-		// Approved=True.
-		approvedStatus := getStatus(cmapi.CertificateRequestConditionApproved, cr.Status.Conditions)
-		if approvedStatus != "True" {
-			continue
-		}
-
-		k := label{namespace: cr.Namespace}
-		_, exists := count[k]
-		if !exists {
-			labels = append(labels, k)
-		}
-		count[k] += 1
-	}
-
-	for _, key := range labels {
-		ch <- prometheus.MustNewConstMetric(
-			approvedCount,
-			prometheus.GaugeValue,
-			float64(count[key]),
-			key.namespace,
-		)
-	}
+// namespaceCounts counts certificate requests by namespace. Let's remember the
+// order of the namespaces so that we can send the metrics deterministically.
+// Undeterministic outputs are a pain to test and debug.
+type namespaceCounts struct {
+	namespaces []string
+	count      map[string]int
 }
 
-func collectCRsDenied(ctx context.Context, log logr.Logger, c cache.Cache, ch chan<- prometheus.Metric) {
-	list := &cmapi.CertificateRequestList{}
-	err := c.List(ctx, list)
-	if err != nil {
-		log.Error(err, "unable to list CertificateRequests")
-		return
+func (n *namespaceCounts) inc(namespace string) {
+	if n.count == nil {
+		n.count = make(map[string]int)
 	}
-
-	type label struct{ namespace string }
-
-	var labels []label
-	count := make(map[label]int)
-
-	for _, cr := range list.Items {
-		deniedStatus := getStatus(cmapi.CertificateRequestConditionDenied, cr.Status.Conditions)
-		// A certificate request is said to be denied if it has the condition
-		// Denied=True.
-		if deniedStatus != "True" {
-			continue
-		}
-
-		k := label{namespace: cr.Namespace}
-		_, exists := count[k]
-		if !exists {
-			labels = append(labels, k)
-		}
-		count[k] += 1
-	}
-
-	for _, key := range labels {
-		ch <- prometheus.MustNewConstMetric(
-			deniedCount,
-			prometheus.GaugeValue,
-			float64(count[key]),
-			key.namespace,
-		)
+	if _, exists := n.count[namespace]; !exists {
+		n.namespaces = append(n.namespaces, namespace)
 	}
+	n.count[namespace]++
 }
 
-func collectCRsUnmatched(logger logr.Logger, c cache.Cache, ch chan<- prometheus.Metric) {
-	list := &cmapi.CertificateRequestList{}
-	err := c.List(context.Background(), list)
-	if err != nil {
-		logger.Error(err, "unable to list CertificateRequests")
-		return
-	}
-
-	type label struct {
-		namespace string
-	}
-
-	var labels []label
-	count := make(map[label]int)
-
-	for _, cr := range list.Items {
-		// A certificate request is said to be unmatched if it doesn't have the
-		// Approved and Denied conditions.
-		approvedStatus := getStatus(cmapi.CertificateRequestConditionApproved, cr.Status.Conditions)
-		deniedStatus := getStatus(cmapi.CertificateRequestConditionDenied, cr.Status.Conditions)
-		if approvedStatus == "True" || deniedStatus == "True" {
-			continue
-		}
-
-		k := label{namespace: cr.Namespace}
-		_, exists := count[k]
-		if !exists {
-			labels = append(labels, k)
-		}
-		count[k] += 1
-	}
-
-	for _, key := range labels {
+func (n *namespaceCounts) collect(ch chan<- prometheus.Metric, desc *prometheus.Desc) {
+	for _, namespace := range n.namespaces {
 		ch <- prometheus.MustNewConstMetric(
-			unmatchedCount,
+			desc,
 			prometheus.GaugeValue,
-			float64(count[key]),
-			key.namespace,
+			float64(n.count[namespace]),
+			namespace,
 		)
 	}
 }
@@ -21,13 +21,16 @@ import (
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakeclock "k8s.io/utils/clock/testing"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -168,13 +171,102 @@ func Test_Metrics(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("approved_not_issued_count counts approved CRs that are neither issued nor failed", func(t *testing.T) {
+		mock := mockCollector(t, []cmapi.CertificateRequest{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "waiting", Namespace: "bar"},
+				Status: cmapi.CertificateRequestStatus{Conditions: []cmapi.CertificateRequestCondition{
+					{Type: "Ready", Status: "False"},
+					{Type: "Approved", Status: "True"},
+				}},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "issued", Namespace: "bar"},
+				Status: cmapi.CertificateRequestStatus{
+					Conditions: []cmapi.CertificateRequestCondition{
+						{Type: "Ready", Status: "True"},
+						{Type: "Approved", Status: "True"},
+					},
+					Certificate: []byte("cert"),
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "failed", Namespace: "bar"},
+				Status: cmapi.CertificateRequestStatus{
+					Conditions: []cmapi.CertificateRequestCondition{
+						{Type: "Ready", Status: "False", Reason: "Failed"},
+						{Type: "Approved", Status: "True"},
+					},
+					FailureTime: &metav1.Time{Time: fixedTime},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "unmatched", Namespace: "bar"},
+				Status: cmapi.CertificateRequestStatus{Conditions: []cmapi.CertificateRequestCondition{
+					{Type: "Ready", Status: "False"},
+				}},
+			},
+		})
+		const expected = `
+			# HELP approverpolicy_certificaterequest_approved_not_issued_count Number of CertificateRequests that have been approved (Approved=True), but have not been issued or failed yet.
+			# TYPE approverpolicy_certificaterequest_approved_not_issued_count gauge
+			approverpolicy_certificaterequest_approved_not_issued_count{namespace="bar"} 1
+		`
+		err := testutil.CollectAndCompare(mock, strings.NewReader(expected), "approverpolicy_certificaterequest_approved_not_issued_count")
+		require.NoError(t, err)
+	})
+
+	t.Run("pending_approval_count only counts unmatched CRs older than the threshold", func(t *testing.T) {
+		mock := mockCollector(t, []cmapi.CertificateRequest{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "old", Namespace: "bar", CreationTimestamp: metav1.NewTime(fixedTime.Add(-time.Hour))},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "new", Namespace: "bar", CreationTimestamp: metav1.NewTime(fixedTime.Add(-time.Minute))},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "old-approved", Namespace: "bar", CreationTimestamp: metav1.NewTime(fixedTime.Add(-time.Hour))},
+				Status: cmapi.CertificateRequestStatus{Conditions: []cmapi.CertificateRequestCondition{
+					{Type: "Approved", Status: "True"},
+				}},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "old", Namespace: "other", CreationTimestamp: metav1.NewTime(fixedTime.Add(-10 * time.Minute))},
+			},
+		})
+		const expected = `
+			# HELP approverpolicy_certificaterequest_pending_approval_count Number of CertificateRequests that don't have an Approved or Denied condition set, and were created longer ago than the pending approval threshold.
+			# TYPE approverpolicy_certificaterequest_pending_approval_count gauge
+			approverpolicy_certificaterequest_pending_approval_count{namespace="bar"} 1
+			approverpolicy_certificaterequest_pending_approval_count{namespace="other"} 1
+		`
+		err := testutil.CollectAndCompare(mock, strings.NewReader(expected), "approverpolicy_certificaterequest_pending_approval_count")
+		require.NoError(t, err)
+	})
+
+	t.Run("CRs are listed once per scrape", func(t *testing.T) {
+		mock := mockCollector(t, nil)
+		ch := make(chan prometheus.Metric)
+		go func() {
+			mock.Collect(ch)
+			close(ch)
+		}()
+		for range ch {
+		}
+		require.Equal(t, 1, mock.cache.(*mockCache).lists)
+	})
 }
 
+// fixedTime is the time of the clock used by the mock collector.
+var fixedTime = time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+
 func mockCollector(t *testing.T, crs []cmapi.CertificateRequest) *collector {
 	return &collector{
-		cache: &mockCache{t: t, objects: crs},
-		ctx:   t.Context(),
-		log:   logr.Discard(),
+		cache:                &mockCache{t: t, objects: crs},
+		ctx:                  t.Context(),
+		log:                  logr.Discard(),
+		clock:                fakeclock.NewFakeClock(fixedTime),
+		pendingApprovalAfter: 5 * time.Minute,
 	}
 }
 
@@ -183,6 +275,9 @@ var errNotImplemented = errors.New("not implemented")
 type mockCache struct {
 	t       *testing.T
 	objects []cmapi.CertificateRequest
+
+	// lists is the number of times List has been called.
+	lists int
 }
 
 // The only two functions we care about are WaitForCacheSync and List.
@@ -193,6 +288,7 @@ func (mock *mockCache) List(ctx context.Context, given client.ObjectList, opts .
 	require.IsType(mock.t, &cmapi.CertificateRequestList{}, given)
 	crList := given.(*cmapi.CertificateRequestList)
 	crList.Items = mock.objects
+	mock.lists++
 	return nil
 }
 
@@ -0,0 +1,151 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package policyreport builds Kubernetes Policy Working Group PolicyReports
+// summarising the approvals and denials of the CertificateRequests in a
+// namespace.
+package policyreport
+
+import (
+	"sort"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/cert-manager/approver-policy/pkg/approver/manager"
+)
+
+const (
+	// Name is the name of the PolicyReport written to each namespace.
+	Name = "cert-manager-approver-policy"
+
+	// Source is the source of every result written by approver-policy.
+	Source = "approver-policy"
+
+	// Category is the category of every result written by approver-policy.
+	Category = "cert-manager"
+
+	// UnknownPolicy is the policy of denial results which were not made by a
+	// particular CertificateRequestPolicy, such as requests denied because no
+	// policy was applicable to them.
+	UnknownPolicy = "approver-policy"
+
+	// MaxResults is the maximum number of results in a PolicyReport. Only the
+	// most recent results are kept so that the report stays well within the
+	// size limit of objects.
+	MaxResults = 1000
+)
+
+// ResultsForReview returns the results of the decision made on the
+// CertificateRequest from its review. An approval is a single passing result
+// of the approving policy. A denial is a failing result for every denial of
+// the review, whose rule is the path of the denied field, or the name of the
+// evaluator if the denial has no field.
+func ResultsForReview(now time.Time, cr *cmapi.CertificateRequest, result manager.ReviewResult, message string, response manager.ReviewResponse) []PolicyReportResult {
+	if result == manager.ResultApproved {
+		return []PolicyReportResult{NewResult(now, cr, ResultPass, response.Policy, "", message)}
+	}
+
+	if len(response.Denials) == 0 {
+		return []PolicyReportResult{NewResult(now, cr, ResultFail, UnknownPolicy, "", message)}
+	}
+
+	results := make([]PolicyReportResult, 0, len(response.Denials))
+	for _, denial := range response.Denials {
+		rule := denial.Field
+		if len(rule) == 0 {
+			rule = denial.Evaluator
+		}
+		r := NewResult(now, cr, ResultFail, denial.Policy, rule, message)
+		if len(denial.Evaluator) > 0 {
+			r.Properties = map[string]string{"evaluator": denial.Evaluator}
+		}
+		results = append(results, r)
+	}
+	return results
+}
+
+// NewResult returns a result of the policy rule for the CertificateRequest.
+func NewResult(now time.Time, cr *cmapi.CertificateRequest, result Result, policy, rule, message string) PolicyReportResult {
+	return PolicyReportResult{
+		Source:    Source,
+		Policy:    policy,
+		Rule:      rule,
+		Category:  Category,
+		Timestamp: Timestamp{Seconds: now.Unix(), Nanos: int32(now.Nanosecond())}, // #nosec G115 -- Nanosecond is always within [0, 999999999].
+		Result:    result,
+		Resources: []corev1.ObjectReference{{
+			APIVersion: cmapi.SchemeGroupVersion.String(),
+			Kind:       cmapi.CertificateRequestKind,
+			Namespace:  cr.Namespace,
+			Name:       cr.Name,
+			UID:        cr.UID,
+		}},
+		Message: message,
+	}
+}
+
+// Build returns the PolicyReport of the namespace holding the given results.
+// Results are ordered newest first, and only the most recent MaxResults are
+// kept.
+func Build(namespace string, results []PolicyReportResult) *PolicyReport {
+	results = append([]PolicyReportResult(nil), results...)
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Timestamp != b.Timestamp {
+			if a.Timestamp.Seconds != b.Timestamp.Seconds {
+				return a.Timestamp.Seconds > b.Timestamp.Seconds
+			}
+			return a.Timestamp.Nanos > b.Timestamp.Nanos
+		}
+		if a.Resources[0].Name != b.Resources[0].Name {
+			return a.Resources[0].Name < b.Resources[0].Name
+		}
+		if a.Policy != b.Policy {
+			return a.Policy < b.Policy
+		}
+		return a.Rule < b.Rule
+	})
+	if len(results) > MaxResults {
+		results = results[:MaxResults]
+	}
+
+	report := &PolicyReport{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: GroupVersionKind.GroupVersion().String(),
+			Kind:       GroupVersionKind.Kind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      Name,
+			Namespace: namespace,
+			Labels:    map[string]string{"app.kubernetes.io/managed-by": Source},
+		},
+		Results: results,
+	}
+
+	for _, result := range results {
+		switch result.Result {
+		case ResultPass:
+			report.Summary.Pass++
+		case ResultFail:
+			report.Summary.Fail++
+		}
+	}
+
+	return report
+}
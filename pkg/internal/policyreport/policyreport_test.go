@@ -0,0 +1,137 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policyreport
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/cert-manager/approver-policy/pkg/approver/manager"
+)
+
+func Test_ResultsForReview(t *testing.T) {
+	now := time.Date(2026, time.January, 1, 0, 0, 0, 500, time.UTC)
+	cr := gen.CertificateRequest("test", gen.SetCertificateRequestNamespace("test-ns"))
+	cr.UID = types.UID("test-uid")
+
+	resource := []corev1.ObjectReference{{APIVersion: "cert-manager.io/v1", Kind: "CertificateRequest", Namespace: "test-ns", Name: "test", UID: "test-uid"}}
+	timestamp := Timestamp{Seconds: now.Unix(), Nanos: 500}
+
+	tests := map[string]struct {
+		result   manager.ReviewResult
+		response manager.ReviewResponse
+		exp      []PolicyReportResult
+	}{
+		"an approval should be a passing result of the approving policy": {
+			result:   manager.ResultApproved,
+			response: manager.ReviewResponse{Result: manager.ResultApproved, Policy: "test-policy"},
+			exp: []PolicyReportResult{
+				{Source: Source, Policy: "test-policy", Category: Category, Timestamp: timestamp, Result: ResultPass, Resources: resource, Message: "test-message"},
+			},
+		},
+		"a denial should be a failing result for every denied field, or the evaluator if there is no field": {
+			result: manager.ResultDenied,
+			response: manager.ReviewResponse{Result: manager.ResultDenied, Denials: []manager.Denial{
+				{Policy: "test-policy-a", Evaluator: "allowed", Field: "spec.allowed.dnsNames.values"},
+				{Policy: "test-policy-b", Evaluator: "plugin"},
+			}},
+			exp: []PolicyReportResult{
+				{Source: Source, Policy: "test-policy-a", Rule: "spec.allowed.dnsNames.values", Category: Category, Timestamp: timestamp, Result: ResultFail, Resources: resource, Message: "test-message", Properties: map[string]string{"evaluator": "allowed"}},
+				{Source: Source, Policy: "test-policy-b", Rule: "plugin", Category: Category, Timestamp: timestamp, Result: ResultFail, Resources: resource, Message: "test-message", Properties: map[string]string{"evaluator": "plugin"}},
+			},
+		},
+		"a denial with no denials should be a failing result of the unknown policy": {
+			result:   manager.ResultDenied,
+			response: manager.ReviewResponse{Result: manager.ResultUnprocessed},
+			exp: []PolicyReportResult{
+				{Source: Source, Policy: UnknownPolicy, Category: Category, Timestamp: timestamp, Result: ResultFail, Resources: resource, Message: "test-message"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.exp, ResultsForReview(now, cr, test.result, "test-message", test.response))
+		})
+	}
+}
+
+func Test_Build(t *testing.T) {
+	result := func(name string, seconds int64, result Result) PolicyReportResult {
+		return PolicyReportResult{
+			Policy:    "test-policy",
+			Timestamp: Timestamp{Seconds: seconds},
+			Result:    result,
+			Resources: []corev1.ObjectReference{{Name: name}},
+		}
+	}
+
+	t.Run("results should be sorted newest first and summarised", func(t *testing.T) {
+		report := Build("test-ns", []PolicyReportResult{
+			result("b", 1, ResultPass),
+			result("a", 1, ResultFail),
+			result("c", 2, ResultPass),
+		})
+
+		assert.Equal(t, GroupVersionKind, report.GroupVersionKind())
+		assert.Equal(t, "test-ns", report.Namespace)
+		assert.Equal(t, Name, report.Name)
+		assert.Equal(t, Summary{Pass: 2, Fail: 1}, report.Summary)
+		assert.Equal(t, []PolicyReportResult{
+			result("c", 2, ResultPass),
+			result("a", 1, ResultFail),
+			result("b", 1, ResultPass),
+		}, report.Results)
+	})
+
+	t.Run("only the most recent results should be kept", func(t *testing.T) {
+		var results []PolicyReportResult
+		for i := range MaxResults + 10 {
+			results = append(results, result("test", int64(i), ResultPass))
+		}
+
+		report := Build("test-ns", results)
+		assert.Len(t, report.Results, MaxResults)
+		assert.Equal(t, int64(MaxResults+9), report.Results[0].Timestamp.Seconds)
+		assert.Equal(t, MaxResults, report.Summary.Pass)
+	})
+}
+
+func Test_Store(t *testing.T) {
+	store := NewStore()
+	store.Set("test-ns-a", "uid-a", []PolicyReportResult{{Policy: "test-policy-a"}})
+	store.Set("test-ns-a", "uid-b", []PolicyReportResult{{Policy: "test-policy-b"}})
+	store.Set("test-ns-b", "uid-c", []PolicyReportResult{{Policy: "test-policy-c"}})
+
+	store.Prune("test-ns-a", sets.New[types.UID]("uid-a"))
+
+	results, ok := store.Get("uid-a")
+	assert.True(t, ok)
+	assert.Equal(t, []PolicyReportResult{{Policy: "test-policy-a"}}, results)
+
+	_, ok = store.Get("uid-b")
+	assert.False(t, ok, "expected results of requests which no longer exist to be pruned")
+
+	_, ok = store.Get("uid-c")
+	assert.True(t, ok, "expected results in other namespaces to be kept")
+}
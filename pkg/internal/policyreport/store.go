@@ -0,0 +1,71 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policyreport
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// Store holds the results of the decisions made on CertificateRequests since
+// approver-policy started, by CertificateRequest UID. The denied fields of a
+// decision are only known when the review is made, so are kept here until the
+// CertificateRequest is deleted.
+type Store struct {
+	lock    sync.RWMutex
+	results map[types.UID]storeEntry
+}
+
+type storeEntry struct {
+	namespace string
+	results   []PolicyReportResult
+}
+
+// NewStore returns an empty Store.
+func NewStore() *Store {
+	return &Store{results: make(map[types.UID]storeEntry)}
+}
+
+// Set replaces the results of the CertificateRequest.
+func (s *Store) Set(namespace string, uid types.UID, results []PolicyReportResult) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.results[uid] = storeEntry{namespace: namespace, results: results}
+}
+
+// Get returns the results of the CertificateRequest. Returns false if no
+// results have been stored.
+func (s *Store) Get(uid types.UID) ([]PolicyReportResult, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	entry, ok := s.results[uid]
+	return entry.results, ok
+}
+
+// Prune removes the results of the CertificateRequests in the namespace whose
+// UIDs are not in keep.
+func (s *Store) Prune(namespace string, keep sets.Set[types.UID]) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for uid, entry := range s.results {
+		if entry.namespace == namespace && !keep.Has(uid) {
+			delete(s.results, uid)
+		}
+	}
+}
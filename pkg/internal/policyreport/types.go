@@ -0,0 +1,101 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policyreport
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// The types below are the subset of the Kubernetes Policy Working Group
+// wgpolicyk8s.io/v1alpha2 PolicyReport API written by approver-policy. They
+// are only ever encoded to JSON and applied, so are not registered with a
+// scheme.
+
+// GroupVersionKind is the GroupVersionKind of PolicyReports.
+var GroupVersionKind = schema.GroupVersionKind{Group: "wgpolicyk8s.io", Version: "v1alpha2", Kind: "PolicyReport"}
+
+// Result is the result of a policy applied to a resource.
+type Result string
+
+const (
+	// ResultPass is the result of a CertificateRequest which was approved.
+	ResultPass Result = "pass"
+
+	// ResultFail is the result of a CertificateRequest which was denied.
+	ResultFail Result = "fail"
+)
+
+// PolicyReport summarises the results of policies applied to the resources
+// in a namespace.
+type PolicyReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Summary is the number of results of each kind.
+	Summary Summary `json:"summary"`
+
+	// Results are the results of policies applied to resources.
+	Results []PolicyReportResult `json:"results,omitempty"`
+}
+
+// Summary is the number of results of each kind in a PolicyReport.
+type Summary struct {
+	Pass  int `json:"pass"`
+	Fail  int `json:"fail"`
+	Warn  int `json:"warn"`
+	Error int `json:"error"`
+	Skip  int `json:"skip"`
+}
+
+// PolicyReportResult is the result of a policy rule applied to resources.
+type PolicyReportResult struct {
+	// Source is the name of the policy engine which produced the result.
+	Source string `json:"source"`
+
+	// Policy is the name of the policy.
+	Policy string `json:"policy"`
+
+	// Rule is the name of the rule of the policy which produced the result.
+	Rule string `json:"rule,omitempty"`
+
+	// Category is the category of the policy.
+	Category string `json:"category,omitempty"`
+
+	// Timestamp is when the result was produced.
+	Timestamp Timestamp `json:"timestamp"`
+
+	// Result is the result of the rule.
+	Result Result `json:"result"`
+
+	// Resources are the resources the rule was applied to.
+	Resources []corev1.ObjectReference `json:"resources,omitempty"`
+
+	// Message describes the result.
+	Message string `json:"message,omitempty"`
+
+	// Properties are additional information about the result.
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+// Timestamp is a time in the protobuf Timestamp JSON representation used by
+// PolicyReports.
+type Timestamp struct {
+	Seconds int64 `json:"seconds"`
+	Nanos   int32 `json:"nanos"`
+}
@@ -0,0 +1,357 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package rbac resolves which subjects are granted a permission by the RBAC
+// Roles, ClusterRoles, RoleBindings and ClusterRoleBindings of a cluster,
+// without making requests to the API server.
+package rbac
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apiserver/pkg/authentication/serviceaccount"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Attributes describe the permission of a request to the API server.
+type Attributes struct {
+	Verb     string
+	APIGroup string
+	Resource string
+	Name     string
+}
+
+// UsePolicy returns the attributes of the permission to use the named
+// CertificateRequestPolicy, which requesters must be granted for the policy
+// to be applicable to their requests.
+func UsePolicy(name string) Attributes {
+	return Attributes{
+		Verb:     "use",
+		APIGroup: "policy.cert-manager.io",
+		Resource: "certificaterequestpolicies",
+		Name:     name,
+	}
+}
+
+// Grant is a subject which is granted a permission by a binding.
+type Grant struct {
+	// Subject is the user, group or service account granted the permission.
+	Subject rbacv1.Subject
+
+	// Namespace is the namespace the permission is granted in. Empty if the
+	// permission is granted in all namespaces by a ClusterRoleBinding.
+	Namespace string
+
+	// Binding is the kind and name of the binding which grants the
+	// permission.
+	BindingKind string
+	BindingName string
+
+	// Role is the kind and name of the role bound by the binding.
+	RoleKind string
+	RoleName string
+}
+
+// BoundSubject is a subject and the namespaces it is granted a permission in.
+type BoundSubject struct {
+	// Subject is the user, group or service account granted the permission.
+	Subject rbacv1.Subject
+
+	// Namespaces are the namespaces the permission is granted in, in order.
+	// Nil if the permission is granted in all namespaces.
+	Namespaces []string
+}
+
+// BoundSubjects groups the grants by subject, ordered by subject kind,
+// namespace and name.
+func BoundSubjects(grants []Grant) []BoundSubject {
+	type key struct{ kind, namespace, name string }
+
+	var (
+		keys        []key
+		namespaces  = make(map[key]sets.Set[string])
+		clusterWide = sets.New[key]()
+	)
+	for _, grant := range grants {
+		k := key{grant.Subject.Kind, grant.Subject.Namespace, grant.Subject.Name}
+		if _, ok := namespaces[k]; !ok {
+			keys = append(keys, k)
+			namespaces[k] = sets.New[string]()
+		}
+		if len(grant.Namespace) == 0 {
+			clusterWide.Insert(k)
+		} else {
+			namespaces[k].Insert(grant.Namespace)
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		switch {
+		case a.kind != b.kind:
+			return a.kind < b.kind
+		case a.namespace != b.namespace:
+			return a.namespace < b.namespace
+		default:
+			return a.name < b.name
+		}
+	})
+
+	subjects := make([]BoundSubject, 0, len(keys))
+	for _, k := range keys {
+		subject := BoundSubject{Subject: rbacv1.Subject{Kind: k.kind, Namespace: k.namespace, Name: k.name}}
+		if !clusterWide.Has(k) {
+			subject.Namespaces = sets.List(namespaces[k])
+		}
+		subjects = append(subjects, subject)
+	}
+	return subjects
+}
+
+// GrantsIn returns the grants which apply in the namespace, i.e. those granted
+// in the namespace or in all namespaces.
+func GrantsIn(grants []Grant, namespace string) []Grant {
+	var in []Grant
+	for _, grant := range grants {
+		if len(grant.Namespace) == 0 || grant.Namespace == namespace {
+			in = append(in, grant)
+		}
+	}
+	return in
+}
+
+// Snapshot is a point in time view of the RBAC objects of a cluster.
+type Snapshot struct {
+	clusterRoles        map[string]*rbacv1.ClusterRole
+	roles               map[string]map[string]*rbacv1.Role
+	clusterRoleBindings []rbacv1.ClusterRoleBinding
+	roleBindings        []rbacv1.RoleBinding
+}
+
+// NewSnapshot returns a Snapshot of the given RBAC objects.
+func NewSnapshot(clusterRoles []rbacv1.ClusterRole, roles []rbacv1.Role, clusterRoleBindings []rbacv1.ClusterRoleBinding, roleBindings []rbacv1.RoleBinding) *Snapshot {
+	s := &Snapshot{
+		clusterRoles:        make(map[string]*rbacv1.ClusterRole, len(clusterRoles)),
+		roles:               make(map[string]map[string]*rbacv1.Role),
+		clusterRoleBindings: clusterRoleBindings,
+		roleBindings:        roleBindings,
+	}
+	for i := range clusterRoles {
+		s.clusterRoles[clusterRoles[i].Name] = &clusterRoles[i]
+	}
+	for i := range roles {
+		role := &roles[i]
+		if s.roles[role.Namespace] == nil {
+			s.roles[role.Namespace] = make(map[string]*rbacv1.Role)
+		}
+		s.roles[role.Namespace][role.Name] = role
+	}
+	return s
+}
+
+// List returns a Snapshot of the RBAC objects read from the reader.
+func List(ctx context.Context, reader client.Reader) (*Snapshot, error) {
+	var clusterRoles rbacv1.ClusterRoleList
+	if err := reader.List(ctx, &clusterRoles); err != nil {
+		return nil, fmt.Errorf("failed to list ClusterRoles: %w", err)
+	}
+	var roles rbacv1.RoleList
+	if err := reader.List(ctx, &roles); err != nil {
+		return nil, fmt.Errorf("failed to list Roles: %w", err)
+	}
+	var clusterRoleBindings rbacv1.ClusterRoleBindingList
+	if err := reader.List(ctx, &clusterRoleBindings); err != nil {
+		return nil, fmt.Errorf("failed to list ClusterRoleBindings: %w", err)
+	}
+	var roleBindings rbacv1.RoleBindingList
+	if err := reader.List(ctx, &roleBindings); err != nil {
+		return nil, fmt.Errorf("failed to list RoleBindings: %w", err)
+	}
+
+	return NewSnapshot(clusterRoles.Items, roles.Items, clusterRoleBindings.Items, roleBindings.Items), nil
+}
+
+// WhoCan returns every subject granted the permission, and the binding which
+// grants it. Grants are ordered by namespace, binding and subject.
+func (s *Snapshot) WhoCan(attrs Attributes) []Grant {
+	var grants []Grant
+
+	for _, binding := range s.clusterRoleBindings {
+		if binding.RoleRef.Kind != "ClusterRole" || !s.clusterRoleAllows(binding.RoleRef.Name, attrs) {
+			continue
+		}
+		for _, subject := range binding.Subjects {
+			grants = append(grants, Grant{
+				Subject:     subject,
+				BindingKind: "ClusterRoleBinding",
+				BindingName: binding.Name,
+				RoleKind:    binding.RoleRef.Kind,
+				RoleName:    binding.RoleRef.Name,
+			})
+		}
+	}
+
+	for _, binding := range s.roleBindings {
+		if !s.roleRefAllows(binding.Namespace, binding.RoleRef, attrs) {
+			continue
+		}
+		for _, subject := range binding.Subjects {
+			grants = append(grants, Grant{
+				Subject:     subject,
+				Namespace:   binding.Namespace,
+				BindingKind: "RoleBinding",
+				BindingName: binding.Name,
+				RoleKind:    binding.RoleRef.Kind,
+				RoleName:    binding.RoleRef.Name,
+			})
+		}
+	}
+
+	sort.SliceStable(grants, func(i, j int) bool {
+		a, b := grants[i], grants[j]
+		switch {
+		case a.Namespace != b.Namespace:
+			return a.Namespace < b.Namespace
+		case a.BindingKind != b.BindingKind:
+			return a.BindingKind < b.BindingKind
+		case a.BindingName != b.BindingName:
+			return a.BindingName < b.BindingName
+		case a.Subject.Kind != b.Subject.Kind:
+			return a.Subject.Kind < b.Subject.Kind
+		case a.Subject.Namespace != b.Subject.Namespace:
+			return a.Subject.Namespace < b.Subject.Namespace
+		default:
+			return a.Subject.Name < b.Subject.Name
+		}
+	})
+
+	return grants
+}
+
+// Allows returns true if the user, or any of the groups, is granted the
+// permission in the namespace.
+func (s *Snapshot) Allows(user string, groups []string, namespace string, attrs Attributes) bool {
+	for _, binding := range s.clusterRoleBindings {
+		if binding.RoleRef.Kind == "ClusterRole" && subjectsMatch(binding.Subjects, "", user, groups) && s.clusterRoleAllows(binding.RoleRef.Name, attrs) {
+			return true
+		}
+	}
+
+	for _, binding := range s.roleBindings {
+		if binding.Namespace == namespace && subjectsMatch(binding.Subjects, binding.Namespace, user, groups) && s.roleRefAllows(binding.Namespace, binding.RoleRef, attrs) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// subjectsMatch returns true if any of the subjects is the user, or one of the
+// groups. Service accounts without a namespace are in the namespace of the
+// binding.
+func subjectsMatch(subjects []rbacv1.Subject, bindingNamespace, user string, groups []string) bool {
+	for _, subject := range subjects {
+		switch subject.Kind {
+		case rbacv1.UserKind:
+			if subject.Name == user {
+				return true
+			}
+		case rbacv1.GroupKind:
+			if slices.Contains(groups, subject.Name) {
+				return true
+			}
+		case rbacv1.ServiceAccountKind:
+			namespace := subject.Namespace
+			if len(namespace) == 0 {
+				namespace = bindingNamespace
+			}
+			if serviceaccount.MakeUsername(namespace, subject.Name) == user {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// roleRefAllows returns true if the role referenced by a RoleBinding in the
+// namespace allows the permission.
+func (s *Snapshot) roleRefAllows(namespace string, ref rbacv1.RoleRef, attrs Attributes) bool {
+	switch ref.Kind {
+	case "ClusterRole":
+		return s.clusterRoleAllows(ref.Name, attrs)
+	case "Role":
+		role, ok := s.roles[namespace][ref.Name]
+		return ok && rulesAllow(role.Rules, attrs)
+	default:
+		return false
+	}
+}
+
+// clusterRoleAllows returns true if the named ClusterRole allows the
+// permission, including the rules of the ClusterRoles it aggregates.
+func (s *Snapshot) clusterRoleAllows(name string, attrs Attributes) bool {
+	role, ok := s.clusterRoles[name]
+	if !ok {
+		return false
+	}
+	if rulesAllow(role.Rules, attrs) {
+		return true
+	}
+
+	// The rules of aggregated ClusterRoles are normally copied into the
+	// aggregating ClusterRole by the API server, but may not have been yet.
+	if role.AggregationRule == nil {
+		return false
+	}
+	for _, selector := range role.AggregationRule.ClusterRoleSelectors {
+		sel, err := metav1.LabelSelectorAsSelector(&selector)
+		if err != nil {
+			continue
+		}
+		for _, aggregated := range s.clusterRoles {
+			if aggregated.Name != name && sel.Matches(labels.Set(aggregated.Labels)) && rulesAllow(aggregated.Rules, attrs) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// rulesAllow returns true if any of the rules allow the permission.
+func rulesAllow(rules []rbacv1.PolicyRule, attrs Attributes) bool {
+	for _, rule := range rules {
+		if matches(rule.Verbs, attrs.Verb) &&
+			matches(rule.APIGroups, attrs.APIGroup) &&
+			matches(rule.Resources, attrs.Resource) &&
+			(len(rule.ResourceNames) == 0 || slices.Contains(rule.ResourceNames, attrs.Name)) {
+			return true
+		}
+	}
+	return false
+}
+
+// matches returns true if values contains the value or the wildcard "*".
+func matches(values []string, value string) bool {
+	return slices.Contains(values, rbacv1.VerbAll) || slices.Contains(values, value)
+}
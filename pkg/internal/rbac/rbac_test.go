@@ -0,0 +1,151 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_WhoCan(t *testing.T) {
+	useRule := func(names ...string) rbacv1.PolicyRule {
+		return rbacv1.PolicyRule{
+			APIGroups:     []string{"policy.cert-manager.io"},
+			Resources:     []string{"certificaterequestpolicies"},
+			Verbs:         []string{"use"},
+			ResourceNames: names,
+		}
+	}
+	user := func(name string) rbacv1.Subject {
+		return rbacv1.Subject{Kind: rbacv1.UserKind, APIGroup: rbacv1.GroupName, Name: name}
+	}
+
+	snapshot := NewSnapshot(
+		[]rbacv1.ClusterRole{
+			{ObjectMeta: metav1.ObjectMeta{Name: "use-test-policy"}, Rules: []rbacv1.PolicyRule{useRule("test-policy")}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "use-other-policy"}, Rules: []rbacv1.PolicyRule{useRule("other-policy")}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "wildcard"}, Rules: []rbacv1.PolicyRule{{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}}}},
+			{
+				ObjectMeta:      metav1.ObjectMeta{Name: "aggregate"},
+				AggregationRule: &rbacv1.AggregationRule{ClusterRoleSelectors: []metav1.LabelSelector{{MatchLabels: map[string]string{"aggregate-to-policy": "true"}}}},
+			},
+			{ObjectMeta: metav1.ObjectMeta{Name: "aggregated", Labels: map[string]string{"aggregate-to-policy": "true"}}, Rules: []rbacv1.PolicyRule{useRule()}},
+		},
+		[]rbacv1.Role{
+			{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-a", Name: "use-all-policies"}, Rules: []rbacv1.PolicyRule{useRule()}},
+		},
+		[]rbacv1.ClusterRoleBinding{
+			{ObjectMeta: metav1.ObjectMeta{Name: "admin"}, RoleRef: rbacv1.RoleRef{Kind: "ClusterRole", Name: "wildcard"}, Subjects: []rbacv1.Subject{user("admin")}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "other"}, RoleRef: rbacv1.RoleRef{Kind: "ClusterRole", Name: "use-other-policy"}, Subjects: []rbacv1.Subject{user("other")}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "aggregate"}, RoleRef: rbacv1.RoleRef{Kind: "ClusterRole", Name: "aggregate"}, Subjects: []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "group-a"}}},
+		},
+		[]rbacv1.RoleBinding{
+			{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-b", Name: "cluster-role"}, RoleRef: rbacv1.RoleRef{Kind: "ClusterRole", Name: "use-test-policy"}, Subjects: []rbacv1.Subject{
+				{Kind: rbacv1.ServiceAccountKind, Namespace: "ns-b", Name: "sa"},
+			}},
+			{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-a", Name: "role"}, RoleRef: rbacv1.RoleRef{Kind: "Role", Name: "use-all-policies"}, Subjects: []rbacv1.Subject{user("user-a")}},
+			{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-c", Name: "missing-role"}, RoleRef: rbacv1.RoleRef{Kind: "Role", Name: "use-all-policies"}, Subjects: []rbacv1.Subject{user("user-c")}},
+		},
+	)
+
+	assert.Equal(t, []Grant{
+		{Subject: user("admin"), BindingKind: "ClusterRoleBinding", BindingName: "admin", RoleKind: "ClusterRole", RoleName: "wildcard"},
+		{Subject: rbacv1.Subject{Kind: rbacv1.GroupKind, Name: "group-a"}, BindingKind: "ClusterRoleBinding", BindingName: "aggregate", RoleKind: "ClusterRole", RoleName: "aggregate"},
+		{Subject: user("user-a"), Namespace: "ns-a", BindingKind: "RoleBinding", BindingName: "role", RoleKind: "Role", RoleName: "use-all-policies"},
+		{Subject: rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Namespace: "ns-b", Name: "sa"}, Namespace: "ns-b", BindingKind: "RoleBinding", BindingName: "cluster-role", RoleKind: "ClusterRole", RoleName: "use-test-policy"},
+	}, snapshot.WhoCan(UsePolicy("test-policy")))
+
+	assert.Equal(t, []Grant{
+		{Subject: user("admin"), BindingKind: "ClusterRoleBinding", BindingName: "admin", RoleKind: "ClusterRole", RoleName: "wildcard"},
+	}, snapshot.WhoCan(Attributes{Verb: "create", APIGroup: "policy.cert-manager.io", Resource: "certificaterequestapprovals"}))
+}
+
+func Test_BoundSubjects(t *testing.T) {
+	userA := rbacv1.Subject{Kind: rbacv1.UserKind, Name: "user-a"}
+	groupA := rbacv1.Subject{Kind: rbacv1.GroupKind, Name: "group-a"}
+	sa := rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Namespace: "ns-a", Name: "sa"}
+
+	grants := []Grant{
+		{Subject: userA, Namespace: "ns-b"},
+		{Subject: sa, Namespace: "ns-a"},
+		{Subject: userA, Namespace: "ns-a"},
+		{Subject: groupA, Namespace: "ns-a"},
+		{Subject: groupA},
+	}
+
+	assert.Equal(t, []BoundSubject{
+		{Subject: groupA},
+		{Subject: sa, Namespaces: []string{"ns-a"}},
+		{Subject: userA, Namespaces: []string{"ns-a", "ns-b"}},
+	}, BoundSubjects(grants))
+
+	assert.Equal(t, []Grant{
+		{Subject: userA, Namespace: "ns-b"},
+		{Subject: groupA},
+	}, GrantsIn(grants, "ns-b"))
+}
+
+func Test_Allows(t *testing.T) {
+	useRole := rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{Name: "use-test-policy"},
+		Rules: []rbacv1.PolicyRule{{
+			APIGroups:     []string{"policy.cert-manager.io"},
+			Resources:     []string{"certificaterequestpolicies"},
+			Verbs:         []string{"use"},
+			ResourceNames: []string{"test-policy"},
+		}},
+	}
+	ref := rbacv1.RoleRef{Kind: "ClusterRole", Name: "use-test-policy"}
+
+	snapshot := NewSnapshot([]rbacv1.ClusterRole{useRole}, nil,
+		[]rbacv1.ClusterRoleBinding{
+			{ObjectMeta: metav1.ObjectMeta{Name: "group"}, RoleRef: ref, Subjects: []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "group-a"}}},
+		},
+		[]rbacv1.RoleBinding{
+			{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-a", Name: "user"}, RoleRef: ref, Subjects: []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "user-a"}}},
+			{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-a", Name: "sa"}, RoleRef: ref, Subjects: []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: "sa"}}},
+		},
+	)
+
+	tests := map[string]struct {
+		user      string
+		groups    []string
+		namespace string
+		policy    string
+		exp       bool
+	}{
+		"user bound in namespace":           {user: "user-a", namespace: "ns-a", policy: "test-policy", exp: true},
+		"user not bound in other namespace": {user: "user-a", namespace: "ns-b", policy: "test-policy", exp: false},
+		"user not bound to other policy":    {user: "user-a", namespace: "ns-a", policy: "other-policy", exp: false},
+		"group bound in all namespaces":     {user: "user-b", groups: []string{"group-a"}, namespace: "ns-b", policy: "test-policy", exp: true},
+		"service account in namespace of binding": {
+			user: "system:serviceaccount:ns-a:sa", namespace: "ns-a", policy: "test-policy", exp: true,
+		},
+		"service account in other namespace": {
+			user: "system:serviceaccount:ns-b:sa", namespace: "ns-a", policy: "test-policy", exp: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.exp, snapshot.Allows(test.user, test.groups, test.namespace, UsePolicy(test.policy)))
+		})
+	}
+}
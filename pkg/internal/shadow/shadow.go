@@ -0,0 +1,421 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package shadow detects CertificateRequestPolicies which are made pointless by
+// other CertificateRequestPolicies.
+//
+// A request is approved if any bound policy approves it, so a policy which
+// selects every request another policy selects, and permits everything the
+// other policy permits, shadows it: for requesters bound to both policies the
+// shadowed policy never changes the outcome of a review.
+//
+// Detection is conservative. A policy is only reported as shadowing another
+// when that can be determined from the two specs alone, so CEL validations,
+// constraints and plugin values must match exactly to be considered
+// equivalent.
+package shadow
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"k8s.io/utils/ptr"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/internal/util"
+)
+
+// Relation is how a CertificateRequestPolicy relates to another
+// CertificateRequestPolicy.
+type Relation string
+
+const (
+	// RelationShadows means the policy shadows the other policy, making the
+	// other policy pointless.
+	RelationShadows Relation = "Shadows"
+
+	// RelationShadowedBy means the policy is shadowed by the other policy,
+	// making the policy pointless.
+	RelationShadowedBy Relation = "ShadowedBy"
+
+	// RelationEquivalent means both policies select and permit the same
+	// requests, so each makes the other pointless.
+	RelationEquivalent Relation = "Equivalent"
+)
+
+// Finding is an overlap between a CertificateRequestPolicy and another
+// CertificateRequestPolicy.
+type Finding struct {
+	// Policy is the name of the other CertificateRequestPolicy.
+	Policy string
+
+	// Relation is how the CertificateRequestPolicy relates to Policy.
+	Relation Relation
+}
+
+// String returns a human readable description of the Finding.
+func (f Finding) String() string {
+	switch f.Relation {
+	case RelationShadows:
+		return fmt.Sprintf("shadows CertificateRequestPolicy %q: it selects and permits every request %q does, so %q has no effect for requesters bound to both", f.Policy, f.Policy, f.Policy)
+	case RelationShadowedBy:
+		return fmt.Sprintf("is shadowed by CertificateRequestPolicy %q: %q selects and permits every request this policy does, so this policy has no effect for requesters bound to both", f.Policy, f.Policy)
+	default:
+		return fmt.Sprintf("is equivalent to CertificateRequestPolicy %q: both select and permit the same requests, so one has no effect for requesters bound to both", f.Policy)
+	}
+}
+
+// Find returns the overlaps between the given CertificateRequestPolicy and
+// each of the given policies, sorted by policy name. Policies with the same
+// name as the given policy are ignored, so the given policy may be included in
+// policies.
+func Find(policy *policyapi.CertificateRequestPolicy, policies []policyapi.CertificateRequestPolicy) []Finding {
+	var findings []Finding
+	for i := range policies {
+		other := &policies[i]
+		if other.Name == policy.Name {
+			continue
+		}
+
+		shadows, shadowed := Shadows(policy, other), Shadows(other, policy)
+		switch {
+		case shadows && shadowed:
+			findings = append(findings, Finding{Policy: other.Name, Relation: RelationEquivalent})
+		case shadows:
+			findings = append(findings, Finding{Policy: other.Name, Relation: RelationShadows})
+		case shadowed:
+			findings = append(findings, Finding{Policy: other.Name, Relation: RelationShadowedBy})
+		}
+	}
+
+	sort.Slice(findings, func(i, j int) bool {
+		return findings[i].Policy < findings[j].Policy
+	})
+
+	return findings
+}
+
+// Shadows returns true if policy a shadows policy b, i.e. a selects every
+// request b selects, and approves every request b approves.
+func Shadows(a, b *policyapi.CertificateRequestPolicy) bool {
+	return selectorCovers(a.Spec.Selector, b.Spec.Selector) &&
+		pluginsCover(a.Spec.Plugins, b.Spec.Plugins) &&
+		constraintsCover(a.Spec.Constraints, b.Spec.Constraints) &&
+		allowedCovers(a.Spec.Allowed, b.Spec.Allowed)
+}
+
+// selectorCovers returns true if selector a selects every request selector b
+// selects.
+func selectorCovers(a, b policyapi.CertificateRequestPolicySelector) bool {
+	if a.IssuerRef != nil {
+		if b.IssuerRef == nil {
+			b.IssuerRef = new(policyapi.CertificateRequestPolicySelectorIssuerRef)
+		}
+		if !patternCovers(a.IssuerRef.Name, b.IssuerRef.Name) ||
+			!patternCovers(a.IssuerRef.Kind, b.IssuerRef.Kind) ||
+			!patternCovers(a.IssuerRef.Group, b.IssuerRef.Group) {
+			return false
+		}
+	}
+
+	if a.Namespace != nil {
+		if b.Namespace == nil {
+			b.Namespace = new(policyapi.CertificateRequestPolicySelectorNamespace)
+		}
+		if len(a.Namespace.MatchNames) > 0 {
+			if len(b.Namespace.MatchNames) == 0 {
+				b.Namespace.MatchNames = []string{"*"}
+			}
+			if !util.WildcardSubset(a.Namespace.MatchNames, b.Namespace.MatchNames) {
+				return false
+			}
+		}
+		// b must require at least the labels a requires.
+		for k, v := range a.Namespace.MatchLabels {
+			if bv, ok := b.Namespace.MatchLabels[k]; !ok || bv != v {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// patternCovers returns true if the wildcard pattern a matches every string
+// that b matches. A nil pattern matches everything.
+func patternCovers(a, b *string) bool {
+	if a == nil {
+		return true
+	}
+	if b == nil {
+		return util.WildcardMatches(*a, "*")
+	}
+	// Matching b as a literal string is sufficient since a wildcard in b can
+	// only be matched by a wildcard in a.
+	return util.WildcardMatches(*a, *b)
+}
+
+// pluginsCover returns true if every plugin of a is configured identically in
+// b, so that plugins of a approve every request plugins of b approve.
+func pluginsCover(a, b map[string]policyapi.CertificateRequestPolicyPluginData) bool {
+	for name, data := range a {
+		bData, ok := b[name]
+		if !ok || !reflect.DeepEqual(data, bData) {
+			return false
+		}
+	}
+	return true
+}
+
+// constraintsCover returns true if constraints a are satisfied by every
+// request satisfying constraints b.
+func constraintsCover(a, b *policyapi.CertificateRequestPolicyConstraints) bool {
+	if a == nil {
+		return true
+	}
+	if b == nil {
+		b = new(policyapi.CertificateRequestPolicyConstraints)
+	}
+
+	if a.MinDuration != nil && (b.MinDuration == nil || b.MinDuration.Duration < a.MinDuration.Duration) {
+		return false
+	}
+	if a.MaxDuration != nil && (b.MaxDuration == nil || b.MaxDuration.Duration > a.MaxDuration.Duration) {
+		return false
+	}
+
+	// All other constraints of a must be identical in b.
+	av, bv := reflect.ValueOf(*a), reflect.ValueOf(*b)
+	for i := 0; i < av.NumField(); i++ {
+		switch av.Type().Field(i).Name {
+		case "MinDuration", "MaxDuration":
+			continue
+		}
+		if !av.Field(i).IsZero() && !reflect.DeepEqual(av.Field(i).Interface(), bv.Field(i).Interface()) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// allowedCovers returns true if allowed a permits every request attribute
+// that allowed b permits.
+func allowedCovers(a, b *policyapi.CertificateRequestPolicyAllowed) bool {
+	if a == nil {
+		a = new(policyapi.CertificateRequestPolicyAllowed)
+	}
+	if b == nil {
+		b = new(policyapi.CertificateRequestPolicyAllowed)
+	}
+
+	if ptrTrue(b.IsCA) && !ptrTrue(a.IsCA) {
+		return false
+	}
+
+	var aUsages, bUsages []cmapi.KeyUsage
+	if a.Usages != nil {
+		aUsages = *a.Usages
+	}
+	if b.Usages != nil {
+		bUsages = *b.Usages
+	}
+	for _, usage := range bUsages {
+		if !containsUsage(aUsages, usage) {
+			return false
+		}
+	}
+
+	return stringCovers(a.CommonName, b.CommonName) &&
+		sliceCovers(a.DNSNames, b.DNSNames) &&
+		sliceCovers(a.IPAddresses, b.IPAddresses) &&
+		sliceCovers(a.URIs, b.URIs) &&
+		sliceCovers(a.EmailAddresses, b.EmailAddresses) &&
+		sliceCovers(a.RegisteredIDs, b.RegisteredIDs) &&
+		otherNamesCover(a.OtherNames, b.OtherNames) &&
+		subjectCovers(a.Subject, b.Subject)
+}
+
+// subjectCovers returns true if subject a permits every Subject that b
+// permits.
+func subjectCovers(a, b *policyapi.CertificateRequestPolicyAllowedX509Subject) bool {
+	if a == nil {
+		a = new(policyapi.CertificateRequestPolicyAllowedX509Subject)
+	}
+	if b == nil {
+		b = new(policyapi.CertificateRequestPolicyAllowedX509Subject)
+	}
+
+	// An unset dn places no restriction on the Subject, unlike other fields.
+	if a.DN != nil && (b.DN == nil || !stringCovers(a.DN, b.DN)) {
+		return false
+	}
+
+	aExtra := make(map[string]*policyapi.CertificateRequestPolicyAllowedStringSlice)
+	for i := range a.ExtraAttributes {
+		aExtra[a.ExtraAttributes[i].OID] = &a.ExtraAttributes[i].CertificateRequestPolicyAllowedStringSlice
+	}
+	bExtra := make(map[string]*policyapi.CertificateRequestPolicyAllowedStringSlice)
+	for i := range b.ExtraAttributes {
+		bExtra[b.ExtraAttributes[i].OID] = &b.ExtraAttributes[i].CertificateRequestPolicyAllowedStringSlice
+	}
+
+	return stringCovers(a.SerialNumber, b.SerialNumber) &&
+		sliceCovers(a.Organizations, b.Organizations) &&
+		sliceCovers(a.Countries, b.Countries) &&
+		sliceCovers(a.OrganizationalUnits, b.OrganizationalUnits) &&
+		sliceCovers(a.Localities, b.Localities) &&
+		sliceCovers(a.Provinces, b.Provinces) &&
+		sliceCovers(a.StreetAddresses, b.StreetAddresses) &&
+		sliceCovers(a.PostalCodes, b.PostalCodes) &&
+		slicesByOIDCover(aExtra, bExtra)
+}
+
+// otherNamesCover returns true if otherNames a permit every otherName SAN
+// that b permits.
+func otherNamesCover(a, b []policyapi.CertificateRequestPolicyAllowedOtherName) bool {
+	aByOID := make(map[string]*policyapi.CertificateRequestPolicyAllowedStringSlice)
+	for i := range a {
+		aByOID[a[i].OID] = &a[i].CertificateRequestPolicyAllowedStringSlice
+	}
+	bByOID := make(map[string]*policyapi.CertificateRequestPolicyAllowedStringSlice)
+	for i := range b {
+		bByOID[b[i].OID] = &b[i].CertificateRequestPolicyAllowedStringSlice
+	}
+	return slicesByOIDCover(aByOID, bByOID)
+}
+
+// slicesByOIDCover returns true if, for every OID in either a or b, the
+// allowed values of a permit every value the allowed values of b permit.
+func slicesByOIDCover(a, b map[string]*policyapi.CertificateRequestPolicyAllowedStringSlice) bool {
+	for oid := range a {
+		if !sliceCovers(a[oid], b[oid]) {
+			return false
+		}
+	}
+	for oid := range b {
+		if !sliceCovers(a[oid], b[oid]) {
+			return false
+		}
+	}
+	return true
+}
+
+// stringCovers returns true if allowed string a permits every value that b
+// permits.
+func stringCovers(a, b *policyapi.CertificateRequestPolicyAllowedString) bool {
+	// a must permit an empty value if b does.
+	var bRequired *bool
+	if b != nil {
+		bRequired = b.Required
+	}
+	if !ptrTrue(bRequired) && a != nil && ptrTrue(a.Required) {
+		return false
+	}
+
+	// If b only permits an empty value, there is nothing else to cover.
+	if b == nil || !permitsValues(b.Value != nil, b.Value != nil && len(*b.Value) > 0, b.Validations) {
+		return true
+	}
+	if a == nil || !permitsValues(a.Value != nil, a.Value != nil && len(*a.Value) > 0, a.Validations) {
+		return false
+	}
+
+	if !validationsCover(a.Validations, b.Validations) {
+		return false
+	}
+
+	if a.Value == nil {
+		return true
+	}
+	// b without a value permits any value passing its validations.
+	return util.WildcardMatches(*a.Value, ptr.Deref(b.Value, "*"))
+}
+
+// sliceCovers returns true if allowed string slice a permits every set of
+// values that b permits.
+func sliceCovers(a, b *policyapi.CertificateRequestPolicyAllowedStringSlice) bool {
+	// a must permit no values if b does.
+	var bRequired *bool
+	if b != nil {
+		bRequired = b.Required
+	}
+	if !ptrTrue(bRequired) && a != nil && ptrTrue(a.Required) {
+		return false
+	}
+
+	// If b only permits no values, there is nothing else to cover.
+	if b == nil || !permitsValues(b.Values != nil, b.Values != nil && len(*b.Values) > 0, b.Validations) {
+		return true
+	}
+	if a == nil || !permitsValues(a.Values != nil, a.Values != nil && len(*a.Values) > 0, a.Validations) {
+		return false
+	}
+
+	if !validationsCover(a.Validations, b.Validations) {
+		return false
+	}
+
+	if a.Values == nil {
+		return true
+	}
+	// b without values permits any values passing its validations.
+	return util.WildcardSubset(*a.Values, ptr.Deref(b.Values, []string{"*"}))
+}
+
+// permitsValues returns true if an allowed field permits any non-empty value.
+// Fields with values set only permit those values, otherwise fields with
+// validations permit any value passing them.
+func permitsValues(valuesSet, valuesNonEmpty bool, validations []policyapi.ValidationRule) bool {
+	if valuesSet {
+		return valuesNonEmpty
+	}
+	return len(validations) > 0
+}
+
+// validationsCover returns true if every validation rule in a is also a rule
+// in b.
+func validationsCover(a, b []policyapi.ValidationRule) bool {
+	for _, aRule := range a {
+		var found bool
+		for _, bRule := range b {
+			if strings.TrimSpace(aRule.Rule) == strings.TrimSpace(bRule.Rule) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func containsUsage(usages []cmapi.KeyUsage, usage cmapi.KeyUsage) bool {
+	for _, u := range usages {
+		if u == usage {
+			return true
+		}
+	}
+	return false
+}
+
+func ptrTrue(b *bool) bool {
+	return b != nil && *b
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shadow
+
+import (
+	"testing"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+)
+
+func Test_Shadows(t *testing.T) {
+	issuer := func(name string) policyapi.CertificateRequestPolicySelector {
+		return policyapi.CertificateRequestPolicySelector{
+			IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{Name: ptr.To(name)},
+		}
+	}
+	dnsNames := func(values ...string) *policyapi.CertificateRequestPolicyAllowed {
+		return &policyapi.CertificateRequestPolicyAllowed{
+			DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &values},
+		}
+	}
+
+	tests := map[string]struct {
+		a, b policyapi.CertificateRequestPolicySpec
+		exp  bool
+	}{
+		"identical policies shadow each other": {
+			a:   policyapi.CertificateRequestPolicySpec{Selector: issuer("my-issuer"), Allowed: dnsNames("*.example.com")},
+			b:   policyapi.CertificateRequestPolicySpec{Selector: issuer("my-issuer"), Allowed: dnsNames("*.example.com")},
+			exp: true,
+		},
+		"a broader dnsNames wildcard on the same issuer shadows a stricter policy": {
+			a:   policyapi.CertificateRequestPolicySpec{Selector: issuer("my-issuer"), Allowed: dnsNames("*.example.com")},
+			b:   policyapi.CertificateRequestPolicySpec{Selector: issuer("my-issuer"), Allowed: dnsNames("*.foo.example.com", "bar.example.com")},
+			exp: true,
+		},
+		"a stricter dnsNames wildcard doesn't shadow a broader policy": {
+			a:   policyapi.CertificateRequestPolicySpec{Selector: issuer("my-issuer"), Allowed: dnsNames("*.foo.example.com")},
+			b:   policyapi.CertificateRequestPolicySpec{Selector: issuer("my-issuer"), Allowed: dnsNames("*.example.com")},
+			exp: false,
+		},
+		"a policy selecting fewer issuers doesn't shadow": {
+			a:   policyapi.CertificateRequestPolicySpec{Selector: issuer("my-issuer"), Allowed: dnsNames("*")},
+			b:   policyapi.CertificateRequestPolicySpec{Selector: issuer("*"), Allowed: dnsNames("*.example.com")},
+			exp: false,
+		},
+		"a policy selecting any issuer shadows a policy selecting fewer": {
+			a:   policyapi.CertificateRequestPolicySpec{Selector: issuer("*"), Allowed: dnsNames("*")},
+			b:   policyapi.CertificateRequestPolicySpec{Selector: issuer("my-*"), Allowed: dnsNames("*.example.com")},
+			exp: true,
+		},
+		"a policy selecting different namespaces doesn't shadow": {
+			a: policyapi.CertificateRequestPolicySpec{Selector: policyapi.CertificateRequestPolicySelector{
+				Namespace: &policyapi.CertificateRequestPolicySelectorNamespace{MatchNames: []string{"team-a"}},
+			}},
+			b: policyapi.CertificateRequestPolicySpec{Selector: policyapi.CertificateRequestPolicySelector{
+				Namespace: &policyapi.CertificateRequestPolicySelectorNamespace{MatchNames: []string{"team-b"}},
+			}},
+			exp: false,
+		},
+		"a policy requiring fewer namespace labels shadows": {
+			a: policyapi.CertificateRequestPolicySpec{Selector: policyapi.CertificateRequestPolicySelector{
+				Namespace: &policyapi.CertificateRequestPolicySelectorNamespace{MatchLabels: map[string]string{"foo": "bar"}},
+			}},
+			b: policyapi.CertificateRequestPolicySpec{Selector: policyapi.CertificateRequestPolicySelector{
+				Namespace: &policyapi.CertificateRequestPolicySelectorNamespace{MatchNames: []string{"team-a"}, MatchLabels: map[string]string{"foo": "bar", "baz": "qux"}},
+			}},
+			exp: true,
+		},
+		"a policy requiring a value doesn't shadow one which doesn't": {
+			a: policyapi.CertificateRequestPolicySpec{Selector: issuer("*"), Allowed: &policyapi.CertificateRequestPolicyAllowed{
+				CommonName: &policyapi.CertificateRequestPolicyAllowedString{Value: ptr.To("*"), Required: ptr.To(true)},
+			}},
+			b: policyapi.CertificateRequestPolicySpec{Selector: issuer("*"), Allowed: &policyapi.CertificateRequestPolicyAllowed{
+				CommonName: &policyapi.CertificateRequestPolicyAllowedString{Value: ptr.To("foo")},
+			}},
+			exp: false,
+		},
+		"a policy with validations doesn't shadow one without them": {
+			a: policyapi.CertificateRequestPolicySpec{Selector: issuer("*"), Allowed: &policyapi.CertificateRequestPolicyAllowed{
+				DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"*"}, Validations: []policyapi.ValidationRule{{Rule: "self.endsWith('.com')"}}},
+			}},
+			b:   policyapi.CertificateRequestPolicySpec{Selector: issuer("*"), Allowed: dnsNames("example.com")},
+			exp: false,
+		},
+		"a policy without validations shadows one with them": {
+			a: policyapi.CertificateRequestPolicySpec{Selector: issuer("*"), Allowed: dnsNames("*")},
+			b: policyapi.CertificateRequestPolicySpec{Selector: issuer("*"), Allowed: &policyapi.CertificateRequestPolicyAllowed{
+				DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{Validations: []policyapi.ValidationRule{{Rule: "self.endsWith('.com')"}}},
+			}},
+			exp: true,
+		},
+		"a policy not allowing isCA or a usage doesn't shadow one which does": {
+			a: policyapi.CertificateRequestPolicySpec{Selector: issuer("*"), Allowed: &policyapi.CertificateRequestPolicyAllowed{
+				Usages: &[]cmapi.KeyUsage{cmapi.UsageServerAuth},
+			}},
+			b: policyapi.CertificateRequestPolicySpec{Selector: issuer("*"), Allowed: &policyapi.CertificateRequestPolicyAllowed{
+				IsCA: ptr.To(true), Usages: &[]cmapi.KeyUsage{cmapi.UsageServerAuth},
+			}},
+			exp: false,
+		},
+		"a policy with a wider duration range shadows": {
+			a: policyapi.CertificateRequestPolicySpec{Selector: issuer("*"), Constraints: &policyapi.CertificateRequestPolicyConstraints{
+				MaxDuration: &metav1.Duration{Duration: 2 * time.Hour},
+			}},
+			b: policyapi.CertificateRequestPolicySpec{Selector: issuer("*"), Constraints: &policyapi.CertificateRequestPolicyConstraints{
+				MinDuration: &metav1.Duration{Duration: time.Minute},
+				MaxDuration: &metav1.Duration{Duration: time.Hour},
+			}},
+			exp: true,
+		},
+		"a policy with a different private key constraint doesn't shadow": {
+			a: policyapi.CertificateRequestPolicySpec{Selector: issuer("*"), Constraints: &policyapi.CertificateRequestPolicyConstraints{
+				PrivateKey: &policyapi.CertificateRequestPolicyConstraintsPrivateKey{Algorithm: ptr.To(cmapi.ECDSAKeyAlgorithm)},
+			}},
+			b:   policyapi.CertificateRequestPolicySpec{Selector: issuer("*")},
+			exp: false,
+		},
+		"a policy with a plugin doesn't shadow one without it": {
+			a: policyapi.CertificateRequestPolicySpec{Selector: issuer("*"), Plugins: map[string]policyapi.CertificateRequestPolicyPluginData{
+				"manual-approval": {},
+			}},
+			b:   policyapi.CertificateRequestPolicySpec{Selector: issuer("*")},
+			exp: false,
+		},
+		"a policy without a plugin shadows one with it": {
+			a: policyapi.CertificateRequestPolicySpec{Selector: issuer("*")},
+			b: policyapi.CertificateRequestPolicySpec{Selector: issuer("*"), Plugins: map[string]policyapi.CertificateRequestPolicyPluginData{
+				"manual-approval": {},
+			}},
+			exp: true,
+		},
+		"a policy constraining the dn doesn't shadow one which doesn't": {
+			a: policyapi.CertificateRequestPolicySpec{Selector: issuer("*"), Allowed: &policyapi.CertificateRequestPolicyAllowed{
+				Subject: &policyapi.CertificateRequestPolicyAllowedX509Subject{DN: &policyapi.CertificateRequestPolicyAllowedString{Value: ptr.To("*")}},
+			}},
+			b:   policyapi.CertificateRequestPolicySpec{Selector: issuer("*")},
+			exp: false,
+		},
+		"a policy not allowing an otherName type doesn't shadow one which does": {
+			a: policyapi.CertificateRequestPolicySpec{Selector: issuer("*")},
+			b: policyapi.CertificateRequestPolicySpec{Selector: issuer("*"), Allowed: &policyapi.CertificateRequestPolicyAllowed{
+				OtherNames: []policyapi.CertificateRequestPolicyAllowedOtherName{{
+					OID: "1.3.6.1.4.1.311.20.2.3",
+					CertificateRequestPolicyAllowedStringSlice: policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"*@example.com"}},
+				}},
+			}},
+			exp: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			a := &policyapi.CertificateRequestPolicy{ObjectMeta: metav1.ObjectMeta{Name: "a"}, Spec: test.a}
+			b := &policyapi.CertificateRequestPolicy{ObjectMeta: metav1.ObjectMeta{Name: "b"}, Spec: test.b}
+			assert.Equal(t, test.exp, Shadows(a, b))
+		})
+	}
+}
+
+func Test_Find(t *testing.T) {
+	policy := func(name, dnsName string) policyapi.CertificateRequestPolicy {
+		return policyapi.CertificateRequestPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: policyapi.CertificateRequestPolicySpec{
+				Selector: policyapi.CertificateRequestPolicySelector{IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{}},
+				Allowed: &policyapi.CertificateRequestPolicyAllowed{
+					DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{dnsName}},
+				},
+			},
+		}
+	}
+
+	p := policy("policy", "*.example.com")
+	findings := Find(&p, []policyapi.CertificateRequestPolicy{
+		policy("policy", "*"),
+		policy("d-unrelated", "*.example.net"),
+		policy("c-broader", "*"),
+		policy("b-stricter", "foo.example.com"),
+		policy("a-equivalent", "*.example.com"),
+	})
+
+	assert.Equal(t, []Finding{
+		{Policy: "a-equivalent", Relation: RelationEquivalent},
+		{Policy: "b-stricter", Relation: RelationShadows},
+		{Policy: "c-broader", Relation: RelationShadowedBy},
+	}, findings)
+}
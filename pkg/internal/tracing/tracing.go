@@ -0,0 +1,104 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tracing configures OpenTelemetry tracing of approver-policy.
+// Spans are always created through Tracer, but are only recorded and exported
+// once Setup has been called. Until then, the global no-op TracerProvider is
+// used.
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName is the name of the tracer used for all approver-policy
+// spans.
+const instrumentationName = "github.com/cert-manager/approver-policy"
+
+// Options configure exporting traces to an OTLP collector.
+type Options struct {
+	// Endpoint is the host and port of the OTLP gRPC collector traces are
+	// exported to. Tracing is disabled if empty.
+	Endpoint string
+
+	// Insecure disables TLS when connecting to the collector.
+	Insecure bool
+
+	// SampleRatio is the ratio of traces which are sampled, between 0 and 1.
+	// Traces with a sampled parent are always sampled.
+	SampleRatio float64
+}
+
+// Tracer returns the tracer used to create approver-policy spans.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Setup configures the global TracerProvider to export spans to the OTLP
+// collector in opts, and the global propagator to propagate W3C trace
+// context. The returned function flushes and stops exporting spans. If no
+// endpoint is configured, Setup does nothing.
+func Setup(ctx context.Context, opts Options) (func(context.Context) error, error) {
+	if len(opts.Endpoint) == 0 {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	if opts.SampleRatio < 0 || opts.SampleRatio > 1 {
+		return nil, fmt.Errorf("tracing sample ratio must be between 0 and 1, got %v", opts.SampleRatio)
+	}
+
+	exporterOpts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(opts.Endpoint)}
+	if opts.Insecure {
+		exporterOpts = append(exporterOpts, otlptracegrpc.WithInsecure())
+	}
+
+	exporter, err := otlptracegrpc.New(ctx, exporterOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName("approver-policy"),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tracing resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio))),
+	)
+	SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// SetTracerProvider sets the global TracerProvider and propagator. Used by
+// Setup, and by tests to record spans with an in-memory exporter.
+func SetTracerProvider(provider trace.TracerProvider) {
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+}
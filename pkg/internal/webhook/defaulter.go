@@ -0,0 +1,61 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	"github.com/cert-manager/approver-policy/pkg/approver"
+)
+
+// defaulter defaults policy.cert-manager.io resources.
+type defaulter struct {
+	log logr.Logger
+
+	schemas map[string]approver.PluginSchema
+}
+
+var _ admission.CustomDefaulter = &defaulter{}
+
+// Default sets the default values declared by plugin schemas on the plugin
+// values of the CertificateRequestPolicy.
+func (d *defaulter) Default(_ context.Context, obj runtime.Object) error {
+	policy, ok := obj.(*policyapi.CertificateRequestPolicy)
+	if !ok {
+		return fmt.Errorf("expected a CertificateRequestPolicy, but got a %T", obj)
+	}
+
+	for name, plugin := range policy.Spec.Plugins {
+		schema, ok := d.schemas[name]
+		if !ok {
+			continue
+		}
+
+		if values, defaulted := schema.Default(plugin.Values); defaulted {
+			d.log.V(2).Info("defaulting plugin values", "policy", policy.Name, "plugin", name)
+			policy.Spec.Plugins[name] = policyapi.CertificateRequestPolicyPluginData{Values: values}
+		}
+	}
+
+	return nil
+}
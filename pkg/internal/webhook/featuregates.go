@@ -0,0 +1,83 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"fmt"
+	"sort"
+
+	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// certManagerFeatureGate is a cert-manager controller feature gate which
+// changes the CSR that cert-manager builds for a Certificate.
+type certManagerFeatureGate struct {
+	// enabled is the default of the feature gate in cert-manager.
+	enabled bool
+
+	// option returns the GenerateCSR option for the state of the feature gate.
+	option func(bool) utilpki.GenerateCSROption
+}
+
+// certManagerFeatureGates are the cert-manager controller feature gates, and
+// their defaults as of cert-manager v1.17, which change the CSR built for a
+// Certificate.
+var certManagerFeatureGates = map[string]certManagerFeatureGate{
+	"LiteralCertificateSubject":             {enabled: true, option: utilpki.WithUseLiteralSubject},
+	"UseCertificateRequestBasicConstraints": {enabled: false, option: utilpki.WithEncodeBasicConstraintsInRequest},
+	"NameConstraints":                       {enabled: true, option: utilpki.WithNameConstraints},
+	"OtherNames":                            {enabled: false, option: utilpki.WithOtherNames},
+}
+
+// CertManagerFeatureGates returns the supported cert-manager feature gates
+// along with their defaults, in the form "Name=true|false (default X)".
+func CertManagerFeatureGates() []string {
+	var gates []string
+	for name, gate := range certManagerFeatureGates {
+		gates = append(gates, fmt.Sprintf("%s=true|false (default %t)", name, gate.enabled))
+	}
+	sort.Strings(gates)
+	return gates
+}
+
+// csrOptions returns the GenerateCSR options matching the given cert-manager
+// feature gates. Feature gates which are not given take cert-manager's
+// default. An error is returned for feature gates which are not supported.
+func csrOptions(featureGates map[string]bool) ([]utilpki.GenerateCSROption, error) {
+	for name := range featureGates {
+		if _, ok := certManagerFeatureGates[name]; !ok {
+			return nil, fmt.Errorf("unsupported cert-manager feature gate %q", name)
+		}
+	}
+
+	var names []string
+	for name := range certManagerFeatureGates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var opts []utilpki.GenerateCSROption
+	for _, name := range names {
+		gate := certManagerFeatureGates[name]
+		enabled, ok := featureGates[name]
+		if !ok {
+			enabled = gate.enabled
+		}
+		opts = append(opts, gate.option(enabled))
+	}
+	return opts, nil
+}
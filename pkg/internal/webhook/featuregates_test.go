@@ -0,0 +1,59 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"testing"
+
+	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_csrOptions(t *testing.T) {
+	crt := gen.Certificate("test",
+		gen.SetCertificateCommonName("example.com"),
+		gen.SetCertificateIsCA(true),
+	)
+
+	hasBasicConstraints := func(t *testing.T, featureGates map[string]bool) bool {
+		opts, err := csrOptions(featureGates)
+		require.NoError(t, err)
+		template, err := utilpki.GenerateCSR(crt, opts...)
+		require.NoError(t, err)
+		for _, ext := range template.ExtraExtensions {
+			if ext.Id.Equal(utilpki.OIDExtensionBasicConstraints) {
+				return true
+			}
+		}
+		return false
+	}
+
+	t.Run("feature gates which are not given take cert-manager's default", func(t *testing.T) {
+		assert.False(t, hasBasicConstraints(t, nil))
+	})
+
+	t.Run("enabled feature gates are used to build the CSR", func(t *testing.T) {
+		assert.True(t, hasBasicConstraints(t, map[string]bool{"UseCertificateRequestBasicConstraints": true}))
+	})
+
+	t.Run("unsupported feature gates return an error", func(t *testing.T) {
+		_, err := csrOptions(map[string]bool{"ServerSideApply": true})
+		assert.EqualError(t, err, `unsupported cert-manager feature gate "ServerSideApply"`)
+	})
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+	internalmanager "github.com/cert-manager/approver-policy/pkg/internal/approver/manager"
+)
+
+// requestDefaulter defaults the duration and usages of CertificateRequests
+// and Certificates from the defaults of the highest priority
+// CertificateRequestPolicy which applies to them.
+type requestDefaulter struct {
+	log logr.Logger
+
+	// selector selects the policies which apply to a request.
+	selector internalmanager.Selector
+}
+
+var _ admission.CustomDefaulter = &requestDefaulter{}
+
+// Default sets the policy defaults on a CertificateRequest or Certificate
+// which has been created without them.
+func (d *requestDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	switch obj := obj.(type) {
+	case *cmapi.CertificateRequest:
+		// The requester may not have been set on the request yet, since the
+		// order of mutating webhooks is not guaranteed.
+		cr := obj.DeepCopy()
+		if cr.Spec.Username == "" {
+			cr.Spec.Username = req.UserInfo.Username
+			cr.Spec.UID = req.UserInfo.UID
+			cr.Spec.Groups = req.UserInfo.Groups
+		}
+
+		defaults, err := d.defaults(ctx, cr)
+		if err != nil || defaults == nil {
+			return err
+		}
+
+		if obj.Spec.Duration == nil && defaults.Duration != nil {
+			obj.Spec.Duration = defaults.Duration.DeepCopy()
+		}
+		if len(obj.Spec.Usages) == 0 && len(defaults.Usages) > 0 && !requestsKeyUsages(obj) {
+			obj.Spec.Usages = append([]cmapi.KeyUsage(nil), defaults.Usages...)
+		}
+
+	case *cmapi.Certificate:
+		// Policies are selected for the CertificateRequest that will be
+		// created for the Certificate, as if requested by the user creating
+		// the Certificate.
+		cr := &cmapi.CertificateRequest{}
+		cr.Namespace = obj.Namespace
+		cr.Spec.IssuerRef = obj.Spec.IssuerRef
+		cr.Spec.IsCA = obj.Spec.IsCA
+		cr.Spec.Username = req.UserInfo.Username
+		cr.Spec.UID = req.UserInfo.UID
+		cr.Spec.Groups = req.UserInfo.Groups
+
+		defaults, err := d.defaults(ctx, cr)
+		if err != nil || defaults == nil {
+			return err
+		}
+
+		if obj.Spec.Duration == nil && defaults.Duration != nil {
+			obj.Spec.Duration = defaults.Duration.DeepCopy()
+		}
+		if len(obj.Spec.Usages) == 0 && len(defaults.Usages) > 0 {
+			obj.Spec.Usages = append([]cmapi.KeyUsage(nil), defaults.Usages...)
+		}
+
+	default:
+		return fmt.Errorf("expected a CertificateRequest or Certificate, but got a %T", obj)
+	}
+
+	return nil
+}
+
+// defaults returns the defaults of the highest priority policy which applies
+// to the request, or nil if no applicable policy defines defaults.
+func (d *requestDefaulter) defaults(ctx context.Context, cr *cmapi.CertificateRequest) (*policyapi.CertificateRequestPolicyDefaults, error) {
+	policies, err := d.selector.Select(ctx, cr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select policies: %w", err)
+	}
+
+	var withDefaults []policyapi.CertificateRequestPolicy
+	for _, policy := range policies {
+		if policy.Spec.Defaults != nil {
+			withDefaults = append(withDefaults, policy)
+		}
+	}
+	if len(withDefaults) == 0 {
+		return nil, nil
+	}
+
+	sort.SliceStable(withDefaults, func(i, j int) bool {
+		if withDefaults[i].Spec.Defaults.Priority != withDefaults[j].Spec.Defaults.Priority {
+			return withDefaults[i].Spec.Defaults.Priority > withDefaults[j].Spec.Defaults.Priority
+		}
+		return withDefaults[i].Name < withDefaults[j].Name
+	})
+
+	d.log.V(2).Info("defaulting request", "namespace", cr.Namespace, "policy", withDefaults[0].Name)
+	return withDefaults[0].Spec.Defaults, nil
+}
+
+// requestsKeyUsages returns true if the CSR of the CertificateRequest requests
+// key usages or extended key usages, which must match the usages of the
+// CertificateRequest. Requests with a CSR which cannot be decoded are treated
+// as requesting usages, so are not defaulted.
+func requestsKeyUsages(cr *cmapi.CertificateRequest) bool {
+	csr, err := utilpki.DecodeX509CertificateRequestBytes(cr.Spec.Request)
+	if err != nil {
+		return true
+	}
+	for _, ext := range csr.Extensions {
+		if oid := ext.Id.String(); oid == "2.5.29.15" || oid == "2.5.29.37" {
+			return true
+		}
+	}
+	return false
+}
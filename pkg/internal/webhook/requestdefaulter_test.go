@@ -0,0 +1,130 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2/ktesting"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
+)
+
+// selectorFunc implements a Selector with a function.
+type selectorFunc func(context.Context, *cmapi.CertificateRequest) ([]policyapi.CertificateRequestPolicy, error)
+
+func (f selectorFunc) Select(ctx context.Context, cr *cmapi.CertificateRequest) ([]policyapi.CertificateRequestPolicy, error) {
+	return f(ctx, cr)
+}
+
+func Test_requestDefaulter(t *testing.T) {
+	policyWithDefaults := func(name string, defaults *policyapi.CertificateRequestPolicyDefaults) policyapi.CertificateRequestPolicy {
+		return policyapi.CertificateRequestPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       policyapi.CertificateRequestPolicySpec{Defaults: defaults},
+		}
+	}
+
+	csr, _, err := gen.CSR(x509.ECDSA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csrWithUsages, _, err := gen.CSR(x509.ECDSA, func(csr *x509.CertificateRequest) error {
+		ext, err := utilpki.MarshalKeyUsage(x509.KeyUsageDigitalSignature)
+		csr.ExtraExtensions = append(csr.ExtraExtensions, ext)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		obj      runtime.Object
+		policies []policyapi.CertificateRequestPolicy
+		expObj   runtime.Object
+	}{
+		"if no applicable policy has defaults, do nothing": {
+			obj:      gen.CertificateRequest("test", gen.SetCertificateRequestCSR(csr)),
+			policies: []policyapi.CertificateRequestPolicy{policyWithDefaults("a", nil)},
+			expObj:   gen.CertificateRequest("test", gen.SetCertificateRequestCSR(csr)),
+		},
+		"if applicable policies have defaults, set the defaults of the highest priority policy": {
+			obj: gen.CertificateRequest("test", gen.SetCertificateRequestCSR(csr)),
+			policies: []policyapi.CertificateRequestPolicy{
+				policyWithDefaults("b", &policyapi.CertificateRequestPolicyDefaults{Priority: 10, Duration: &metav1.Duration{Duration: time.Hour}, Usages: []cmapi.KeyUsage{cmapi.UsageServerAuth}}),
+				policyWithDefaults("a", &policyapi.CertificateRequestPolicyDefaults{Priority: 10, Duration: &metav1.Duration{Duration: 2 * time.Hour}, Usages: []cmapi.KeyUsage{cmapi.UsageClientAuth}}),
+				policyWithDefaults("c", &policyapi.CertificateRequestPolicyDefaults{Priority: 1, Duration: &metav1.Duration{Duration: 3 * time.Hour}}),
+			},
+			expObj: gen.CertificateRequest("test", gen.SetCertificateRequestCSR(csr),
+				gen.SetCertificateRequestDuration(&metav1.Duration{Duration: 2 * time.Hour}),
+				gen.SetCertificateRequestKeyUsages(cmapi.UsageClientAuth),
+			),
+		},
+		"if the request already has a duration and its CSR requests key usages, do not change them": {
+			obj: gen.CertificateRequest("test", gen.SetCertificateRequestCSR(csrWithUsages),
+				gen.SetCertificateRequestDuration(&metav1.Duration{Duration: time.Minute}),
+			),
+			policies: []policyapi.CertificateRequestPolicy{
+				policyWithDefaults("a", &policyapi.CertificateRequestPolicyDefaults{Duration: &metav1.Duration{Duration: time.Hour}, Usages: []cmapi.KeyUsage{cmapi.UsageServerAuth}}),
+			},
+			expObj: gen.CertificateRequest("test", gen.SetCertificateRequestCSR(csrWithUsages),
+				gen.SetCertificateRequestDuration(&metav1.Duration{Duration: time.Minute}),
+			),
+		},
+		"if a Certificate has no duration or usages, set the defaults": {
+			obj: gen.Certificate("test"),
+			policies: []policyapi.CertificateRequestPolicy{
+				policyWithDefaults("a", &policyapi.CertificateRequestPolicyDefaults{Duration: &metav1.Duration{Duration: time.Hour}, Usages: []cmapi.KeyUsage{cmapi.UsageServerAuth}}),
+			},
+			expObj: gen.Certificate("test",
+				gen.SetCertificateDuration(&metav1.Duration{Duration: time.Hour}),
+				gen.SetCertificateKeyUsages(cmapi.UsageServerAuth),
+			),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := &requestDefaulter{
+				log: ktesting.NewLogger(t, ktesting.DefaultConfig),
+				selector: selectorFunc(func(_ context.Context, cr *cmapi.CertificateRequest) ([]policyapi.CertificateRequestPolicy, error) {
+					assert.Equal(t, "alice", cr.Spec.Username, "expected the requester to be the admission user")
+					return test.policies, nil
+				}),
+			}
+
+			ctx := admission.NewContextWithRequest(t.Context(), admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{UserInfo: authenticationv1.UserInfo{Username: "alice"}},
+			})
+
+			assert.NoError(t, d.Default(ctx, test.obj))
+			assert.Equal(t, test.expObj, test.obj)
+		})
+	}
+}
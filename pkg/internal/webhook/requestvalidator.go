@@ -0,0 +1,221 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"crypto"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"sync"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/go-logr/logr"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/cert-manager/approver-policy/pkg/approver/manager"
+)
+
+// requestValidator rejects CertificateRequests and Certificates which would be
+// denied by the CertificateRequestPolicies applicable to them, so that policy
+// violations are reported when the object is applied rather than
+// asynchronously as a Denied condition.
+type requestValidator struct {
+	log logr.Logger
+
+	// manager reviews requests with the same policies and evaluators as the
+	// CertificateRequest controller.
+	manager manager.Interface
+
+	// csrOptions build the CSR for Certificates the same way as cert-manager,
+	// according to its configured feature gates.
+	csrOptions []utilpki.GenerateCSROption
+
+	// keys holds the throwaway private keys which sign the CSRs built for
+	// Certificates.
+	keys throwawayKeys
+}
+
+var _ admission.CustomValidator = &requestValidator{}
+
+// ValidateCreate rejects CertificateRequests and Certificates which would be
+// denied.
+func (v *requestValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return v.validate(ctx, obj)
+}
+
+// ValidateUpdate rejects Certificates which would be denied after the update.
+// The spec of CertificateRequests is immutable, and updates which don't change
+// the spec of a Certificate don't change the request, so neither are reviewed
+// again.
+func (v *requestValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	crt, ok := newObj.(*cmapi.Certificate)
+	if !ok {
+		return nil, nil
+	}
+	if oldCrt, ok := oldObj.(*cmapi.Certificate); ok && apiequality.Semantic.DeepEqual(oldCrt.Spec, crt.Spec) {
+		return nil, nil
+	}
+	return v.validate(ctx, newObj)
+}
+
+// ValidateDelete is a no-op.
+func (v *requestValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate reviews the CertificateRequest, or the CertificateRequest that
+// would be created for the Certificate, as requested by the admission user.
+// Only requests which would be denied are rejected; requests that no policy
+// applies to, or which are pending, are admitted.
+func (v *requestValidator) validate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var cr *cmapi.CertificateRequest
+	switch obj := obj.(type) {
+	case *cmapi.CertificateRequest:
+		// The requester may not have been set on the request yet, since the
+		// order of mutating webhooks is not guaranteed.
+		cr = obj.DeepCopy()
+		if cr.Spec.Username == "" {
+			setRequester(cr, req.UserInfo)
+		}
+
+	case *cmapi.Certificate:
+		pk, err := v.keys.get(obj)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate private key for Certificate: %w", err)
+		}
+		cr, err = certificateRequestForCertificate(obj, pk, v.csrOptions...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build CertificateRequest for Certificate: %w", err)
+		}
+		setRequester(cr, req.UserInfo)
+
+	default:
+		return nil, fmt.Errorf("expected a CertificateRequest or Certificate, but got a %T", obj)
+	}
+
+	response, err := v.manager.Review(ctx, cr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to review request: %w", err)
+	}
+
+	if response.Result == manager.ResultDenied {
+		v.log.V(2).Info("rejecting request", "namespace", cr.Namespace, "kind", req.Kind.Kind, "name", req.Name, "message", response.Message)
+		return nil, errors.New(response.Message)
+	}
+
+	return nil, nil
+}
+
+// setRequester sets the user of the admission request as the requester of
+// the CertificateRequest.
+func setRequester(cr *cmapi.CertificateRequest, user authenticationv1.UserInfo) {
+	cr.Spec.Username = user.Username
+	cr.Spec.UID = user.UID
+	cr.Spec.Groups = user.Groups
+	if len(user.Extra) > 0 {
+		cr.Spec.Extra = make(map[string][]string, len(user.Extra))
+		for k, v := range user.Extra {
+			cr.Spec.Extra[k] = v
+		}
+	}
+}
+
+// maxThrowawayKeys is the number of throwaway private keys above which all
+// keys are discarded.
+const maxThrowawayKeys = 32
+
+// throwawayKeys caches a throwaway private key for every private key
+// algorithm and size, since generating a key, especially a large RSA key, is
+// far more expensive than the review itself. The keys only sign CSRs which
+// are reviewed and discarded, so sharing them between requests is safe. The
+// zero value is ready to use.
+type throwawayKeys struct {
+	lock sync.Mutex
+	keys map[string]crypto.Signer
+}
+
+// get returns the throwaway private key of the Certificate's private key
+// algorithm and size, generating it if needed.
+func (t *throwawayKeys) get(crt *cmapi.Certificate) (crypto.Signer, error) {
+	var key string
+	if crt.Spec.PrivateKey != nil {
+		key = fmt.Sprintf("%s/%d", crt.Spec.PrivateKey.Algorithm, crt.Spec.PrivateKey.Size)
+	}
+
+	t.lock.Lock()
+	pk, ok := t.keys[key]
+	t.lock.Unlock()
+	if ok {
+		return pk, nil
+	}
+
+	pk, err := utilpki.GeneratePrivateKeyForCertificate(crt)
+	if err != nil {
+		return nil, err
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.keys == nil || len(t.keys) >= maxThrowawayKeys {
+		t.keys = make(map[string]crypto.Signer)
+	}
+	t.keys[key] = pk
+	return pk, nil
+}
+
+// certificateRequestForCertificate builds the CertificateRequest that
+// cert-manager would create for the Certificate. The CSR is signed with pk, a
+// throwaway private key of the Certificate's private key algorithm and size.
+// opts must match the feature gates of the cert-manager controller, otherwise
+// the CSR may differ from the one cert-manager builds.
+func certificateRequestForCertificate(crt *cmapi.Certificate, pk crypto.Signer, opts ...utilpki.GenerateCSROption) (*cmapi.CertificateRequest, error) {
+	template, err := utilpki.GenerateCSR(crt, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	csr, err := utilpki.EncodeCSR(template, pk)
+	if err != nil {
+		return nil, err
+	}
+
+	cr := &cmapi.CertificateRequest{}
+	cr.Namespace = crt.Namespace
+	cr.Name = crt.Name
+	cr.Annotations = map[string]string{
+		cmapi.CertificateNameKey: crt.Name,
+	}
+	cr.Spec = cmapi.CertificateRequestSpec{
+		Duration:  crt.Spec.Duration,
+		IssuerRef: crt.Spec.IssuerRef,
+		Request:   pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csr}),
+		IsCA:      crt.Spec.IsCA,
+		Usages:    crt.Spec.Usages,
+	}
+	return cr, nil
+}
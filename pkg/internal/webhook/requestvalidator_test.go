@@ -0,0 +1,208 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2/ktesting"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/cert-manager/approver-policy/pkg/approver/manager"
+	fakemanager "github.com/cert-manager/approver-policy/pkg/approver/manager/fake"
+)
+
+func Test_requestValidator(t *testing.T) {
+	csr, _, err := gen.CSR(x509.ECDSA, gen.SetCSRDNSNames("example.com"))
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		obj       runtime.Object
+		response  manager.ReviewResponse
+		reviewErr error
+
+		expUsername string
+		expDNSNames []string
+		expErr      string
+	}{
+		"if the request would be approved, allow it": {
+			obj:         gen.CertificateRequest("test", gen.SetCertificateRequestCSR(csr)),
+			response:    manager.ReviewResponse{Result: manager.ResultApproved},
+			expUsername: "alice",
+			expDNSNames: []string{"example.com"},
+		},
+		"if no policy applies to the request, allow it": {
+			obj:         gen.CertificateRequest("test", gen.SetCertificateRequestCSR(csr)),
+			response:    manager.ReviewResponse{Result: manager.ResultUnprocessed},
+			expUsername: "alice",
+			expDNSNames: []string{"example.com"},
+		},
+		"if the request already has a requester, review it as that requester": {
+			obj:         gen.CertificateRequest("test", gen.SetCertificateRequestCSR(csr), gen.SetCertificateRequestUsername("bob")),
+			response:    manager.ReviewResponse{Result: manager.ResultApproved},
+			expUsername: "bob",
+			expDNSNames: []string{"example.com"},
+		},
+		"if the request would be denied, reject it with the review message": {
+			obj:         gen.CertificateRequest("test", gen.SetCertificateRequestCSR(csr)),
+			response:    manager.ReviewResponse{Result: manager.ResultDenied, Message: "No policy approved this request: [a: spec.allowed.dnsNames.values: Invalid value: []string{\"example.com\"}: example.com does not match *.example.net]"},
+			expUsername: "alice",
+			expDNSNames: []string{"example.com"},
+			expErr:      "No policy approved this request: [a: spec.allowed.dnsNames.values: Invalid value: []string{\"example.com\"}: example.com does not match *.example.net]",
+		},
+		"if the review errors, return the error": {
+			obj:         gen.CertificateRequest("test", gen.SetCertificateRequestCSR(csr)),
+			reviewErr:   errors.New("this is an error"),
+			expUsername: "alice",
+			expDNSNames: []string{"example.com"},
+			expErr:      "failed to review request: this is an error",
+		},
+		"if a Certificate would be denied, reject it": {
+			obj: gen.Certificate("test",
+				gen.SetCertificateNamespace("test-ns"),
+				gen.SetCertificateDNSNames("example.com"),
+				gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "my-issuer", Kind: "Issuer", Group: "cert-manager.io"}),
+			),
+			response:    manager.ReviewResponse{Result: manager.ResultDenied, Message: "denied"},
+			expUsername: "alice",
+			expDNSNames: []string{"example.com"},
+			expErr:      "denied",
+		},
+		"if a Certificate would be pending, allow it": {
+			obj: gen.Certificate("test",
+				gen.SetCertificateNamespace("test-ns"),
+				gen.SetCertificateDNSNames("example.com"),
+			),
+			response:    manager.ReviewResponse{Result: manager.ResultPending},
+			expUsername: "alice",
+			expDNSNames: []string{"example.com"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			v := &requestValidator{
+				log: ktesting.NewLogger(t, ktesting.DefaultConfig),
+				manager: fakemanager.NewFakeManager().WithReview(func(_ context.Context, cr *cmapi.CertificateRequest) (manager.ReviewResponse, error) {
+					assert.Equal(t, test.expUsername, cr.Spec.Username)
+					req, err := utilpki.DecodeX509CertificateRequestBytes(cr.Spec.Request)
+					require.NoError(t, err)
+					assert.Equal(t, test.expDNSNames, req.DNSNames)
+					if crt, ok := test.obj.(*cmapi.Certificate); ok {
+						assert.Equal(t, crt.Namespace, cr.Namespace)
+						assert.Equal(t, crt.Spec.IssuerRef, cr.Spec.IssuerRef)
+					}
+					return test.response, test.reviewErr
+				}),
+			}
+
+			ctx := admission.NewContextWithRequest(t.Context(), admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{UserInfo: authenticationv1.UserInfo{Username: "alice"}},
+			})
+
+			_, err := v.ValidateCreate(ctx, test.obj)
+			if len(test.expErr) > 0 {
+				assert.EqualError(t, err, test.expErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_requestValidatorUpdate(t *testing.T) {
+	crt := gen.Certificate("test",
+		gen.SetCertificateNamespace("test-ns"),
+		gen.SetCertificateDNSNames("example.com"),
+	)
+
+	tests := map[string]struct {
+		oldObj, newObj runtime.Object
+		expReview      bool
+	}{
+		"if the spec of a Certificate is unchanged, don't review it": {
+			oldObj:    crt,
+			newObj:    gen.CertificateFrom(crt, gen.AddCertificateLabels(map[string]string{"foo": "bar"})),
+			expReview: false,
+		},
+		"if the spec of a Certificate changed, review it": {
+			oldObj:    crt,
+			newObj:    gen.CertificateFrom(crt, gen.SetCertificateDNSNames("example.net")),
+			expReview: true,
+		},
+		"if a CertificateRequest is updated, don't review it": {
+			oldObj:    gen.CertificateRequest("test"),
+			newObj:    gen.CertificateRequest("test", gen.AddCertificateRequestAnnotations(map[string]string{"foo": "bar"})),
+			expReview: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var reviewed bool
+			v := &requestValidator{
+				log: ktesting.NewLogger(t, ktesting.DefaultConfig),
+				manager: fakemanager.NewFakeManager().WithReview(func(_ context.Context, _ *cmapi.CertificateRequest) (manager.ReviewResponse, error) {
+					reviewed = true
+					return manager.ReviewResponse{Result: manager.ResultDenied, Message: "denied"}, nil
+				}),
+			}
+
+			ctx := admission.NewContextWithRequest(t.Context(), admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{UserInfo: authenticationv1.UserInfo{Username: "alice"}},
+			})
+
+			_, err := v.ValidateUpdate(ctx, test.oldObj, test.newObj)
+			assert.Equal(t, test.expReview, reviewed)
+			if test.expReview {
+				assert.EqualError(t, err, "denied")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_throwawayKeys(t *testing.T) {
+	var keys throwawayKeys
+
+	rsa, err := keys.get(gen.Certificate("a"))
+	require.NoError(t, err)
+	ecdsa, err := keys.get(gen.Certificate("b", gen.SetCertificateKeyAlgorithm(cmapi.ECDSAKeyAlgorithm)))
+	require.NoError(t, err)
+	assert.NotEqual(t, rsa, ecdsa)
+
+	again, err := keys.get(gen.Certificate("c"))
+	require.NoError(t, err)
+	assert.Same(t, rsa, again, "expected the key to be reused for the same algorithm and size")
+
+	_, err = keys.get(gen.Certificate("d", gen.SetCertificateKeyAlgorithm("bad-alg")))
+	assert.Error(t, err)
+	assert.Len(t, keys.keys, 2)
+}
@@ -32,6 +32,7 @@ import (
 
 	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
 	"github.com/cert-manager/approver-policy/pkg/approver"
+	"github.com/cert-manager/approver-policy/pkg/internal/shadow"
 )
 
 // validator validates against policy.cert-manager.io resources.
@@ -39,6 +40,7 @@ type validator struct {
 	log logr.Logger
 
 	registeredPlugins []string
+	schemas           map[string]approver.PluginSchema
 	webhooks          []approver.Webhook
 
 	lister client.Reader
@@ -96,6 +98,13 @@ func (v *validator) validate(ctx context.Context, obj runtime.Object) (admission
 		}
 	}
 
+	// Default and validate plugin values against the schema declared by their
+	// plugin. Webhooks are passed the defaulted policy, so that the same values
+	// are validated regardless of whether the policy has been through the
+	// defaulting webhook.
+	policy, schemaErrs := approver.DefaultAndValidatePlugins(v.schemas, policy)
+	fieldErrs = append(fieldErrs, schemaErrs...)
+
 	if policy.Spec.Selector.IssuerRef == nil && policy.Spec.Selector.Namespace == nil {
 		fieldErrs = append(fieldErrs, field.Required(fldPath.Child("selector"), "one of issuerRef or namespace must be defined, hint: `{}` on either matches everything"))
 	}
@@ -106,6 +115,10 @@ func (v *validator) validate(ctx context.Context, obj runtime.Object) (admission
 		}
 	}
 
+	if defaults := policy.Spec.Defaults; defaults != nil && defaults.Duration != nil && defaults.Duration.Duration <= 0 {
+		fieldErrs = append(fieldErrs, field.Invalid(fldPath.Child("defaults", "duration"), defaults.Duration.Duration.String(), "duration must be a value greater than 0"))
+	}
+
 	allAllowed := true
 	for _, webhook := range v.webhooks {
 		response, err := webhook.Validate(ctx, policy)
@@ -120,6 +133,18 @@ func (v *validator) validate(ctx context.Context, obj runtime.Object) (admission
 		warnings = append(warnings, response.Warnings...)
 	}
 
+	// Warn when the policy is made pointless by, or makes pointless, another
+	// policy. This never rejects the policy, since which policies a requester
+	// is bound to is not known here.
+	var policies policyapi.CertificateRequestPolicyList
+	if err := v.lister.List(ctx, &policies); err != nil {
+		v.log.Error(err, "failed to list CertificateRequestPolicies to detect shadowed policies")
+	} else {
+		for _, finding := range shadow.Find(policy, policies.Items) {
+			warnings = append(warnings, fmt.Sprintf("this CertificateRequestPolicy %s", finding))
+		}
+	}
+
 	var errs []error
 
 	if aggregateError := fieldErrs.ToAggregate(); aggregateError != nil {
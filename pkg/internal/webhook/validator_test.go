@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
@@ -59,6 +60,8 @@ func Test_validate(t *testing.T) {
 		crp               runtime.Object
 		webhooks          []approver.Webhook
 		registeredPlugins []string
+		schemas           map[string]approver.PluginSchema
+		existingPolicies  []runtime.Object
 
 		expectedWarnings admission.Warnings
 		expectedError    *string
@@ -80,6 +83,55 @@ func Test_validate(t *testing.T) {
 
 			expectedError: ptr.To("[spec.plugins: Unsupported value: \"bar\": supported values: \"foo\", \"baz\", spec.selector: Required value: one of issuerRef or namespace must be defined, hint: `{}` on either matches everything]"),
 		},
+		"if plugin values do not match the plugin schema, return an error": {
+			crp: &policyapi.CertificateRequestPolicy{
+				TypeMeta:   testTypeMeta,
+				ObjectMeta: testObjectMeta,
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Plugins: map[string]policyapi.CertificateRequestPolicyPluginData{
+						"foo": {Values: map[string]string{"enabled": "maybe", "unknown": "value"}},
+					},
+					Selector: policyapi.CertificateRequestPolicySelector{
+						IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{},
+					},
+				},
+			},
+			registeredPlugins: []string{"foo"},
+			schemas: map[string]approver.PluginSchema{
+				"foo": {Values: []approver.PluginValueSchema{
+					{Key: "enabled", Type: approver.PluginValueTypeBool},
+					{Key: "mode", Required: true},
+				}},
+			},
+
+			expectedError: ptr.To("[spec.plugins.foo.values[enabled]: Invalid value: \"maybe\": must be of type bool: strconv.ParseBool: parsing \"maybe\": invalid syntax, spec.plugins.foo.values[mode]: Required value, spec.plugins.foo.values: Unsupported value: \"unknown\": supported values: \"enabled\", \"mode\"]"),
+		},
+		"if plugin values are defaulted by the plugin schema, webhooks receive the defaulted values": {
+			crp: &policyapi.CertificateRequestPolicy{
+				TypeMeta:   testTypeMeta,
+				ObjectMeta: testObjectMeta,
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Plugins: map[string]policyapi.CertificateRequestPolicyPluginData{
+						"foo": {Values: map[string]string{}},
+					},
+					Selector: policyapi.CertificateRequestPolicySelector{
+						IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{},
+					},
+				},
+			},
+			registeredPlugins: []string{"foo"},
+			schemas: map[string]approver.PluginSchema{
+				"foo": {Values: []approver.PluginValueSchema{
+					{Key: "mode", Required: true, Enum: []string{"strict", "lenient"}, Default: "strict"},
+				}},
+			},
+			webhooks: []approver.Webhook{fakeapprover.NewFakeWebhook().WithValidate(func(_ context.Context, policy *policyapi.CertificateRequestPolicy) (approver.WebhookValidationResponse, error) {
+				if mode := policy.Spec.Plugins["foo"].Values["mode"]; mode != "strict" {
+					return approver.WebhookValidationResponse{Allowed: false, Errors: field.ErrorList{field.Invalid(field.NewPath("mode"), mode, "not defaulted")}}, nil
+				}
+				return approver.WebhookValidationResponse{Allowed: true}, nil
+			})},
+		},
 		"if neither issuer ref nor namespace are defined, return error": {
 			crp: &policyapi.CertificateRequestPolicy{
 				TypeMeta:   testTypeMeta,
@@ -109,6 +161,22 @@ func Test_validate(t *testing.T) {
 
 			expectedError: ptr.To("spec.selector.namespace.matchLabels: Invalid value: map[string]string{\"$%234\":\"8dsdk\"}: key: Invalid value: \"$%234\": name part must consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my.name',  or '123-abc', regex used for validation is '([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]')"),
 		},
+		"if a non-positive default duration is defined, return error": {
+			crp: &policyapi.CertificateRequestPolicy{
+				TypeMeta:   testTypeMeta,
+				ObjectMeta: testObjectMeta,
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Selector: policyapi.CertificateRequestPolicySelector{
+						IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{},
+					},
+					Defaults: &policyapi.CertificateRequestPolicyDefaults{
+						Duration: &metav1.Duration{Duration: -time.Hour},
+					},
+				},
+			},
+
+			expectedError: ptr.To("spec.defaults.duration: Invalid value: \"-1h0m0s\": duration must be a value greater than 0"),
+		},
 		"if a registered webhook does not allow CertificateRequestPolicy, return an error": {
 			crp: &policyapi.CertificateRequestPolicy{
 				TypeMeta:   testTypeMeta,
@@ -206,15 +274,70 @@ func Test_validate(t *testing.T) {
 			registeredPlugins: []string{"foo", "bar"},
 			webhooks:          []approver.Webhook{passingWebhook},
 		},
+		"if the CertificateRequestPolicy shadows or is shadowed by existing policies, allow it with warnings": {
+			crp: &policyapi.CertificateRequestPolicy{
+				TypeMeta:   testTypeMeta,
+				ObjectMeta: testObjectMeta,
+				Spec: policyapi.CertificateRequestPolicySpec{
+					Allowed: &policyapi.CertificateRequestPolicyAllowed{
+						DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"*.example.com"}},
+					},
+					Selector: policyapi.CertificateRequestPolicySelector{
+						IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{Name: ptr.To("my-issuer")},
+					},
+				},
+			},
+			existingPolicies: []runtime.Object{
+				&policyapi.CertificateRequestPolicy{
+					ObjectMeta: metav1.ObjectMeta{Name: "broader"},
+					Spec: policyapi.CertificateRequestPolicySpec{
+						Allowed: &policyapi.CertificateRequestPolicyAllowed{
+							DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"*"}},
+						},
+						Selector: policyapi.CertificateRequestPolicySelector{
+							IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{},
+						},
+					},
+				},
+				&policyapi.CertificateRequestPolicy{
+					ObjectMeta: metav1.ObjectMeta{Name: "stricter"},
+					Spec: policyapi.CertificateRequestPolicySpec{
+						Allowed: &policyapi.CertificateRequestPolicyAllowed{
+							DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"*.foo.example.com"}},
+						},
+						Selector: policyapi.CertificateRequestPolicySelector{
+							IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{Name: ptr.To("my-issuer")},
+							Namespace: &policyapi.CertificateRequestPolicySelectorNamespace{MatchNames: []string{"team-a"}},
+						},
+					},
+				},
+				&policyapi.CertificateRequestPolicy{
+					ObjectMeta: metav1.ObjectMeta{Name: "unrelated"},
+					Spec: policyapi.CertificateRequestPolicySpec{
+						Allowed: &policyapi.CertificateRequestPolicyAllowed{
+							DNSNames: &policyapi.CertificateRequestPolicyAllowedStringSlice{Values: &[]string{"*.example.net"}},
+						},
+						Selector: policyapi.CertificateRequestPolicySelector{
+							IssuerRef: &policyapi.CertificateRequestPolicySelectorIssuerRef{Name: ptr.To("my-issuer")},
+						},
+					},
+				},
+			},
+			expectedWarnings: admission.Warnings{
+				`this CertificateRequestPolicy is shadowed by CertificateRequestPolicy "broader": "broader" selects and permits every request this policy does, so this policy has no effect for requesters bound to both`,
+				`this CertificateRequestPolicy shadows CertificateRequestPolicy "stricter": it selects and permits every request "stricter" does, so "stricter" has no effect for requesters bound to both`,
+			},
+		},
 	}
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
 			fakeclient := fakeclient.NewClientBuilder().
 				WithScheme(policyapi.GlobalScheme).
+				WithRuntimeObjects(test.existingPolicies...).
 				Build()
 
-			v := &validator{lister: fakeclient, log: ktesting.NewLogger(t, ktesting.DefaultConfig), webhooks: test.webhooks, registeredPlugins: test.registeredPlugins}
+			v := &validator{lister: fakeclient, log: ktesting.NewLogger(t, ktesting.DefaultConfig), webhooks: test.webhooks, registeredPlugins: test.registeredPlugins, schemas: test.schemas}
 			gotWarnings, gotErr := v.validate(t.Context(), test.crp)
 			if test.expectedError == nil && gotErr != nil {
 				t.Errorf("unexpected error: %v", gotErr)
@@ -20,12 +20,17 @@ import (
 	"context"
 	"fmt"
 
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	policyapi "github.com/cert-manager/approver-policy/pkg/apis/policy/v1alpha1"
 	"github.com/cert-manager/approver-policy/pkg/approver"
+	internalmanager "github.com/cert-manager/approver-policy/pkg/internal/approver/manager"
+	"github.com/cert-manager/approver-policy/pkg/internal/approver/manager/predicate"
+	"github.com/cert-manager/approver-policy/pkg/internal/metrics"
 	"github.com/cert-manager/approver-policy/pkg/registry"
 )
 
@@ -38,6 +43,24 @@ type Options struct {
 	// shared webhook server.
 	Webhooks []approver.Webhook
 
+	// Evaluators are the registered Evaluators used to review
+	// CertificateRequests and Certificates on admission.
+	Evaluators []approver.Evaluator
+
+	// PluginSchemas are the schemas of the registered Approvers, keyed by
+	// Approver name, used to default and validate plugin values.
+	PluginSchemas map[string]approver.PluginSchema
+
+	// Authorizer decides whether the user of a CertificateRequest is bound to
+	// a CertificateRequestPolicy. Defaults to creating a SubjectAccessReview
+	// for every decision.
+	Authorizer predicate.Authorizer
+
+	// CertManagerFeatureGates are the feature gates of the cert-manager
+	// controller, used to build the same CSR as cert-manager for Certificates.
+	// Feature gates which are not given take cert-manager's default.
+	CertManagerFeatureGates map[string]bool
+
 	// Manager is the shared controller-runtime manager used by this
 	// approver-policy instance. The webhook will register its endpoints and
 	// runnables against.
@@ -50,8 +73,8 @@ func Register(ctx context.Context, opts Options) error {
 	log := opts.Log.WithName("webhook")
 
 	var registerdPlugins []string
-	for _, approver := range registry.Shared.Approvers() {
-		if name := approver.Name(); name != "allowed" && name != "constraints" {
+	for _, a := range registry.Shared.Approvers() {
+		if name := a.Name(); name != "allowed" && name != "constraints" {
 			registerdPlugins = append(registerdPlugins, name)
 		}
 	}
@@ -62,16 +85,53 @@ func Register(ctx context.Context, opts Options) error {
 		lister:            opts.Manager.GetCache(),
 		webhooks:          opts.Webhooks,
 		registeredPlugins: registerdPlugins,
+		schemas:           opts.PluginSchemas,
+	}
+	defaulter := &defaulter{
+		log:     log.WithName("defaulting"),
+		schemas: opts.PluginSchemas,
 	}
 
 	err := builder.WebhookManagedBy(opts.Manager).
 		For(&policyapi.CertificateRequestPolicy{}).
+		WithDefaulter(defaulter).
 		WithValidator(validator).
 		Complete()
 	if err != nil {
 		return fmt.Errorf("error registering webhook: %v", err)
 	}
 
+	authorizer := opts.Authorizer
+	if authorizer == nil {
+		authorizer = predicate.NewSubjectAccessReviewAuthorizer(opts.Manager.GetClient())
+	}
+
+	requestDefaulter := &requestDefaulter{
+		log:      log.WithName("request-defaulting"),
+		selector: internalmanager.NewSelector(metrics.CallerWebhook, opts.Manager.GetCache(), authorizer),
+	}
+
+	csrOpts, err := csrOptions(opts.CertManagerFeatureGates)
+	if err != nil {
+		return err
+	}
+
+	requestValidator := &requestValidator{
+		log:        log.WithName("request-validation"),
+		manager:    internalmanager.New(metrics.CallerWebhook, opts.Manager.GetCache(), authorizer, opts.Evaluators),
+		csrOptions: csrOpts,
+	}
+
+	for _, obj := range []runtime.Object{&cmapi.CertificateRequest{}, &cmapi.Certificate{}} {
+		if err := builder.WebhookManagedBy(opts.Manager).
+			For(obj).
+			WithDefaulter(requestDefaulter).
+			WithValidator(requestValidator).
+			Complete(); err != nil {
+			return fmt.Errorf("error registering %T webhook: %v", obj, err)
+		}
+	}
+
 	if err := opts.Manager.AddReadyzCheck("validator", opts.Manager.GetWebhookServer().StartedChecker()); err != nil {
 		return fmt.Errorf("error adding readyz check: %v", err)
 	}